@@ -1,38 +1,179 @@
 package main
 
 import (
+	"go-api-server-sample/cmd/api-server/internal/api/apikey"
+	"go-api-server-sample/cmd/api-server/internal/api/author"
 	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/api/contenttype"
 	"go-api-server-sample/cmd/api-server/internal/api/health"
+	"go-api-server-sample/cmd/api-server/internal/api/tag"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/metrics"
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/config"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
+	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // Container は依存性注入コンテナ
 type Container struct {
+	// Config は読み込んで検証済みのアプリケーション全体の設定
+	Config *config.Config
+
 	// APIs
-	ContentAPI *content.ContentAPI
-	HealthAPI  *health.HealthAPI
+	ContentAPI     *content.ContentAPI
+	ContentTypeAPI *contenttype.ContentTypeAPI
+	TagAPI         *tag.TagAPI
+	AuthorAPI      *author.AuthorAPI
+	HealthAPI      *health.HealthAPI
+	APIKeyAPI      *apikey.APIKeyAPI
+
+	// Middlewares
+	// Authenticator は認証が無効な場合は nil
+	Authenticator gin.HandlerFunc
+	// Authorize はルートごとに必要な操作の権限を確認するミドルウェアを返す
+	Authorize func(middleware.Permission) gin.HandlerFunc
+	// RateLimiter と IPRateLimiter はレート制限が無効な場合は nil
+	RateLimiter   gin.HandlerFunc
+	IPRateLimiter gin.HandlerFunc
+	// Idempotency は Idempotency-Key ヘッダーによる再送の重複を防ぐ
+	Idempotency gin.HandlerFunc
+
+	// Metrics はメトリクスが無効な場合は nil
+	Metrics *metrics.Metrics
+
+	// Workers
+	TrashPurger *content.TrashPurger
+	// ContentTypeRefresher は他のインスタンスで変更されたコンテンツタイプを読み込み直す
+	ContentTypeRefresher *contenttype.Refresher
 
 	// Repositories
-	ContentRepository content.ContentRepository
+	ContentRepository     content.ContentRepository
+	ContentTypeRepository contenttype.ContentTypeRepository
+	TagRepository         tag.TagRepository
+	AuthorRepository      author.AuthorRepository
+	APIKeyRepository      apikey.APIKeyRepository
+	IdempotencyStore      middleware.IdempotencyStore
 }
 
 // NewContainer は新しいContainerインスタンスを作成する
-func NewContainer(db *gorm.DB) *Container {
-	container := &Container{}
+func NewContainer(db *gorm.DB, cfg *config.Config) (*Container, error) {
+	container := &Container{Config: cfg}
 
 	container.initRepositories(db)
-	container.initAPIs(db)
+	if err := container.initMetrics(db, cfg); err != nil {
+		return nil, err
+	}
+	container.initAPIs(db, cfg)
+	container.initHealthChecks(db, cfg)
+	container.initWorkers(cfg)
+
+	if err := container.initMiddlewares(cfg); err != nil {
+		return nil, err
+	}
 
-	return container
+	return container, nil
 }
 
 func (c *Container) initRepositories(db *gorm.DB) {
 	c.ContentRepository = repositories.NewContentRepository(db)
+	c.ContentTypeRepository = repositories.NewContentTypeRepository(db)
+	c.TagRepository = repositories.NewTagRepository(db)
+	c.AuthorRepository = repositories.NewAuthorRepository(db)
+	c.APIKeyRepository = repositories.NewAPIKeyRepository(db)
+	c.IdempotencyStore = repositories.NewIdempotencyKeyRepository(db)
 }
 
-func (c *Container) initAPIs(db *gorm.DB) {
-	c.ContentAPI = content.NewContentAPI(c.ContentRepository)
-	c.HealthAPI = health.NewHealthAPI(db)
+func (c *Container) initMetrics(db *gorm.DB, cfg *config.Config) error {
+	if !cfg.Metrics.Enabled {
+		return nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	c.Metrics = metrics.New(sqlDB, cfg.Database.Database)
+	return nil
+}
+
+func (c *Container) initAPIs(db *gorm.DB, cfg *config.Config) {
+	// コンテンツの検証で参照するコンテンツタイプは、コンテンツタイプの変更時と定期的にデータベースから読み込み直す
+	contentTypes := entities.NewDefaultContentTypeRegistry()
+
+	contentOpts := []content.Option{
+		content.WithContentTypes(contentTypes),
+		content.WithIfMatchRequired(cfg.Content.RequireIfMatch),
+		content.WithCursorSecret(cfg.Content.CursorSecret.Reveal()),
+		content.WithTrashRetention(cfg.Content.TrashRetention),
+	}
+	if c.Metrics != nil {
+		contentOpts = append(contentOpts, content.WithEventRecorder(c.Metrics))
+	}
+	c.ContentAPI = content.NewContentAPI(c.ContentRepository, contentOpts...)
+	c.ContentTypeAPI = contenttype.NewContentTypeAPI(c.ContentTypeRepository, contentTypes)
+	c.TagAPI = tag.NewTagAPI(c.TagRepository)
+	c.AuthorAPI = author.NewAuthorAPI(c.AuthorRepository)
+	c.APIKeyAPI = apikey.NewAPIKeyAPI(c.APIKeyRepository)
+}
+
+// initHealthChecks は /readyz と /health で確認する依存先を登録する
+func (c *Container) initHealthChecks(db *gorm.DB, cfg *config.Config) {
+	c.HealthAPI = health.NewHealthAPI(
+		health.WithCacheTTL(cfg.Health.CacheTTL),
+		health.WithCheckTimeout(cfg.Health.CheckTimeout),
+	)
+	c.HealthAPI.Register(health.DatabaseCheck, database.PingCheck(db))
+	c.HealthAPI.Register("migrations", database.SchemaVersionCheck(db))
+	c.HealthAPI.Register("connection_pool", database.PoolSaturationCheck(db, cfg.Health.PoolMaxUsage), health.NonCritical())
+	if cfg.Health.DiskPath != "" {
+		minFree := uint64(cfg.Health.DiskMinFreeMB) << 20
+		c.HealthAPI.Register("disk", health.DiskSpaceCheck(cfg.Health.DiskPath, minFree), health.NonCritical())
+	}
+}
+
+func (c *Container) initWorkers(cfg *config.Config) {
+	c.ContentTypeRefresher = contenttype.NewRefresher(c.ContentTypeAPI, cfg.Content.ContentTypeRefreshInterval)
+	c.TrashPurger = content.NewTrashPurger(
+		c.ContentRepository,
+		cfg.Content.TrashRetention,
+		cfg.Content.TrashPurgeInterval,
+	)
+}
+
+func (c *Container) initMiddlewares(cfg *config.Config) error {
+	c.Idempotency = middleware.Idempotency(c.IdempotencyStore, cfg.Content.IdempotencyTTL, cfg.Content.IdempotencyLease)
+
+	if cfg.RateLimit.Enabled {
+		store := middleware.NewMemoryRateLimitStore()
+		c.IPRateLimiter = middleware.IPRateLimiter(store, middleware.RateLimit{Requests: cfg.RateLimit.IPRequests, Period: cfg.RateLimit.Period})
+		c.RateLimiter = middleware.RateLimiter(store, middleware.RateLimits{
+			Read:  middleware.RateLimit{Requests: cfg.RateLimit.ReadRequests, Period: cfg.RateLimit.Period},
+			Write: middleware.RateLimit{Requests: cfg.RateLimit.WriteRequests, Period: cfg.RateLimit.Period},
+		})
+	}
+
+	if !cfg.Auth.Enabled {
+		c.Authorize = middleware.AllowAll
+		return nil
+	}
+
+	verifier, err := middleware.NewJWTVerifier(middleware.JWTConfig{
+		HS256Secret:        cfg.Auth.JWTHS256Secret.Reveal(),
+		RS256PublicKeyFile: cfg.Auth.JWTRS256PublicKeyFile,
+		JWKSFile:           cfg.Auth.JWTJWKSFile,
+		Issuer:             cfg.Auth.JWTIssuer,
+		Audience:           cfg.Auth.JWTAudience,
+		Leeway:             cfg.Auth.JWTLeeway,
+	})
+	if err != nil {
+		return err
+	}
+	c.Authenticator = middleware.Authenticate(verifier, middleware.WithAPIKeys(c.APIKeyAPI))
+	c.Authorize = middleware.Authorize
+
+	return nil
 }
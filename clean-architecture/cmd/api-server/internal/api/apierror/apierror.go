@@ -0,0 +1,161 @@
+package apierror
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Kind はエラーの分類で、レスポンスのHTTPステータスを決める
+type Kind int
+
+const (
+	KindValidation Kind = iota + 1
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindUnprocessable
+	KindPreconditionRequired
+	KindTooManyRequests
+	KindInternal
+)
+
+var kindStatus = map[Kind]int{
+	KindValidation:           http.StatusBadRequest,
+	KindUnauthorized:         http.StatusUnauthorized,
+	KindForbidden:            http.StatusForbidden,
+	KindNotFound:             http.StatusNotFound,
+	KindConflict:             http.StatusConflict,
+	KindPreconditionFailed:   http.StatusPreconditionFailed,
+	KindUnprocessable:        http.StatusUnprocessableEntity,
+	KindPreconditionRequired: http.StatusPreconditionRequired,
+	KindTooManyRequests:      http.StatusTooManyRequests,
+	KindInternal:             http.StatusInternalServerError,
+}
+
+// Error はAPIのエラーレスポンスの元になるエラー
+// ハンドラーは Abort でエラーを記録し、middleware.ErrorHandler が application/problem+json のレスポンスに変換する
+type Error struct {
+	Kind Kind
+	// Code はエラーの種類を表す安定した識別子（例: content_not_found）で、クライアントはこの値で分岐する
+	Code string
+	// Message はエラーの種類ごとの概要
+	Message string
+	// Detail はこのリクエストに固有の説明で、クライアントに返してよい内容のみを設定する
+	Detail string
+	// Fields はリクエストの項目ごとの検証エラー
+	Fields []FieldError
+	// Err は内部の原因で、リリースモードではクライアントに返さない
+	Err error
+	// reason は Detail の元になったエラーで、Localize で翻訳する
+	reason error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	if e.Detail != "" {
+		return e.Message + ": " + e.Detail
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status はエラーのHTTPステータスを返す
+func (e *Error) Status() int {
+	if status, ok := kindStatus[e.Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDetail はリクエストに固有の説明を設定したエラーを返す（e は変更しない）
+func (e *Error) WithDetail(detail string) *Error {
+	copied := *e
+	copied.Detail = detail
+	return &copied
+}
+
+// WithReason はクライアントに返してよいエラーを説明に設定したエラーを返す（e は変更しない）
+// Reason やドメイン層の検証エラーはレスポンスの言語に翻訳される
+func (e *Error) WithReason(reason error) *Error {
+	copied := e.WithDetail(reason.Error())
+	copied.reason = reason
+	return copied
+}
+
+// Localize は概要・説明・項目の検証エラーを lang に翻訳したエラーを返す（e は変更しない）
+// カタログにないメッセージは翻訳せずにそのまま返す
+func (e *Error) Localize(lang string) *Error {
+	copied := *e
+	copied.Message = i18n.Format(lang, "problem."+e.Code, e.Message)
+	if e.reason != nil {
+		if detail, ok := localizeReason(lang, e.reason); ok {
+			copied.Detail = detail
+		}
+	}
+	if e.Fields != nil {
+		copied.Fields = make([]FieldError, len(e.Fields))
+		for i, field := range e.Fields {
+			copied.Fields[i] = localizeField(lang, field)
+		}
+	}
+	return &copied
+}
+
+// New は指定した分類のエラーを作成する
+func New(kind Kind, code, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Validation はリクエストの内容が不正であることを表すエラーを作成する
+// cause はドメインの検証エラーなど、クライアントに返してよいエラーを指定する
+func Validation(code, message string, cause error) *Error {
+	e := New(KindValidation, code, message)
+	if cause != nil {
+		return e.WithReason(cause)
+	}
+	return e
+}
+
+// NotFound は対象のリソースが存在しないことを表すエラーを作成する
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict はリソースの現在の状態と矛盾する操作であることを表すエラーを作成する
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Forbidden は操作の権限がないことを表すエラーを作成する
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// Internal はサーバー内部のエラーを作成する
+// message と cause はログとデバッグ用にのみ使用し、レスポンスの概要は内部エラー共通のメッセージになる
+func Internal(message string, cause error) *Error {
+	e := New(KindInternal, "internal_error", message)
+	e.Err = cause
+	return e
+}
+
+// Abort はエラーを記録して後続のハンドラーを中断する
+// レスポンスは middleware.ErrorHandler が書き込む
+func Abort(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,125 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError はリクエストの項目ごとの検証エラー
+type FieldError struct {
+	// Field はリクエストでの項目名（JSONのキーまたはクエリパラメータ名）で、配列の要素は tags[0] のように表す
+	Field string `json:"field"`
+	// Rule は満たさなかった検証ルール（required, max など）
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+
+	// key と args はメッセージカタログのキーと書式に埋め込む値で、Localize で翻訳する
+	key  string
+	args []any
+}
+
+// errMalformedRequest はJSONの構文エラーなど、項目ごとに示せないバインドのエラーの説明
+var errMalformedRequest = NewReason("malformed_request", "リクエストの形式が正しくありません")
+
+func init() {
+	// 検証エラーの項目名を構造体のフィールド名ではなくリクエストでの名前にする
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// Bind はリクエストボディのバインドに失敗したことを表すエラーを作成する
+func Bind(err error) *Error {
+	return bindError("invalid_request", "不正なリクエストです", err)
+}
+
+// BindQuery はクエリパラメータのバインドに失敗したことを表すエラーを作成する
+func BindQuery(err error) *Error {
+	return bindError("invalid_query", "不正なクエリパラメータです", err)
+}
+
+// InvalidID はパスパラメータのIDが不正であることを表すエラーを作成する
+func InvalidID(err error) *Error {
+	e := InvalidPathParam("invalid_id", "不正なIDです", "id")
+	e.Err = err
+	return e
+}
+
+// InvalidPathParam は正の整数を指定するパスパラメータが不正であることを表すエラーを作成する
+func InvalidPathParam(code, message, field string) *Error {
+	e := New(KindValidation, code, message)
+	e.Fields = []FieldError{newFieldError(field, "uint", "field.positive_integer")}
+	return e
+}
+
+func bindError(code, message string, err error) *Error {
+	e := New(KindValidation, code, message)
+	e.Err = err
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fe := range validationErrs {
+			key, args := ruleMessage(fe)
+			e.Fields = append(e.Fields, newFieldError(fieldPath(fe), fe.Tag(), key, args...))
+		}
+	case errors.As(err, &typeErr):
+		e.Fields = []FieldError{newFieldError(typeErr.Field, "type", "field.type", typeErr.Type.Kind().String())}
+	default:
+		// JSONの構文エラーなどは内部の情報を含み得るため、原因は Err にのみ保持する
+		e = e.WithReason(errMalformedRequest)
+	}
+
+	return e
+}
+
+// fieldPath は構造体名を除いた項目のパス（tags[0] など）を返す
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// ruleMessage は検証ルールに対応するメッセージカタログのキーと埋め込む値を返す
+func ruleMessage(fe validator.FieldError) (string, []any) {
+	suffix := ""
+	switch fe.Kind() {
+	case reflect.String:
+		suffix = ".string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		suffix = ".items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "field.required", nil
+	case "min", "max":
+		return "field." + fe.Tag() + suffix, []any{fe.Param()}
+	case "oneof":
+		return "field.oneof", []any{fe.Param()}
+	default:
+		return "field.invalid", nil
+	}
+}
+
+// requestFieldName はjson・formタグの名前を返す（タグがない場合はフィールド名）
+func requestFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
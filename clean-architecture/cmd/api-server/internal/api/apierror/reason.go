@@ -0,0 +1,140 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/i18n"
+	"go-api-server-sample/internal/domain/entities"
+)
+
+// Reason はクライアントに返してよいエラーの原因で、Code によりメッセージを翻訳できる
+// メッセージは既定の言語（日本語）の書式で、With で指定した値を埋め込む
+type Reason struct {
+	code    string
+	message string
+	args    []any
+	// parent は Variant の元になった原因で、メッセージの前に付ける
+	parent *Reason
+	// origin は With の元になった原因で、errors.Is で一致させる
+	origin *Reason
+}
+
+// NewReason はエラーの原因を作成する
+func NewReason(code, message string) *Reason {
+	return &Reason{code: code, message: message}
+}
+
+// Variant は r をより具体的にした原因を作成する
+// メッセージは「r のメッセージ: message」となり、errors.Is で r と一致する
+func (r *Reason) Variant(code, message string) *Reason {
+	return &Reason{code: code, message: message, parent: r}
+}
+
+// With はメッセージの書式に args を埋め込んだ原因を返す（errors.Is で r と一致する）
+func (r *Reason) With(args ...any) *Reason {
+	copied := *r
+	copied.args = args
+	copied.origin = r
+	return &copied
+}
+
+// Code は原因の種類を表す識別子を返す
+func (r *Reason) Code() string {
+	return r.code
+}
+
+func (r *Reason) Error() string {
+	return r.localize(i18n.Default)
+}
+
+func (r *Reason) Is(target error) bool {
+	for p := r; p != nil; p = p.parent {
+		if p == target || (p.origin != nil && p.origin == target) {
+			return true
+		}
+	}
+	return false
+}
+
+// localize は lang のメッセージを返す（カタログにない言語の場合は既定のメッセージ）
+func (r *Reason) localize(lang string) string {
+	message := i18n.Format(lang, "reason."+r.code, r.message, r.args...)
+	if r.parent != nil {
+		return r.parent.localize(lang) + ": " + message
+	}
+	return message
+}
+
+// domainReasons はドメイン層のエラーと原因のコードの対応
+// ドメイン層は翻訳を扱わないため、API層で対応付けて翻訳する
+var domainReasons = map[error]string{
+	entities.ErrInvalidTitle:             "invalid_title",
+	entities.ErrInvalidBody:              "invalid_body",
+	entities.ErrInvalidContentType:       "unknown_content_type",
+	entities.ErrInvalidAuthor:            "invalid_author_name",
+	entities.ErrInvalidStatusTransition:  "invalid_status_transition",
+	entities.ErrRevisionContentMismatch:  "revision_content_mismatch",
+	entities.ErrInvalidAuthorEmail:       "invalid_author_email",
+	entities.ErrMergeSameAuthor:          "merge_same_author",
+	entities.ErrUnknownAuthor:            "unknown_author",
+	entities.ErrInvalidAPIKeyName:        "invalid_api_key_name",
+	entities.ErrInvalidAPIKeyScope:       "invalid_api_key_scope",
+	entities.ErrAPIKeyScopeRequired:      "api_key_scope_required",
+	entities.ErrAPIKeyExpiryInPast:       "api_key_expiry_in_past",
+	entities.ErrAPIKeyAlreadyRevoked:     "api_key_already_revoked",
+	entities.ErrInvalidFieldsSchema:      "invalid_fields_schema",
+	entities.ErrInvalidFields:            "invalid_fields",
+	entities.ErrDuplicateFieldName:       "duplicate_field_name",
+	entities.ErrInvalidContentTypeName:   "invalid_content_type_name",
+	entities.ErrInvalidMaxBodyLength:     "invalid_max_body_length",
+	entities.ErrUnsupportedRequiredField: "unsupported_required_field",
+	entities.ErrInvalidAllowedAuthor:     "invalid_allowed_author",
+	entities.ErrBodyTooLong:              "body_too_long",
+	entities.ErrMissingRequiredField:     "missing_required_field",
+	entities.ErrAuthorNotAllowedForType:  "author_not_allowed_for_type",
+	entities.ErrInvalidIdempotencyKey:    "invalid_idempotency_key",
+	entities.ErrInvalidTagName:           "invalid_tag_name",
+	entities.ErrTooManyTags:              "too_many_tags",
+	entities.ErrMergeSameTag:             "merge_same_tag",
+}
+
+// localizeReason は err に含まれる原因を lang のメッセージに翻訳する
+// 原因のメッセージの後に付加された値（フィールド名など）はそのまま残す
+// 翻訳できる原因を含まない場合は false を返す
+func localizeReason(lang string, err error) (string, bool) {
+	var reason *Reason
+	if errors.As(err, &reason) {
+		return withSuffix(err, reason.Error(), reason.localize(lang)), true
+	}
+
+	for sentinel, code := range domainReasons {
+		if errors.Is(err, sentinel) {
+			message := i18n.Format(lang, "reason."+code, sentinel.Error())
+			return withSuffix(err, sentinel.Error(), message), true
+		}
+	}
+	return "", false
+}
+
+func withSuffix(err error, source, localized string) string {
+	if suffix, ok := strings.CutPrefix(err.Error(), source); ok {
+		return localized + suffix
+	}
+	return localized
+}
+
+// localizeField は項目の検証エラーのメッセージを lang で返す
+func localizeField(lang string, field FieldError) FieldError {
+	if message, ok := i18n.Lookup(lang, field.key); ok {
+		field.Message = fmt.Sprintf(message, field.args...)
+	}
+	return field
+}
+
+// newFieldError は key のメッセージを持つ項目の検証エラーを作成する
+// メッセージは既定の言語で設定し、レスポンスの作成時に翻訳する
+func newFieldError(field, rule, key string, args ...any) FieldError {
+	return localizeField(i18n.Default, FieldError{Field: field, Rule: rule, key: key, args: args})
+}
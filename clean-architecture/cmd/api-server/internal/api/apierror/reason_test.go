@@ -0,0 +1,77 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go-api-server-sample/cmd/api-server/internal/api/i18n"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReasonTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReasonTestSuite) TestDomainReasons() {
+	suite.Run("ドメインのエラーと日本語のカタログのメッセージが一致する", func() {
+		for sentinel, code := range domainReasons {
+			message, ok := i18n.Lookup(i18n.Japanese, "reason."+code)
+			assert.True(suite.T(), ok, code)
+			assert.Equal(suite.T(), sentinel.Error(), message, code)
+		}
+	})
+}
+
+func (suite *ReasonTestSuite) TestReason() {
+	base := NewReason("invalid_sort", "並び替えの指定が不正です")
+	duplicate := base.Variant("duplicate_sort_field", "%q が重複しています")
+
+	suite.Run("Variant と With の原因は元の原因と一致する", func() {
+		err := duplicate.With("title")
+
+		assert.ErrorIs(suite.T(), err, base)
+		assert.ErrorIs(suite.T(), err, duplicate)
+		assert.Equal(suite.T(), `並び替えの指定が不正です: "title" が重複しています`, err.Error())
+	})
+
+	suite.Run("翻訳したメッセージに値を埋め込む", func() {
+		message, ok := localizeReason(i18n.English, duplicate.With("title"))
+
+		assert.True(suite.T(), ok)
+		assert.Equal(suite.T(), `The sort is invalid: "title" is specified more than once`, message)
+	})
+}
+
+func (suite *ReasonTestSuite) TestLocalize() {
+	suite.Run("概要と説明を翻訳し、原因に付加された値は残す", func() {
+		cause := fmt.Errorf("%w: summary", entities.ErrMissingRequiredField)
+		err := Validation("invalid_content", "不正なリクエストです", cause)
+
+		localized := err.Localize(i18n.English)
+
+		assert.Equal(suite.T(), "Invalid content", localized.Message)
+		assert.Equal(suite.T(), "A field required by the content type is missing: summary", localized.Detail)
+		assert.Equal(suite.T(), "不正なリクエストです", err.Message)
+	})
+
+	suite.Run("翻訳できない原因の説明はそのまま返す", func() {
+		err := Validation("invalid_content", "不正なリクエストです", errors.New("unknown"))
+
+		assert.Equal(suite.T(), "unknown", err.Localize(i18n.English).Detail)
+	})
+
+	suite.Run("項目の検証エラーを翻訳する", func() {
+		err := InvalidID(errors.New("strconv.ParseUint: invalid syntax"))
+
+		assert.Equal(suite.T(), "正の整数で指定してください", err.Fields[0].Message)
+		assert.Equal(suite.T(), "Must be a positive integer", err.Localize(i18n.English).Fields[0].Message)
+	})
+}
+
+func TestReasonTestSuite(t *testing.T) {
+	suite.Run(t, new(ReasonTestSuite))
+}
@@ -0,0 +1,39 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+)
+
+// lastUsedInterval は最終使用日時を更新する間隔で、リクエストごとの書き込みを避ける
+const lastUsedInterval = time.Minute
+
+var errAPIKeyNotFound = apierror.NotFound("api_key_not_found", "指定されたAPIキーが見つかりません")
+
+// APIKeyRepository はAPIキーの永続化を担当するリポジトリインターフェース
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	// List は失効済みを含むAPIキーを作成日時の新しい順に返す
+	List(ctx context.Context) ([]*entities.APIKey, error)
+	GetByID(ctx context.Context, id uint) (*entities.APIKey, error)
+	// GetByHash はキーのハッシュ値に一致するAPIキーを返す。存在しない場合は gorm.ErrRecordNotFound を返す
+	GetByHash(ctx context.Context, hash string) (*entities.APIKey, error)
+	Revoke(ctx context.Context, key *entities.APIKey) error
+	UpdateLastUsed(ctx context.Context, key *entities.APIKey) error
+}
+
+// APIKeyAPI はAPIKey関連のHTTPハンドラーを提供する構造体
+// APIキーによる認証のため middleware.APIKeyVerifier も実装する
+type APIKeyAPI struct {
+	repo APIKeyRepository
+}
+
+// NewAPIKeyAPI はAPIKeyAPIの新しいインスタンスを作成する
+func NewAPIKeyAPI(repo APIKeyRepository) *APIKeyAPI {
+	return &APIKeyAPI{
+		repo: repo,
+	}
+}
@@ -0,0 +1,58 @@
+package apikey
+
+import (
+	"net/http"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKeyRequest はAPIキー作成リクエストの構造体
+// ExpiresAt を省略した場合は無期限のキーになる
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyResponse はAPIキー作成レスポンスの構造体
+// Key は平文のAPIキーで、このレスポンスでのみ返す
+type CreateAPIKeyResponse struct {
+	*entities.APIKey
+	Key string `json:"key"`
+}
+
+// Create はAPIキーを発行するHTTPハンドラー
+func (api *APIKeyAPI) Create(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	// 発行者は監査のため、利用者が変更できる表示名ではなくプリンシパルの識別子で記録する
+	var createdBy string
+	if principal, ok := middleware.PrincipalFrom(c); ok {
+		createdBy = principal.Subject
+	}
+
+	key, plaintext, err := entities.NewAPIKey(req.Name, req.Scopes, req.ExpiresAt, createdBy)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_api_key_request", "不正なリクエストです", err))
+		return
+	}
+
+	if err := api.repo.Create(c.Request.Context(), key); err != nil {
+		apierror.Abort(c, apierror.Internal("APIキーの作成に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, &CreateAPIKeyResponse{
+		APIKey: key,
+		Key:    plaintext,
+	})
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAPIKeysResponse はAPIキー一覧レスポンスの構造体
+type ListAPIKeysResponse struct {
+	APIKeys []*entities.APIKey `json:"api_keys"`
+}
+
+// List はAPIキー一覧を取得するHTTPハンドラー（平文のキーは含まない）
+func (api *APIKeyAPI) List(c *gin.Context) {
+	keys, err := api.repo.List(c.Request.Context())
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("APIキー一覧の取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListAPIKeysResponse{
+		APIKeys: keys,
+	})
+}
@@ -0,0 +1,47 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Revoke はAPIキーを失効させるHTTPハンドラー
+// 失効したキーは監査のため一覧に残す
+func (api *APIKeyAPI) Revoke(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	key, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errAPIKeyNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("APIキーの取得に失敗しました", err))
+		return
+	}
+
+	if err := key.Revoke(time.Now()); err != nil {
+		apierror.Abort(c, apierror.Conflict("api_key_already_revoked", err.Error()))
+		return
+	}
+
+	if err := api.repo.Revoke(c.Request.Context(), key); err != nil {
+		apierror.Abort(c, apierror.Internal("APIキーの失効に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"gorm.io/gorm"
+)
+
+// scopePermissions はAPIキーのスコープごとに許可される操作
+// APIキーは自身が作成したコンテンツのみを所有するため、他人のコンテンツを変更する権限は付与しない
+var scopePermissions = map[string][]middleware.Permission{
+	entities.APIKeyScopeContentsRead: {
+		middleware.PermissionContentRead,
+	},
+	entities.APIKeyScopeContentsWrite: {
+		middleware.PermissionContentRead,
+		middleware.PermissionContentWrite,
+	},
+}
+
+// VerifyAPIKey は平文のAPIキーを検証し、スコープに応じた操作を許可されたプリンシパルを返す
+func (api *APIKeyAPI) VerifyAPIKey(ctx context.Context, plaintext string) (*middleware.Principal, error) {
+	key, err := api.repo.GetByHash(ctx, entities.HashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, middleware.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, middleware.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		key.LastUsedAt = &now
+		// 最終使用日時は参考情報のため、更新に失敗しても認証は継続する
+		if err := api.repo.UpdateLastUsed(ctx, key); err != nil {
+			logging.FromContext(ctx).Warn("APIキーの最終使用日時の更新に失敗しました", "api_key_id", key.ID, "error", err)
+		}
+	}
+
+	var permissions []middleware.Permission
+	for _, scope := range key.Scopes {
+		permissions = append(permissions, scopePermissions[scope]...)
+	}
+
+	// キーの名前は発行時に自由に付けられるラベルのため、作成者・所有者の判定には使わず識別子のみを渡す
+	return &middleware.Principal{
+		Subject:     fmt.Sprintf("api_key:%d", key.ID),
+		Permissions: permissions,
+	}, nil
+}
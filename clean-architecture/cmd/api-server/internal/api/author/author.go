@@ -0,0 +1,81 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAuthorAlreadyExists は同名の作成者が既に存在することを表す
+	ErrAuthorAlreadyExists = errors.New("同じ名前の作成者が既に存在します")
+	// ErrAuthorInUse は作成者を参照するコンテンツ、または作成者を投稿者として許可するコンテンツタイプが存在することを表す
+	ErrAuthorInUse = errors.New("作成者を参照しているコンテンツまたはコンテンツタイプが存在するため削除できません")
+)
+
+var errAuthorNotFound = apierror.NotFound("author_not_found", "指定された作成者が見つかりません")
+
+// AuthorRepository は作成者の永続化を担当するリポジトリインターフェース
+// Update と Merge はコンテンツに非正規化された作成者名も書き換え、editor を編集者とするリビジョンを記録してコンテンツのバージョンを進める
+type AuthorRepository interface {
+	ListWithUsage(ctx context.Context) ([]*AuthorUsage, error)
+	GetByID(ctx context.Context, id uint) (*entities.Author, error)
+	// Create は同名の作成者が存在する場合は ErrAuthorAlreadyExists を返す
+	Create(ctx context.Context, author *entities.Author) error
+	// Update は同名の作成者が存在する場合は ErrAuthorAlreadyExists を返す
+	Update(ctx context.Context, author *entities.Author, editor string) error
+	// Delete は削除済みを含むコンテンツ、またはコンテンツタイプの許可する作成者から参照されている場合は ErrAuthorInUse を返す
+	Delete(ctx context.Context, author *entities.Author) error
+	// Merge は source を参照するコンテンツとコンテンツタイプの許可する作成者を target に付け替え、source を削除する
+	Merge(ctx context.Context, source, target *entities.Author, editor string) error
+}
+
+// AuthorUsage は作成者と、その作成者の削除されていないコンテンツの件数
+type AuthorUsage struct {
+	entities.Author
+	ContentCount int64 `json:"content_count"`
+}
+
+// AuthorAPI はAuthor関連のHTTPハンドラーを提供する構造体
+type AuthorAPI struct {
+	repo AuthorRepository
+}
+
+// NewAuthorAPI はAuthorAPIの新しいインスタンスを作成する
+func NewAuthorAPI(repo AuthorRepository) *AuthorAPI {
+	return &AuthorAPI{
+		repo: repo,
+	}
+}
+
+// find は指定されたIDの作成者を取得し、取得できない場合はエラーレスポンスを返してfalseを返す
+func (api *AuthorAPI) find(c *gin.Context, id uint) (*entities.Author, bool) {
+	author, err := api.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errAuthorNotFound)
+			return nil, false
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の取得に失敗しました", err))
+		return nil, false
+	}
+
+	return author, true
+}
+
+// parseID はパスパラメータのIDを解析し、不正な場合はエラーレスポンスを返してfalseを返す
+func parseID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return 0, false
+	}
+	return uint(id), true
+}
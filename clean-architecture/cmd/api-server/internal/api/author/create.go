@@ -0,0 +1,44 @@
+package author
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAuthorRequest は作成者作成リクエストの構造体
+type CreateAuthorRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=100"`
+	Email string `json:"email" binding:"omitempty,max=254"`
+}
+
+// Create は作成者を作成するHTTPハンドラー
+func (api *AuthorAPI) Create(c *gin.Context) {
+	var req CreateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	author, err := entities.NewAuthor(req.Name, req.Email)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_author", "不正なリクエストです", err))
+		return
+	}
+
+	if err := api.repo.Create(c.Request.Context(), author); err != nil {
+		if errors.Is(err, ErrAuthorAlreadyExists) {
+			apierror.Abort(c, apierror.Conflict("author_already_exists", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の作成に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, author)
+}
@@ -0,0 +1,36 @@
+package author
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Delete は作成者を削除するHTTPハンドラー
+// コンテンツから参照されている作成者は削除できないため、先に他の作成者へマージする
+func (api *AuthorAPI) Delete(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	author, ok := api.find(c, id)
+	if !ok {
+		return
+	}
+
+	if err := api.repo.Delete(c.Request.Context(), author); err != nil {
+		if errors.Is(err, ErrAuthorInUse) {
+			apierror.Abort(c, apierror.Conflict("author_in_use", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の削除に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,22 @@
+package author
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetByID はIDを指定して作成者を取得するHTTPハンドラー
+func (api *AuthorAPI) GetByID(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	author, ok := api.find(c, id)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, author)
+}
@@ -0,0 +1,27 @@
+package author
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAuthorsResponse は作成者一覧レスポンスの構造体
+type ListAuthorsResponse struct {
+	Authors []*AuthorUsage `json:"authors"`
+}
+
+// List はコンテンツ件数付きの作成者一覧を取得するHTTPハンドラー
+func (api *AuthorAPI) List(c *gin.Context) {
+	authors, err := api.repo.ListWithUsage(c.Request.Context())
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("作成者一覧の取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListAuthorsResponse{
+		Authors: authors,
+	})
+}
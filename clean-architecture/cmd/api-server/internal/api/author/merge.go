@@ -0,0 +1,53 @@
+package author
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MergeAuthorRequest は作成者マージリクエストの構造体
+type MergeAuthorRequest struct {
+	TargetID uint `json:"target_id" binding:"required,min=1"`
+}
+
+// Merge は表記揺れなどで重複した作成者をマージ先の作成者に統合するHTTPハンドラー
+// マージ元の作成者のコンテンツはマージ先の作成者に付け替えられ、マージ元の作成者は削除される
+func (api *AuthorAPI) Merge(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req MergeAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	if id == req.TargetID {
+		apierror.Abort(c, apierror.Validation("merge_same_author", "不正なリクエストです", entities.ErrMergeSameAuthor))
+		return
+	}
+
+	// マージ元・マージ先の作成者を取得
+	source, ok := api.find(c, id)
+	if !ok {
+		return
+	}
+	target, ok := api.find(c, req.TargetID)
+	if !ok {
+		return
+	}
+
+	if err := api.repo.Merge(c.Request.Context(), source, target, middleware.EditorName(c, target.Name)); err != nil {
+		apierror.Abort(c, apierror.Internal("作成者のマージに失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
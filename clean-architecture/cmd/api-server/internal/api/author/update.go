@@ -0,0 +1,54 @@
+package author
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateAuthorRequest は作成者更新リクエストの構造体
+type UpdateAuthorRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=100"`
+	Email string `json:"email" binding:"omitempty,max=254"`
+}
+
+// Update は作成者名とメールアドレスを変更するHTTPハンドラー
+// 作成者名を変更した場合は、その作成者のコンテンツの作成者名も変更される
+func (api *AuthorAPI) Update(c *gin.Context) {
+	id, ok := parseID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAuthorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	author, ok := api.find(c, id)
+	if !ok {
+		return
+	}
+
+	if err := author.Update(req.Name, req.Email); err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_author", "不正なリクエストです", err))
+		return
+	}
+
+	if err := api.repo.Update(c.Request.Context(), author, middleware.EditorName(c, author.Name)); err != nil {
+		if errors.Is(err, ErrAuthorAlreadyExists) {
+			apierror.Abort(c, apierror.Conflict("author_already_exists", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の更新に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, author)
+}
@@ -0,0 +1,104 @@
+package content
+
+import (
+	"errors"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// authorFor は作成するコンテンツの作成者を決定し、作成者名と作成者を設定するオプションを返す
+// 表示名を持つプリンシパルはリクエストの作成者を無視して自身を作成者とし（未登録の場合は保存時に登録する）、
+// それ以外はリクエストの author_id または author で登録済みの作成者を指定する
+// 取得できない場合はエラーレスポンスを返して false を返す
+func (api *ContentAPI) authorFor(c *gin.Context, requestedID *uint, requested string) (string, []entities.ContentOption, bool) {
+	if name, ok := principalAuthor(c); ok {
+		return api.principalAuthorFor(c, name)
+	}
+	return api.requestedAuthor(c, requestedID, requested)
+}
+
+// principalAuthorFor はプリンシパルの表示名の作成者を設定するオプションを返す
+// コンテンツタイプの許可する作成者をIDで検証できるよう、登録済みの場合は作成者への参照を設定する
+func (api *ContentAPI) principalAuthorFor(c *gin.Context, name string) (string, []entities.ContentOption, bool) {
+	author, err := api.repo.GetAuthorByName(c.Request.Context(), name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return name, []entities.ContentOption{entities.RegisterAuthor()}, true
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の取得に失敗しました", err))
+		return "", nil, false
+	}
+
+	return author.Name, []entities.ContentOption{entities.WithAuthor(author)}, true
+}
+
+// updatedAuthorFor は更新後のコンテンツの作成者を決定し、作成者名と作成者を設定するオプションを返す
+// 認証済みの場合は作成者を変更できないため、既存の作成者を維持する
+// 取得できない場合はエラーレスポンスを返して false を返す
+func (api *ContentAPI) updatedAuthorFor(c *gin.Context, content *entities.Content, requestedID *uint, requested string) (string, []entities.ContentOption, bool) {
+	if _, ok := middleware.PrincipalFrom(c); ok {
+		return content.Author, nil, true
+	}
+	if requestedID == nil && strings.TrimSpace(requested) == content.Author {
+		return content.Author, nil, true
+	}
+	return api.requestedAuthor(c, requestedID, requested)
+}
+
+// restoredAuthorFor はリビジョンの復元後のコンテンツの作成者を決定し、作成者名と作成者を設定するオプションを返す
+// 更新と同様に認証済みの場合は既存の作成者を維持し、それ以外はリビジョンの作成者を登録済みの作成者から取得する
+// リビジョンの作成者が名前の変更・マージ・削除により登録されていない場合は422を返して false を返す
+func (api *ContentAPI) restoredAuthorFor(c *gin.Context, content *entities.Content, revision *entities.ContentRevision) (string, []entities.ContentOption, bool) {
+	if _, ok := middleware.PrincipalFrom(c); ok || revision.Author == content.Author {
+		return content.Author, nil, true
+	}
+
+	author, err := api.repo.GetAuthorByName(c.Request.Context(), revision.Author)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errRevisionAuthorUnknown)
+			return "", nil, false
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の取得に失敗しました", err))
+		return "", nil, false
+	}
+
+	return author.Name, []entities.ContentOption{entities.WithAuthor(author)}, true
+}
+
+// requestedAuthor はリクエストで指定された作成者を登録済みの作成者から取得する
+// author_id を優先し、作成者名の場合は完全に一致する作成者を探す。入力の誤りで別の作成者が作られないよう、未登録の作成者は受け付けない
+func (api *ContentAPI) requestedAuthor(c *gin.Context, requestedID *uint, requested string) (string, []entities.ContentOption, bool) {
+	name := strings.TrimSpace(requested)
+	if requestedID == nil && name == "" {
+		// 作成者の指定がない場合はエンティティの検証でエラーにする
+		return "", nil, true
+	}
+
+	var author *entities.Author
+	var err error
+	if requestedID != nil {
+		author, err = api.repo.GetAuthorByID(c.Request.Context(), *requestedID)
+	} else {
+		author, err = api.repo.GetAuthorByName(c.Request.Context(), name)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", entities.ErrUnknownAuthor))
+			return "", nil, false
+		}
+
+		apierror.Abort(c, apierror.Internal("作成者の取得に失敗しました", err))
+		return "", nil, false
+	}
+
+	return author.Name, []entities.ContentOption{entities.WithAuthor(author)}, true
+}
@@ -2,27 +2,89 @@ package content
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go-api-server-sample/internal/domain/entities"
 )
 
+// ErrVersionConflict は他のリクエストにより先に更新されていたため保存できなかったことを表す
+var ErrVersionConflict = errors.New("コンテンツは他のリクエストにより更新されています")
+
+// ErrUnsupportedSearchFilter は検索で扱えない条件（並び替えやカーソル）が指定されたことを表す
+var ErrUnsupportedSearchFilter = errors.New("検索では並び替えとカーソルを指定できません")
+
 //go:generate mockery --name=ContentRepository --output=../../testing/mocks
 
 // ContentRepository はコンテンツの永続化を担当するリポジトリインターフェース
+// Update、UpdateWithRevision、Delete は読み込み時のバージョンと一致する場合のみ保存・削除し、一致しなければ ErrVersionConflict を返す
 type ContentRepository interface {
 	Create(ctx context.Context, content *entities.Content) error
 	GetByID(ctx context.Context, id uint) (*entities.Content, error)
 	List(ctx context.Context, filters ContentFilters) ([]*entities.Content, int64, error)
 	Update(ctx context.Context, content *entities.Content) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, content *entities.Content) error
+
+	// GetAuthorByID と GetAuthorByName はリクエストで指定された作成者を取得する
+	// 存在しない場合は gorm.ErrRecordNotFound を返す
+	GetAuthorByID(ctx context.Context, id uint) (*entities.Author, error)
+	GetAuthorByName(ctx context.Context, name string) (*entities.Author, error)
+
+	// UpdateWithRevision はコンテンツの更新とリビジョンの追加を同一トランザクションで行い、リビジョン番号を採番する
+	UpdateWithRevision(ctx context.Context, content *entities.Content, revision *entities.ContentRevision) error
+	ListRevisions(ctx context.Context, contentID uint) ([]*entities.ContentRevision, error)
+	GetRevision(ctx context.Context, contentID uint, revisionNumber int) (*entities.ContentRevision, error)
+
+	// Search は検索クエリに一致するコンテンツを関連度の高い順に返す
+	// filters の絞り込み条件は List と同様に適用し、SkipTotal は無視して常に件数を集計する
+	// Sort または Cursor を指定した場合は ErrUnsupportedSearchFilter を返す
+	Search(ctx context.Context, query SearchQuery, filters ContentFilters) ([]*SearchResult, int64, error)
+
+	// ListDeleted は削除済み（ゴミ箱にある）コンテンツを削除日時の新しい順に返す
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Content, int64, error)
+	// Restore は削除済みのコンテンツを復元する。ゴミ箱にない場合は gorm.ErrRecordNotFound を返す
+	Restore(ctx context.Context, id uint) error
+	// Purge は削除済みのコンテンツをリビジョン・タグの関連付けとともに完全に削除する
+	// ゴミ箱にない場合は gorm.ErrRecordNotFound を返す
+	Purge(ctx context.Context, id uint) error
+	// PurgeDeletedBefore は指定日時より前に削除されたコンテンツを完全に削除し、削除件数を返す
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SearchResult は検索に一致したコンテンツと関連度、一致箇所を強調した本文の抜粋
+// Headline はHTMLエスケープ済みで、一致箇所を <mark> タグで囲む
+type SearchResult struct {
+	*entities.Content
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"`
 }
 
 // ContentFilters はコンテンツ一覧取得時のフィルタ条件
 type ContentFilters struct {
-	ContentType *string
-	Author      *string
-	Limit       int
-	Offset      int
+	ContentType  *string
+	Author       *string
+	AuthorPrefix *string // 作成者の前方一致（大文字小文字を区別しない）
+	Status       *entities.ContentStatus
+	OwnerSubject *string  // 所有者の識別子（参照できるコンテンツの制限に使用する）
+	Tags         []string // いずれかのタグを持つ
+	TagsAll      []string // すべてのタグを持つ
+	Fields       []FieldFilter
+	Limit        int
+	Offset       int
+
+	// 日時の範囲は After を含み Before を含まない
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	// Sort は並び替えの順序で、同順位のコンテンツは最後の項目と同じ方向のIDで順序を確定させる
+	Sort []SortField
+
+	// Cursor が指定された場合は Offset の代わりにカーソルの位置から取得する
+	Cursor *ContentCursor
+	// SkipTotal が true の場合は件数の集計を省略し、total として0を返す
+	SkipTotal bool
 }
 
 // NewContentFilters はContentFiltersの新しいインスタンスを作成する
@@ -30,17 +92,71 @@ func NewContentFilters() ContentFilters {
 	return ContentFilters{
 		Limit:  20, // デフォルト取得件数
 		Offset: 0,  // デフォルト開始位置
+		Sort:   DefaultSort(),
 	}
 }
 
 // ContentAPI はContent関連のHTTPハンドラーを提供する構造体
 type ContentAPI struct {
-	repo ContentRepository
+	repo           ContentRepository
+	requireIfMatch bool
+	cursors        cursorCodec
+	trashRetention time.Duration
+	events         EventRecorder
+	contentTypes   entities.ContentTypeLookup
+}
+
+// Option はContentAPIの振る舞いを変更するオプション
+type Option func(*ContentAPI)
+
+// WithIfMatchRequired は更新・削除時にIf-Matchヘッダーを必須にする
+func WithIfMatchRequired(required bool) Option {
+	return func(api *ContentAPI) {
+		api.requireIfMatch = required
+	}
+}
+
+// WithCursorSecret は一覧のカーソルの署名に使う鍵を設定する
+// 複数インスタンスで運用する場合は、すべてのインスタンスで同じ鍵を設定する
+func WithCursorSecret(secret string) Option {
+	return func(api *ContentAPI) {
+		api.cursors = newCursorCodec([]byte(secret))
+	}
+}
+
+// WithTrashRetention は削除済みコンテンツをゴミ箱に保持する期間を設定する
+// ゴミ箱の一覧では、削除日時に保持期間を加えた日時を完全削除の予定日時として返す
+func WithTrashRetention(retention time.Duration) Option {
+	return func(api *ContentAPI) {
+		api.trashRetention = retention
+	}
+}
+
+// WithEventRecorder はコンテンツの作成・更新・削除を記録する先を設定する
+func WithEventRecorder(recorder EventRecorder) Option {
+	return func(api *ContentAPI) {
+		api.events = recorder
+	}
+}
+
+// WithContentTypes はコンテンツの作成・更新時の検証で参照するコンテンツタイプを設定する
+// 指定しない場合は初期状態のコンテンツタイプのみを受け付ける
+func WithContentTypes(types entities.ContentTypeLookup) Option {
+	return func(api *ContentAPI) {
+		api.contentTypes = types
+	}
 }
 
 // NewContentAPI はContentAPIの新しいインスタンスを作成する
-func NewContentAPI(repo ContentRepository) *ContentAPI {
-	return &ContentAPI{
-		repo: repo,
+func NewContentAPI(repo ContentRepository, opts ...Option) *ContentAPI {
+	api := &ContentAPI{
+		repo:         repo,
+		cursors:      newCursorCodec(nil),
+		events:       noopEventRecorder{},
+		contentTypes: entities.NewDefaultContentTypeRegistry(),
+	}
+	for _, opt := range opts {
+		opt(api)
 	}
+	return api
 }
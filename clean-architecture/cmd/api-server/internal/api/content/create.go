@@ -1,53 +1,70 @@
 package content
 
 import (
+	"errors"
 	"net/http"
 
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
 	"go-api-server-sample/internal/domain/entities"
 
 	"github.com/gin-gonic/gin"
 )
 
 // CreateContentRequest はコンテンツ作成リクエストの構造体
+// Fields はコンテンツタイプのスキーマで検証されるカスタムフィールド
+// 作成者は AuthorID または Author（作成者名）で登録済みの作成者を指定する
+// 表示名を持つプリンシパルで認証済みの場合は指定を無視し、プリンシパルが作成者になる
 type CreateContentRequest struct {
-	Title       string `json:"title" binding:"required,min=1,max=200"`
-	Body        string `json:"body" binding:"required,min=1"`
-	ContentType string `json:"content_type" binding:"required,oneof=article blog news page"`
-	Author      string `json:"author" binding:"required,min=1,max=100"`
+	Title       string                 `json:"title" binding:"required,min=1,max=200"`
+	Body        string                 `json:"body" binding:"required,min=1"`
+	ContentType string                 `json:"content_type" binding:"required,max=50"`
+	AuthorID    *uint                  `json:"author_id"`
+	Author      string                 `json:"author" binding:"omitempty,max=100"`
+	Tags        []string               `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	Fields      map[string]interface{} `json:"fields"`
 }
 
 // Create はコンテンツを作成するHTTPハンドラー
 func (api *ContentAPI) Create(c *gin.Context) {
 	var req CreateContentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なリクエストです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	author, authorOpts, ok := api.authorFor(c, req.AuthorID, req.Author)
+	if !ok {
 		return
 	}
 
 	// ドメインエンティティ作成
-	content, err := entities.NewContent(req.Title, req.Body, req.ContentType, req.Author)
+	opts := append([]entities.ContentOption{
+		entities.WithTags(req.Tags),
+		entities.WithFields(req.Fields),
+		entities.WithOwner(ownerFor(c)),
+	}, authorOpts...)
+	content, err := entities.NewContent(req.Title, req.Body, req.ContentType, author, opts...)
+	if err == nil {
+		err = content.ValidateContentType(api.contentTypes)
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なリクエストです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
 		return
 	}
 
 	// リポジトリでDB保存
 	if err := api.repo.Create(c.Request.Context(), content); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの作成に失敗しました",
-			"details": err.Error(),
-		})
+		if errors.Is(err, entities.ErrUnknownAuthor) {
+			apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの作成に失敗しました", err))
 		return
 	}
 
+	annotateSpan(c, content)
+	api.events.RecordContentEvent(ContentCreated, content.ContentType)
+	setETag(c, content)
 	c.JSON(http.StatusCreated, content)
 }
@@ -0,0 +1,95 @@
+package content
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+)
+
+var ErrInvalidCursor = apierror.NewReason("invalid_cursor", "カーソルが不正です")
+
+// ContentCursor は一覧の (created_at, id) 上の位置を表すキーセットページネーションのカーソル
+// Backward が true の場合はカーソルより前（新しい側）のページを取得する
+type ContentCursor struct {
+	CreatedAt time.Time
+	ID        uint
+	Backward  bool
+}
+
+// cursorPayload はカーソルのJSON表現
+type cursorPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uint      `json:"i"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+// cursorCodec はカーソルを改ざん検知用の署名付きの不透明な文字列に変換する
+type cursorCodec struct {
+	secret []byte
+}
+
+// newCursorCodec は署名鍵を指定してcursorCodecを作成する
+// 鍵が空の場合はプロセスごとにランダムな鍵を生成するため、再起動や他のインスタンスでは過去のカーソルを利用できない
+func newCursorCodec(secret []byte) cursorCodec {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+	return cursorCodec{secret: secret}
+}
+
+// encode はコンテンツの位置を指すカーソルを作成する
+func (c cursorCodec) encode(content *entities.Content, backward bool) string {
+	payload, _ := json.Marshal(cursorPayload{
+		CreatedAt: content.CreatedAt,
+		ID:        content.ID,
+		Backward:  backward,
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// decode は署名を検証してカーソルを復元する
+func (c cursorCodec) decode(value string) (*ContentCursor, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return nil, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &ContentCursor{
+		CreatedAt: payload.CreatedAt,
+		ID:        payload.ID,
+		Backward:  payload.Backward,
+	}, nil
+}
+
+func (c cursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -14,42 +16,40 @@ func (api *ContentAPI) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なIDです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.InvalidID(err))
 		return
 	}
 
 	// 存在確認
-	_, err = api.repo.GetByID(c.Request.Context(), uint(id))
+	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"code":    http.StatusNotFound,
-				"message": "指定されたコンテンツが見つかりません",
-			})
+			apierror.Abort(c, ErrContentNotFound)
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの取得に失敗しました",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return
+	}
+
+	annotateSpan(c, content)
+
+	// 権限と前提条件の確認
+	if !authorizeModify(c, content) || !api.checkIfMatch(c, content) {
 		return
 	}
 
-	// 削除実行
-	if err := api.repo.Delete(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの削除に失敗しました",
-			"details": err.Error(),
-		})
+	// 削除実行（確認後に更新されていた場合は削除しない）
+	if err := api.repo.Delete(c.Request.Context(), content); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			respondPreconditionFailed(c)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの削除に失敗しました", err))
 		return
 	}
 
+	api.events.RecordContentEvent(ContentDeleted, content.ContentType)
 	c.JSON(http.StatusNoContent, nil)
 }
@@ -0,0 +1,21 @@
+package content
+
+import (
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+)
+
+// ErrContentNotFound は指定されたコンテンツが存在しない（または削除済みである）ことを表す
+var ErrContentNotFound = apierror.NotFound("content_not_found", "指定されたコンテンツが見つかりません")
+
+var (
+	errRevisionNotFound = apierror.NotFound("revision_not_found", "指定されたリビジョンが見つかりません")
+	errNotInTrash       = apierror.NotFound("content_not_in_trash", "指定されたコンテンツはゴミ箱にありません")
+
+	errInvalidRevisionNumber = apierror.InvalidPathParam("invalid_revision_number", "不正なリビジョン番号です", "revision")
+
+	errRevisionAuthorUnknown = apierror.New(apierror.KindUnprocessable, "revision_author_unknown", "リビジョンの作成者が登録されていないため復元できません").WithReason(entities.ErrUnknownAuthor)
+
+	errIfMatchRequired    = apierror.New(apierror.KindPreconditionRequired, "if_match_required", "If-Matchヘッダーを指定してください")
+	errPreconditionFailed = apierror.New(apierror.KindPreconditionFailed, "version_conflict", "コンテンツは他のリクエストにより更新されています")
+)
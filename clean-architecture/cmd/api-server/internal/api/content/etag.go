@@ -0,0 +1,79 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag はコンテンツのIDとバージョンから強いETagを生成する
+func etag(content *entities.Content) string {
+	return fmt.Sprintf(`"%d-%d"`, content.ID, content.Version)
+}
+
+// setETag はレスポンスにコンテンツのETagを設定する
+func setETag(c *gin.Context, content *entities.Content) {
+	c.Header("ETag", etag(content))
+}
+
+// checkIfMatch はIf-Matchヘッダーを検証し、前提条件を満たさない場合はエラーレスポンスを返してfalseを返す
+func (api *ContentAPI) checkIfMatch(c *gin.Context, content *entities.Content) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if !api.requireIfMatch {
+			return true
+		}
+
+		apierror.Abort(c, errIfMatchRequired)
+		return false
+	}
+
+	// If-Match は強い比較のため、弱いETagは一致しない
+	current := etag(content)
+	for _, tag := range splitETags(header) {
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+
+	setETag(c, content)
+	respondPreconditionFailed(c)
+	return false
+}
+
+// matchesIfNoneMatch はIf-None-Matchヘッダーが現在のETagに一致するかを返す
+func matchesIfNoneMatch(c *gin.Context, content *entities.Content) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	// If-None-Match は弱い比較のため、W/ プレフィックスを無視する
+	current := etag(content)
+	for _, tag := range splitETags(header) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// respondPreconditionFailed は412レスポンスを返す
+func respondPreconditionFailed(c *gin.Context) {
+	apierror.Abort(c, errPreconditionFailed)
+}
+
+func splitETags(header string) []string {
+	parts := strings.Split(header, ",")
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if tag := strings.TrimSpace(part); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
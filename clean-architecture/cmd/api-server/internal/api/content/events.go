@@ -0,0 +1,21 @@
+package content
+
+// ContentEvent はコンテンツに対して行われた変更の種類
+type ContentEvent string
+
+const (
+	ContentCreated ContentEvent = "created"
+	ContentUpdated ContentEvent = "updated"
+	ContentDeleted ContentEvent = "deleted"
+)
+
+// EventRecorder はコンテンツの変更を記録する（メトリクスの集計など）
+// 変更の保存に成功した後に呼び出す
+type EventRecorder interface {
+	RecordContentEvent(event ContentEvent, contentType string)
+}
+
+// noopEventRecorder は何も記録しないEventRecorder
+type noopEventRecorder struct{}
+
+func (noopEventRecorder) RecordContentEvent(ContentEvent, string) {}
@@ -0,0 +1,101 @@
+package content
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+)
+
+// FieldOperator はカスタムフィールドの絞り込みで指定できる比較演算子
+type FieldOperator string
+
+const (
+	FieldOpEq  FieldOperator = "="
+	FieldOpNe  FieldOperator = "!="
+	FieldOpGt  FieldOperator = ">"
+	FieldOpGte FieldOperator = ">="
+	FieldOpLt  FieldOperator = "<"
+	FieldOpLte FieldOperator = "<="
+)
+
+// fieldOperators は演算子の判定順（長いものを優先）
+var fieldOperators = []FieldOperator{FieldOpGte, FieldOpLte, FieldOpNe, FieldOpEq, FieldOpGt, FieldOpLt}
+
+// MaxFieldFilters は一度に指定できるカスタムフィールドの絞り込み条件の数
+const MaxFieldFilters = 10
+
+const fieldFilterPrefix = "fields."
+
+var fieldFilterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+var ErrInvalidFieldFilter = apierror.NewReason("invalid_field_filter", "カスタムフィールドの絞り込み条件が不正です")
+
+var (
+	errTooManyFieldFilters = ErrInvalidFieldFilter.Variant("too_many_field_filters", "条件は%d件以下で指定してください")
+	errInvalidFieldName    = ErrInvalidFieldFilter.Variant("invalid_field_filter_name", "フィールド名 %q は使用できません")
+	errMissingFieldValue   = ErrInvalidFieldFilter.Variant("missing_field_filter_value", "%s の値を指定してください")
+)
+
+// FieldFilter はトップレベルのカスタムフィールドの値による絞り込み条件
+type FieldFilter struct {
+	Name     string
+	Operator FieldOperator
+	Value    string
+}
+
+// parseFieldFilters はクエリ文字列から fields.<名前><演算子><値> 形式の条件を取り出す
+// >= などの演算子は key=value の区切りと衝突するため、url.Values を使わず生のクエリを解析する
+func parseFieldFilters(rawQuery string) ([]FieldFilter, error) {
+	filters := []FieldFilter{}
+	for _, part := range strings.Split(rawQuery, "&") {
+		if !strings.HasPrefix(part, fieldFilterPrefix) {
+			continue
+		}
+
+		expr, err := url.QueryUnescape(strings.TrimPrefix(part, fieldFilterPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFieldFilter, err)
+		}
+
+		filter, err := parseFieldFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if len(filters) > MaxFieldFilters {
+		return nil, errTooManyFieldFilters.With(MaxFieldFilters)
+	}
+
+	return filters, nil
+}
+
+func parseFieldFilter(expr string) (FieldFilter, error) {
+	i := strings.IndexAny(expr, "=!<>")
+	if i < 0 {
+		return FieldFilter{}, fmt.Errorf("%w: %s", ErrInvalidFieldFilter, expr)
+	}
+
+	name := expr[:i]
+	if !fieldFilterNamePattern.MatchString(name) {
+		return FieldFilter{}, errInvalidFieldName.With(name)
+	}
+
+	for _, op := range fieldOperators {
+		if !strings.HasPrefix(expr[i:], string(op)) {
+			continue
+		}
+
+		value := expr[i+len(op):]
+		if value == "" {
+			return FieldFilter{}, errMissingFieldValue.With(name)
+		}
+		return FieldFilter{Name: name, Operator: op, Value: value}, nil
+	}
+
+	return FieldFilter{}, fmt.Errorf("%w: %s", ErrInvalidFieldFilter, expr)
+}
@@ -5,42 +5,56 @@ import (
 	"net/http"
 	"strconv"
 
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-var ErrContentNotFound = errors.New("指定されたコンテンツが見つかりません")
-
 // GetByID は指定されたIDのコンテンツを取得するHTTPハンドラー
 func (api *ContentAPI) GetByID(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なIDです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	content, ok := api.findReadable(c, uint(id))
+	if !ok {
+		return
+	}
+
+	annotateSpan(c, content)
+
+	setETag(c, content)
+	if matchesIfNoneMatch(c, content) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
-	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	c.JSON(http.StatusOK, content)
+}
+
+// findReadable は参照できるコンテンツを取得し、取得できない場合はエラーレスポンスを返して false を返す
+// 参照できないコンテンツは存在を知らせないよう、存在しない場合と同じく404を返す
+func (api *ContentAPI) findReadable(c *gin.Context, id uint) (*entities.Content, bool) {
+	content, err := api.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"code":    http.StatusNotFound,
-				"message": "指定されたコンテンツが見つかりません",
-			})
-			return
+			apierror.Abort(c, ErrContentNotFound)
+			return nil, false
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの取得に失敗しました",
-			"details": err.Error(),
-		})
-		return
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return nil, false
 	}
 
-	c.JSON(http.StatusOK, content)
+	if !canRead(c, content) {
+		apierror.Abort(c, ErrContentNotFound)
+		return nil, false
+	}
+
+	return content, true
 }
@@ -2,37 +2,72 @@ package content
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
 	"go-api-server-sample/internal/domain/entities"
 
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	errCursorWithOffset = apierror.NewReason("cursor_with_offset", "cursor と offset は同時に指定できません")
+	errCursorWithSort   = apierror.NewReason("cursor_with_sort", "cursor は既定の並び順（-created_at）でのみ指定できます")
+	errInvalidDateRange = apierror.NewReason("invalid_date_range", "%s は %s より前の日時を指定してください")
+)
+
 // ListContentsRequest は一覧取得リクエストの構造体
 type ListContentsRequest struct {
-	ContentType *string `form:"content_type" binding:"omitempty,oneof=article blog news page"`
+	ContentType *string `form:"content_type" binding:"omitempty,max=50"`
 	Author      *string `form:"author" binding:"omitempty,max=100"`
-	Limit       int     `form:"limit" binding:"omitempty,min=1,max=100"`
-	Offset      int     `form:"offset" binding:"omitempty,min=0"`
+	// AuthorPrefix は作成者の前方一致で、大文字小文字を区別しない
+	AuthorPrefix *string `form:"author_prefix" binding:"omitempty,min=1,max=100"`
+	Status       *string `form:"status" binding:"omitempty,oneof=draft in_review published archived"`
+	Tags         string  `form:"tags" binding:"omitempty,max=1000"`
+	TagsAll      string  `form:"tags_all" binding:"omitempty,max=1000"`
+	Limit        int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset       int     `form:"offset" binding:"omitempty,min=0"`
+	// Cursor は前回のレスポンスの next_cursor または prev_cursor で、offset とは併用できない
+	Cursor string `form:"cursor" binding:"omitempty,max=512"`
+	// IncludeTotal は件数を集計するかどうかで、未指定の場合はカーソル指定時のみ集計しない
+	IncludeTotal *bool `form:"include_total"`
+	// Sort はカンマ区切りの並び替え項目で、先頭に - を付けると降順（例: -updated_at,title）
+	Sort string `form:"sort" binding:"omitempty,max=100"`
+	// 日時の範囲はRFC3339形式で指定し、after は指定日時を含み before は含まない
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedAfter  *time.Time `form:"updated_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedBefore *time.Time `form:"updated_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// validateRanges は日時の範囲の指定が矛盾していないかを検証する
+func (r *ListContentsRequest) validateRanges() error {
+	if r.CreatedAfter != nil && r.CreatedBefore != nil && !r.CreatedAfter.Before(*r.CreatedBefore) {
+		return errInvalidDateRange.With("created_after", "created_before")
+	}
+	if r.UpdatedAfter != nil && r.UpdatedBefore != nil && !r.UpdatedAfter.Before(*r.UpdatedBefore) {
+		return errInvalidDateRange.With("updated_after", "updated_before")
+	}
+	return nil
 }
 
 // ListContentsResponse は一覧取得レスポンスの構造体
+// Total は件数を集計しなかった場合は省略される
 type ListContentsResponse struct {
-	Contents []*entities.Content `json:"contents"`
-	Total    int64               `json:"total"`
-	Limit    int                 `json:"limit"`
-	Offset   int                 `json:"offset"`
+	Contents   []*entities.Content `json:"contents"`
+	Total      *int64              `json:"total,omitempty"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	PrevCursor string              `json:"prev_cursor,omitempty"`
 }
 
 // List はコンテンツ一覧を取得するHTTPハンドラー
 func (api *ContentAPI) List(c *gin.Context) {
 	var req ListContentsRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なクエリパラメータです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.BindQuery(err))
 		return
 	}
 
@@ -40,6 +75,10 @@ func (api *ContentAPI) List(c *gin.Context) {
 	filters := NewContentFilters()
 
 	if req.ContentType != nil {
+		if _, ok := api.contentTypes.Lookup(*req.ContentType); !ok {
+			apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", entities.ErrInvalidContentType))
+			return
+		}
 		filters.ContentType = req.ContentType
 	}
 
@@ -47,6 +86,46 @@ func (api *ContentAPI) List(c *gin.Context) {
 		filters.Author = req.Author
 	}
 
+	if req.AuthorPrefix != nil {
+		filters.AuthorPrefix = req.AuthorPrefix
+	}
+
+	if err := req.validateRanges(); err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+		return
+	}
+	filters.CreatedAfter = req.CreatedAfter
+	filters.CreatedBefore = req.CreatedBefore
+	filters.UpdatedAfter = req.UpdatedAfter
+	filters.UpdatedBefore = req.UpdatedBefore
+
+	sort, err := ParseSort(req.Sort)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+		return
+	}
+	filters.Sort = sort
+
+	// ステータス未指定の場合は公開中のコンテンツのみ返す
+	status := entities.StatusPublished
+	if req.Status != nil {
+		status = entities.ContentStatus(*req.Status)
+	}
+	filters.Status = &status
+	restrictToReadable(c, &filters)
+
+	// タグはカンマ区切りで指定
+	filters.Tags = splitTagNames(req.Tags)
+	filters.TagsAll = splitTagNames(req.TagsAll)
+
+	// カスタムフィールドは fields.event_date>=2026-01-01 の形式で指定
+	fieldFilters, err := parseFieldFilters(c.Request.URL.RawQuery)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+		return
+	}
+	filters.Fields = fieldFilters
+
 	if req.Limit > 0 {
 		filters.Limit = req.Limit
 	}
@@ -55,23 +134,94 @@ func (api *ContentAPI) List(c *gin.Context) {
 		filters.Offset = req.Offset
 	}
 
+	if req.Cursor != "" {
+		if req.Offset > 0 {
+			apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", errCursorWithOffset))
+			return
+		}
+
+		if !isDefaultSort(filters.Sort) {
+			apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", errCursorWithSort))
+			return
+		}
+
+		cursor, err := api.cursors.decode(req.Cursor)
+		if err != nil {
+			apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+			return
+		}
+		filters.Cursor = cursor
+	}
+
+	// 件数の集計は大きなテーブルでは高コストなため、カーソル指定時は既定で省略する
+	filters.SkipTotal = filters.Cursor != nil
+	if req.IncludeTotal != nil {
+		filters.SkipTotal = !*req.IncludeTotal
+	}
+
+	// 次のページの有無を判定するため1件多く取得する
+	limit := filters.Limit
+	filters.Limit = limit + 1
+
 	// リポジトリから取得
 	contents, total, err := api.repo.List(c.Request.Context(), filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツ一覧の取得に失敗しました",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Internal("コンテンツ一覧の取得に失敗しました", err))
 		return
 	}
 
+	// 余分に取得した1件は、後方向の場合は先頭、前方向の場合は末尾にある
+	backward := filters.Cursor != nil && filters.Cursor.Backward
+	hasMore := len(contents) > limit
+	if hasMore {
+		if backward {
+			contents = contents[1:]
+		} else {
+			contents = contents[:limit]
+		}
+	}
+
 	response := &ListContentsResponse{
 		Contents: contents,
-		Total:    total,
-		Limit:    filters.Limit,
+		Limit:    limit,
 		Offset:   filters.Offset,
 	}
 
+	if !filters.SkipTotal {
+		response.Total = &total
+	}
+
+	// カーソルは既定の並び順の位置を表すため、それ以外の並び順では発行しない
+	if len(contents) > 0 && isDefaultSort(filters.Sort) {
+		first, last := contents[0], contents[len(contents)-1]
+
+		// 後方向に取得した場合は、取得元のページが常に後ろに存在する
+		if hasMore || backward {
+			response.NextCursor = api.cursors.encode(last, false)
+		}
+		if filters.Cursor != nil && (hasMore || !backward) {
+			response.PrevCursor = api.cursors.encode(first, true)
+		}
+	}
+
 	c.JSON(http.StatusOK, response)
 }
+
+// splitTagNames はカンマ区切りのタグ名を分割し、空要素と重複を取り除く
+func splitTagNames(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	names := []string{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,34 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Purge はゴミ箱にあるコンテンツを完全に削除するHTTPハンドラー
+func (api *ContentAPI) Purge(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	if err := api.repo.Purge(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errNotInTrash)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの完全削除に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,41 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Restore はゴミ箱にあるコンテンツを復元するHTTPハンドラー
+func (api *ContentAPI) Restore(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	if err := api.repo.Restore(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errNotInTrash)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの復元に失敗しました", err))
+		return
+	}
+
+	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return
+	}
+
+	setETag(c, content)
+	c.JSON(http.StatusOK, content)
+}
@@ -0,0 +1,77 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DiffRevisionsRequest はリビジョン差分取得リクエストの構造体
+type DiffRevisionsRequest struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// DiffRevisionsResponse はリビジョン差分レスポンスの構造体
+type DiffRevisionsResponse struct {
+	ContentID uint                 `json:"content_id"`
+	From      int                  `json:"from"`
+	To        int                  `json:"to"`
+	Changes   []entities.FieldDiff `json:"changes"`
+}
+
+// DiffRevisions は2つのリビジョン間のフィールド単位の差分を返すHTTPハンドラー
+func (api *ContentAPI) DiffRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	var req DiffRevisionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierror.Abort(c, apierror.BindQuery(err))
+		return
+	}
+
+	// 存在と参照できることの確認
+	if _, ok := api.findReadable(c, uint(id)); !ok {
+		return
+	}
+
+	// 比較対象のリビジョンを取得
+	revisions := make([]*entities.ContentRevision, 0, 2)
+	for _, number := range []int{req.From, req.To} {
+		revision, err := api.repo.GetRevision(c.Request.Context(), uint(id), number)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				apierror.Abort(c, errRevisionNotFound)
+				return
+			}
+
+			apierror.Abort(c, apierror.Internal("リビジョンの取得に失敗しました", err))
+			return
+		}
+		revisions = append(revisions, revision)
+	}
+
+	changes, err := revisions[0].Diff(revisions[1])
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &DiffRevisionsResponse{
+		ContentID: uint(id),
+		From:      req.From,
+		To:        req.To,
+		Changes:   changes,
+	})
+}
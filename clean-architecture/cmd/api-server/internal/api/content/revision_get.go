@@ -0,0 +1,47 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetRevision は指定された番号のリビジョンを取得するHTTPハンドラー
+func (api *ContentAPI) GetRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	revisionParam := c.Param("revision")
+	revisionNumber, err := strconv.Atoi(revisionParam)
+	if err != nil || revisionNumber < 1 {
+		apierror.Abort(c, errInvalidRevisionNumber)
+		return
+	}
+
+	// 存在と参照できることの確認
+	if _, ok := api.findReadable(c, uint(id)); !ok {
+		return
+	}
+
+	revision, err := api.repo.GetRevision(c.Request.Context(), uint(id), revisionNumber)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errRevisionNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("リビジョンの取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
@@ -0,0 +1,43 @@
+package content
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRevisionsResponse はリビジョン一覧レスポンスの構造体
+type ListRevisionsResponse struct {
+	ContentID uint                        `json:"content_id"`
+	Revisions []*entities.ContentRevision `json:"revisions"`
+}
+
+// ListRevisions はコンテンツのリビジョン一覧を新しい順に取得するHTTPハンドラー
+func (api *ContentAPI) ListRevisions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	// 存在と参照できることの確認
+	if _, ok := api.findReadable(c, uint(id)); !ok {
+		return
+	}
+
+	revisions, err := api.repo.ListRevisions(c.Request.Context(), uint(id))
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("リビジョン一覧の取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListRevisionsResponse{
+		ContentID: uint(id),
+		Revisions: revisions,
+	})
+}
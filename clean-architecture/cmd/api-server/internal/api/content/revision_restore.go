@@ -0,0 +1,99 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RestoreRevision は指定されたリビジョンの内容を新しいリビジョンとして復元するHTTPハンドラー
+func (api *ContentAPI) RestoreRevision(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	revisionParam := c.Param("revision")
+	revisionNumber, err := strconv.Atoi(revisionParam)
+	if err != nil || revisionNumber < 1 {
+		apierror.Abort(c, errInvalidRevisionNumber)
+		return
+	}
+
+	// 既存コンテンツ取得
+	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, ErrContentNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return
+	}
+
+	annotateSpan(c, content)
+
+	if !authorizeModify(c, content) {
+		return
+	}
+
+	// 復元元リビジョン取得
+	revision, err := api.repo.GetRevision(c.Request.Context(), uint(id), revisionNumber)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errRevisionNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("リビジョンの取得に失敗しました", err))
+		return
+	}
+
+	author, opts, ok := api.restoredAuthorFor(c, content, revision)
+	if !ok {
+		return
+	}
+
+	// リビジョンの内容で更新
+	before := *content
+	err = content.RestoreRevision(revision, author, opts...)
+	if err == nil {
+		err = content.ValidateContentType(api.contentTypes)
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
+		return
+	}
+
+	// 復元操作は変更の有無にかかわらず新しいリビジョンとして記録
+	restored := entities.NewContentRevision(content, content.ChangedFieldsFrom(&before), middleware.EditorName(c, content.Author))
+	restored.RestoredFrom = &revision.RevisionNumber
+
+	if err := api.repo.UpdateWithRevision(c.Request.Context(), content, restored); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			respondPreconditionFailed(c)
+			return
+		}
+		if errors.Is(err, entities.ErrUnknownAuthor) {
+			apierror.Abort(c, errRevisionAuthorUnknown)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("リビジョンの復元に失敗しました", err))
+		return
+	}
+
+	api.events.RecordContentEvent(ContentUpdated, content.ContentType)
+	setETag(c, content)
+	c.JSON(http.StatusOK, content)
+}
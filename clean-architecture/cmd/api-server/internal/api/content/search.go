@@ -0,0 +1,77 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchContentsRequest は全文検索リクエストの構造体
+type SearchContentsRequest struct {
+	Q           string  `form:"q" binding:"required,max=200"`
+	ContentType *string `form:"content_type" binding:"omitempty,max=50"`
+	Status      *string `form:"status" binding:"omitempty,oneof=draft in_review published archived"`
+	Limit       int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset      int     `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SearchContentsResponse は全文検索レスポンスの構造体
+type SearchContentsResponse struct {
+	Results []*SearchResult `json:"results"`
+	Total   int64           `json:"total"`
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// Search はキーワードでコンテンツを全文検索するHTTPハンドラー
+func (api *ContentAPI) Search(c *gin.Context) {
+	var req SearchContentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierror.Abort(c, apierror.BindQuery(err))
+		return
+	}
+
+	query, err := ParseSearchQuery(req.Q)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+		return
+	}
+
+	// フィルタ構築
+	filters := NewContentFilters()
+	filters.ContentType = req.ContentType
+
+	// ステータス未指定の場合は公開中のコンテンツのみ検索する
+	status := entities.StatusPublished
+	if req.Status != nil {
+		status = entities.ContentStatus(*req.Status)
+	}
+	filters.Status = &status
+	restrictToReadable(c, &filters)
+
+	if req.Limit > 0 {
+		filters.Limit = req.Limit
+	}
+	filters.Offset = req.Offset
+
+	results, total, err := api.repo.Search(c.Request.Context(), query, filters)
+	if errors.Is(err, ErrUnsupportedSearchFilter) {
+		apierror.Abort(c, apierror.Validation("invalid_query", "不正なクエリパラメータです", err))
+		return
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("コンテンツの検索に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &SearchContentsResponse{
+		Results: results,
+		Total:   total,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+	})
+}
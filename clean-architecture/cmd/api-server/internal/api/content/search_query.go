@@ -0,0 +1,109 @@
+package content
+
+import (
+	"strings"
+	"unicode"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+)
+
+// MaxSearchTerms は検索クエリに指定できる語の数
+const MaxSearchTerms = 10
+
+var (
+	ErrEmptySearchQuery       = apierror.NewReason("empty_search_query", "検索キーワードを指定してください")
+	ErrTooManySearchTerms     = apierror.NewReason("too_many_search_terms", "検索キーワードは%d個以下で指定してください").With(MaxSearchTerms)
+	ErrNegatedOnlySearchQuery = apierror.NewReason("negated_only_search_query", "除外条件以外の検索キーワードを1つ以上指定してください")
+)
+
+// SearchTerm は検索クエリ中の1つの条件
+// Words が複数の場合は語順どおりに連続するフレーズとして扱う
+type SearchTerm struct {
+	Words   []string
+	Prefix  bool // 末尾の語を前方一致で検索する（例: data*）
+	Negated bool // 一致するコンテンツを除外する（例: -draft）
+}
+
+// SearchQuery は解析済みの検索クエリで、すべての条件を満たすコンテンツを検索する
+type SearchQuery struct {
+	Terms []SearchTerm
+}
+
+// ParseSearchQuery は "フレーズ"、前方一致（語*）、除外（-語）を含む検索クエリを解析する
+func ParseSearchQuery(q string) (SearchQuery, error) {
+	var query SearchQuery
+	runes := []rune(q)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var term SearchTerm
+		if runes[i] == '-' {
+			term.Negated = true
+			i++
+		}
+
+		var text string
+		if i < len(runes) && runes[i] == '"' {
+			// 閉じる引用符がなければ末尾までをフレーズとする
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			text = string(runes[i+1 : end])
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) {
+				end++
+			}
+			text = string(runes[i:end])
+			i = end
+		}
+
+		if strings.HasSuffix(text, "*") {
+			term.Prefix = true
+			text = strings.TrimRight(text, "*")
+		}
+
+		term.Words = strings.Fields(text)
+		if len(term.Words) == 0 {
+			continue
+		}
+		query.Terms = append(query.Terms, term)
+	}
+
+	if len(query.Terms) == 0 {
+		return SearchQuery{}, ErrEmptySearchQuery
+	}
+
+	if len(query.Terms) > MaxSearchTerms {
+		return SearchQuery{}, ErrTooManySearchTerms
+	}
+
+	// 除外条件のみでは全件が対象になるため受け付けない
+	for _, term := range query.Terms {
+		if !term.Negated {
+			return query, nil
+		}
+	}
+	return SearchQuery{}, ErrNegatedOnlySearchQuery
+}
+
+// HasCJK は分かち書きされない日本語・中国語の文字を含むかを返す
+// PostgreSQL の全文検索は空白で語を区切るため、これらの文字を含むクエリは部分一致で検索する
+func (q SearchQuery) HasCJK() bool {
+	for _, term := range q.Terms {
+		for _, word := range term.Words {
+			for _, r := range word {
+				if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package content
+
+import (
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+)
+
+// MaxSortFields は一度に指定できる並び替えの項目数
+const MaxSortFields = 3
+
+// sortableFields は並び替えに指定できる項目と対応する列
+var sortableFields = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"title":        "title",
+	"author":       "author",
+	"content_type": "content_type",
+}
+
+var ErrInvalidSort = apierror.NewReason("invalid_sort", "並び替えの指定が不正です")
+
+var (
+	errUnsortableField    = ErrInvalidSort.Variant("unsortable_field", "%q は並び替えに使用できません")
+	errDuplicateSortField = ErrInvalidSort.Variant("duplicate_sort_field", "%q が重複しています")
+	errTooManySortFields  = ErrInvalidSort.Variant("too_many_sort_fields", "並び替えの項目は%d個までです")
+)
+
+// SortField は並び替えの項目と方向
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// DefaultSort は並び替え未指定時の順序（作成日時の新しい順）
+func DefaultSort() []SortField {
+	return []SortField{{Column: "created_at", Desc: true}}
+}
+
+// ParseSort はカンマ区切りの並び替え指定を解析する
+// 項目名の先頭に - を付けると降順になる（例: -updated_at,title）
+func ParseSort(value string) ([]SortField, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultSort(), nil
+	}
+
+	fields := []SortField{}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+
+		column, ok := sortableFields[name]
+		if !ok {
+			return nil, errUnsortableField.With(name)
+		}
+		if seen[column] {
+			return nil, errDuplicateSortField.With(name)
+		}
+		seen[column] = true
+
+		fields = append(fields, SortField{Column: column, Desc: desc})
+	}
+
+	if len(fields) > MaxSortFields {
+		return nil, errTooManySortFields.With(MaxSortFields)
+	}
+
+	return fields, nil
+}
+
+// isDefaultSort は並び替えが既定の順序かどうかを判定する
+// カーソルは既定の順序の (created_at, id) 上の位置を表すため、それ以外の順序では利用できない
+func isDefaultSort(fields []SortField) bool {
+	return len(fields) == 1 && fields[0] == DefaultSort()[0]
+}
@@ -0,0 +1,17 @@
+package content
+
+import (
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// annotateSpan はリクエストのスパンに対象のコンテンツのIDとコンテンツタイプを付与する
+func annotateSpan(c *gin.Context, content *entities.Content) {
+	trace.SpanFromContext(c.Request.Context()).SetAttributes(
+		attribute.Int64("content.id", int64(content.ID)),
+		attribute.String("content.type", content.ContentType),
+	)
+}
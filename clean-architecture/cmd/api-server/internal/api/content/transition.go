@@ -0,0 +1,82 @@
+package content
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Submit は下書きのコンテンツをレビュー待ちにするHTTPハンドラー
+func (api *ContentAPI) Submit(c *gin.Context) {
+	api.transition(c, authorizeModify, (*entities.Content).SubmitForReview)
+}
+
+// Publish はレビュー待ちまたはアーカイブ済みのコンテンツを公開するHTTPハンドラー
+func (api *ContentAPI) Publish(c *gin.Context) {
+	api.transition(c, authorizeModify, (*entities.Content).Publish)
+}
+
+// Archive は公開中のコンテンツをアーカイブするHTTPハンドラー
+func (api *ContentAPI) Archive(c *gin.Context) {
+	api.transition(c, authorizeModify, (*entities.Content).Archive)
+}
+
+// ReturnToDraft はコンテンツを下書きに戻すHTTPハンドラー
+// 公開中・アーカイブ済みのコンテンツを下書きに戻すには公開の権限が必要
+func (api *ContentAPI) ReturnToDraft(c *gin.Context) {
+	api.transition(c, authorizeReturnToDraft, (*entities.Content).ReturnToDraft)
+}
+
+// transition は対象コンテンツを取得し、権限を確認してステータス遷移を適用し保存する
+func (api *ContentAPI) transition(c *gin.Context, authorize func(*gin.Context, *entities.Content) bool, apply func(*entities.Content) error) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, ErrContentNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return
+	}
+
+	annotateSpan(c, content)
+
+	if !authorize(c, content) {
+		return
+	}
+
+	// ステータス遷移
+	if err := apply(content); err != nil {
+		apierror.Abort(c, apierror.Conflict("invalid_status_transition", "ステータスを変更できません").WithReason(err))
+		return
+	}
+
+	// DB保存
+	if err := api.repo.Update(c.Request.Context(), content); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			respondPreconditionFailed(c)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツのステータス更新に失敗しました", err))
+		return
+	}
+
+	api.events.RecordContentEvent(ContentUpdated, content.ContentType)
+	setETag(c, content)
+	c.JSON(http.StatusOK, content)
+}
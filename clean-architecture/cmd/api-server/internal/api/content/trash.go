@@ -0,0 +1,74 @@
+package content
+
+import (
+	"net/http"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListTrashRequest はゴミ箱の一覧取得リクエストの構造体
+type ListTrashRequest struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// TrashedContent は削除済みのコンテンツと削除日時
+// PurgeAt は保持期間が設定されている場合の完全削除の予定日時
+type TrashedContent struct {
+	*entities.Content
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
+
+// ListTrashResponse はゴミ箱の一覧取得レスポンスの構造体
+type ListTrashResponse struct {
+	Contents []*TrashedContent `json:"contents"`
+	Total    int64             `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// ListTrash は削除済みのコンテンツ一覧を取得するHTTPハンドラー
+func (api *ContentAPI) ListTrash(c *gin.Context) {
+	var req ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		apierror.Abort(c, apierror.BindQuery(err))
+		return
+	}
+
+	filters := NewContentFilters()
+	if req.Limit > 0 {
+		filters.Limit = req.Limit
+	}
+	filters.Offset = req.Offset
+
+	contents, total, err := api.repo.ListDeleted(c.Request.Context(), filters.Limit, filters.Offset)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("ゴミ箱のコンテンツ一覧の取得に失敗しました", err))
+		return
+	}
+
+	trashed := make([]*TrashedContent, 0, len(contents))
+	for _, content := range contents {
+		item := &TrashedContent{
+			Content:   content,
+			DeletedAt: content.DeletedAt.Time,
+		}
+		if api.trashRetention > 0 {
+			purgeAt := content.DeletedAt.Time.Add(api.trashRetention)
+			item.PurgeAt = &purgeAt
+		}
+		trashed = append(trashed, item)
+	}
+
+	c.JSON(http.StatusOK, &ListTrashResponse{
+		Contents: trashed,
+		Total:    total,
+		Limit:    filters.Limit,
+		Offset:   filters.Offset,
+	})
+}
@@ -0,0 +1,60 @@
+package content
+
+import (
+	"context"
+	"time"
+
+	"go-api-server-sample/internal/infrastructure/logging"
+)
+
+// TrashPurger は保持期間を過ぎたゴミ箱のコンテンツを定期的に完全削除する
+type TrashPurger struct {
+	repo      ContentRepository
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewTrashPurger はTrashPurgerの新しいインスタンスを作成する
+// retention が0以下の場合は完全削除を行わない
+func NewTrashPurger(repo ContentRepository, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		repo:      repo,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Run は ctx がキャンセルされるまで、起動時と interval ごとに期限切れのコンテンツを完全削除する
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.retention <= 0 || p.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.PurgeExpired(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Error("ゴミ箱の完全削除に失敗しました", "error", err)
+		} else if purged > 0 {
+			logging.FromContext(ctx).Info("保持期間を過ぎたコンテンツを完全削除しました", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired は保持期間を過ぎたコンテンツを完全削除し、削除件数を返す
+func (p *TrashPurger) PurgeExpired(ctx context.Context) (int64, error) {
+	if p.retention <= 0 {
+		return 0, nil
+	}
+	return p.repo.PurgeDeletedBefore(ctx, p.now().Add(-p.retention))
+}
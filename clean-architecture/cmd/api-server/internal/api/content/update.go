@@ -5,16 +5,25 @@ import (
 	"net/http"
 	"strconv"
 
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // UpdateContentRequest はコンテンツ更新リクエストの構造体
+// Tags と Fields が省略された場合は既存の値を維持し、空の場合はすべて外す
+// 作成者は認証が無効な場合のみ AuthorID または Author（作成者名）で登録済みの作成者を指定でき、認証済みの場合は既存の作成者を維持する
 type UpdateContentRequest struct {
-	Title       string `json:"title" binding:"required,min=1,max=200"`
-	Body        string `json:"body" binding:"required,min=1"`
-	ContentType string `json:"content_type" binding:"required,oneof=article blog news page"`
-	Author      string `json:"author" binding:"required,min=1,max=100"`
+	Title       string                 `json:"title" binding:"required,min=1,max=200"`
+	Body        string                 `json:"body" binding:"required,min=1"`
+	ContentType string                 `json:"content_type" binding:"required,max=50"`
+	AuthorID    *uint                  `json:"author_id"`
+	Author      string                 `json:"author" binding:"omitempty,max=100"`
+	Tags        []string               `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	Fields      map[string]interface{} `json:"fields"`
 }
 
 // Update はコンテンツを更新するHTTPハンドラー
@@ -22,21 +31,13 @@ func (api *ContentAPI) Update(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なIDです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.InvalidID(err))
 		return
 	}
 
 	var req UpdateContentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なリクエストです",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Bind(err))
 		return
 	}
 
@@ -44,40 +45,65 @@ func (api *ContentAPI) Update(c *gin.Context) {
 	content, err := api.repo.GetByID(c.Request.Context(), uint(id))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"code":    http.StatusNotFound,
-				"message": "指定されたコンテンツが見つかりません",
-			})
+			apierror.Abort(c, ErrContentNotFound)
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの取得に失敗しました",
-			"details": err.Error(),
-		})
+		apierror.Abort(c, apierror.Internal("コンテンツの取得に失敗しました", err))
+		return
+	}
+
+	annotateSpan(c, content)
+
+	// 権限と前提条件の確認
+	if !authorizeModify(c, content) || !api.checkIfMatch(c, content) {
+		return
+	}
+
+	author, opts, ok := api.updatedAuthorFor(c, content, req.AuthorID, req.Author)
+	if !ok {
 		return
 	}
 
 	// コンテンツ更新
-	if err := content.Update(req.Title, req.Body, req.ContentType, req.Author); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"code":    http.StatusBadRequest,
-			"message": "不正なリクエストです",
-			"details": err.Error(),
-		})
+	before := *content
+	if req.Tags != nil {
+		opts = append(opts, entities.WithTags(req.Tags))
+	}
+	if req.Fields != nil {
+		opts = append(opts, entities.WithFields(req.Fields))
+	}
+	err = content.Update(req.Title, req.Body, req.ContentType, author, opts...)
+	if err == nil {
+		err = content.ValidateContentType(api.contentTypes)
+	}
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
 		return
 	}
 
-	// DB保存
-	if err := api.repo.Update(c.Request.Context(), content); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    http.StatusInternalServerError,
-			"message": "コンテンツの更新に失敗しました",
-			"details": err.Error(),
-		})
+	// DB保存（内容に変更があればリビジョンを記録）
+	if changedFields := content.ChangedFieldsFrom(&before); len(changedFields) > 0 {
+		revision := entities.NewContentRevision(content, changedFields, middleware.EditorName(c, content.Author))
+		err = api.repo.UpdateWithRevision(c.Request.Context(), content, revision)
+	} else {
+		err = api.repo.Update(c.Request.Context(), content)
+	}
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			respondPreconditionFailed(c)
+			return
+		}
+		if errors.Is(err, entities.ErrUnknownAuthor) {
+			apierror.Abort(c, apierror.Validation("invalid_content", "不正なリクエストです", err))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツの更新に失敗しました", err))
 		return
 	}
 
+	api.events.RecordContentEvent(ContentUpdated, content.ContentType)
+	setETag(c, content)
 	c.JSON(http.StatusOK, content)
 }
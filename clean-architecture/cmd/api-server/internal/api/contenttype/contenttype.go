@@ -0,0 +1,78 @@
+package contenttype
+
+import (
+	"context"
+	"errors"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrContentTypeAlreadyExists は同名のコンテンツタイプが既に存在することを表す
+	ErrContentTypeAlreadyExists = errors.New("同じ名前のコンテンツタイプが既に存在します")
+	// ErrContentTypeInUse はコンテンツタイプを参照するコンテンツが存在することを表す
+	ErrContentTypeInUse = errors.New("コンテンツタイプを使用しているコンテンツが存在するため削除できません")
+)
+
+var errContentTypeNotFound = apierror.NotFound("content_type_not_found", "指定されたコンテンツタイプが見つかりません")
+
+// ContentTypeRepository はコンテンツタイプの永続化を担当するリポジトリインターフェース
+type ContentTypeRepository interface {
+	List(ctx context.Context) ([]*entities.ContentType, error)
+	GetByName(ctx context.Context, name string) (*entities.ContentType, error)
+	// Create は同名のコンテンツタイプが存在する場合は ErrContentTypeAlreadyExists を返す
+	Create(ctx context.Context, contentType *entities.ContentType) error
+	Update(ctx context.Context, contentType *entities.ContentType) error
+	// Delete は削除済みを含むコンテンツから参照されている場合は ErrContentTypeInUse を返す
+	Delete(ctx context.Context, contentType *entities.ContentType) error
+	// AuthorsExist は指定されたIDの作成者がすべて登録されているかを返す
+	AuthorsExist(ctx context.Context, ids []uint) (bool, error)
+}
+
+// ContentTypeAPI はContentType関連のHTTPハンドラーを提供する構造体
+type ContentTypeAPI struct {
+	repo     ContentTypeRepository
+	registry *entities.ContentTypeRegistry
+}
+
+// NewContentTypeAPI はContentTypeAPIの新しいインスタンスを作成する
+// registry はコンテンツの検証で参照されるレジストリで、変更のたびに再読み込みされる
+func NewContentTypeAPI(repo ContentTypeRepository, registry *entities.ContentTypeRegistry) *ContentTypeAPI {
+	return &ContentTypeAPI{
+		repo:     repo,
+		registry: registry,
+	}
+}
+
+// authorsExist は許可する作成者がすべて登録されているかを確認する
+// 登録されていない作成者が含まれる場合や確認に失敗した場合はエラーレスポンスを返して false を返す
+func (api *ContentTypeAPI) authorsExist(c *gin.Context, ids []uint) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	exists, err := api.repo.AuthorsExist(c.Request.Context(), ids)
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("作成者の取得に失敗しました", err))
+		return false
+	}
+	if !exists {
+		apierror.Abort(c, apierror.Validation("invalid_content_type", "不正なリクエストです", entities.ErrUnknownAuthor))
+		return false
+	}
+	return true
+}
+
+// Reload はデータベースに登録されたコンテンツタイプでレジストリを置き換える
+// スキーマをコンパイルできないタイプがある場合はエラーを返し、レジストリは変更しない
+func (api *ContentTypeAPI) Reload(ctx context.Context) error {
+	types, err := api.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	return api.registry.Replace(types)
+}
@@ -0,0 +1,67 @@
+package contenttype
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateContentTypeRequest はコンテンツタイプ作成リクエストの構造体
+type CreateContentTypeRequest struct {
+	Name           string   `json:"name" binding:"required,min=1,max=50"`
+	Description    string   `json:"description" binding:"omitempty,max=200"`
+	MaxBodyLength  int      `json:"max_body_length" binding:"omitempty,min=0"`
+	RequiredFields []string `json:"required_fields" binding:"omitempty,dive,min=1"`
+	// AllowedAuthors は投稿できる作成者のIDで、登録済みの作成者のみ指定できる
+	AllowedAuthors []uint `json:"allowed_authors" binding:"omitempty,dive,min=1"`
+	// FieldsSchema はカスタムフィールドを検証するJSON Schema
+	FieldsSchema map[string]interface{} `json:"fields_schema"`
+}
+
+// Create はコンテンツタイプを作成するHTTPハンドラー
+func (api *ContentTypeAPI) Create(c *gin.Context) {
+	var req CreateContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	contentType, err := entities.NewContentType(req.Name, req.Description, req.MaxBodyLength, req.RequiredFields, req.AllowedAuthors, req.FieldsSchema)
+	if err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_content_type", "不正なリクエストです", err))
+		return
+	}
+
+	if !api.authorsExist(c, contentType.AllowedAuthors) {
+		return
+	}
+
+	if err := api.repo.Create(c.Request.Context(), contentType); err != nil {
+		if errors.Is(err, ErrContentTypeAlreadyExists) {
+			apierror.Abort(c, apierror.Conflict("content_type_already_exists", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツタイプの作成に失敗しました", err))
+		return
+	}
+
+	if !api.reload(c) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, contentType)
+}
+
+// reload は変更後のコンテンツタイプをレジストリに反映し、失敗した場合はエラーレスポンスを返してfalseを返す
+func (api *ContentTypeAPI) reload(c *gin.Context) bool {
+	if err := api.Reload(c.Request.Context()); err != nil {
+		apierror.Abort(c, apierror.Internal("コンテンツタイプの再読み込みに失敗しました", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package contenttype
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Delete はコンテンツタイプを削除するHTTPハンドラー
+func (api *ContentTypeAPI) Delete(c *gin.Context) {
+	contentType, ok := api.find(c)
+	if !ok {
+		return
+	}
+
+	if err := api.repo.Delete(c.Request.Context(), contentType); err != nil {
+		if errors.Is(err, ErrContentTypeInUse) {
+			apierror.Abort(c, apierror.Conflict("content_type_in_use", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツタイプの削除に失敗しました", err))
+		return
+	}
+
+	if !api.reload(c) {
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
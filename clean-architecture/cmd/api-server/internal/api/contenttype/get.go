@@ -0,0 +1,38 @@
+package contenttype
+
+import (
+	"errors"
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetByName は名前を指定してコンテンツタイプを取得するHTTPハンドラー
+func (api *ContentTypeAPI) GetByName(c *gin.Context) {
+	contentType, ok := api.find(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, contentType)
+}
+
+// find はパスパラメータの名前でコンテンツタイプを取得し、取得できない場合はエラーレスポンスを返してfalseを返す
+func (api *ContentTypeAPI) find(c *gin.Context) (*entities.ContentType, bool) {
+	contentType, err := api.repo.GetByName(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errContentTypeNotFound)
+			return nil, false
+		}
+
+		apierror.Abort(c, apierror.Internal("コンテンツタイプの取得に失敗しました", err))
+		return nil, false
+	}
+
+	return contentType, true
+}
@@ -0,0 +1,28 @@
+package contenttype
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListContentTypesResponse はコンテンツタイプ一覧レスポンスの構造体
+type ListContentTypesResponse struct {
+	ContentTypes []*entities.ContentType `json:"content_types"`
+}
+
+// List はコンテンツタイプ一覧を取得するHTTPハンドラー
+func (api *ContentTypeAPI) List(c *gin.Context) {
+	types, err := api.repo.List(c.Request.Context())
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("コンテンツタイプ一覧の取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListContentTypesResponse{
+		ContentTypes: types,
+	})
+}
@@ -0,0 +1,47 @@
+package contenttype
+
+import (
+	"context"
+	"time"
+
+	"go-api-server-sample/internal/infrastructure/logging"
+)
+
+// Refresher はデータベースのコンテンツタイプを定期的に読み込み直し、レジストリに反映する
+// 他のインスタンスで行われた変更も interval 以内にコンテンツの検証へ反映される
+type Refresher struct {
+	api      *ContentTypeAPI
+	interval time.Duration
+}
+
+// NewRefresher はRefresherの新しいインスタンスを作成する
+// interval が0以下の場合は読み込み直さない
+func NewRefresher(api *ContentTypeAPI, interval time.Duration) *Refresher {
+	return &Refresher{
+		api:      api,
+		interval: interval,
+	}
+}
+
+// Run は ctx がキャンセルされるまで interval ごとにコンテンツタイプを読み込み直す
+// 読み込みに失敗した場合は直前の登録内容のまま検証を続ける
+func (r *Refresher) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.api.Reload(ctx); err != nil {
+			logging.FromContext(ctx).Error("コンテンツタイプの再読み込みに失敗しました", "error", err)
+		}
+	}
+}
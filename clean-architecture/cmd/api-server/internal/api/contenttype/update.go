@@ -0,0 +1,56 @@
+package contenttype
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateContentTypeRequest はコンテンツタイプ更新リクエストの構造体
+// 名前はコンテンツから参照されるため変更できない
+type UpdateContentTypeRequest struct {
+	Description    string   `json:"description" binding:"omitempty,max=200"`
+	MaxBodyLength  int      `json:"max_body_length" binding:"omitempty,min=0"`
+	RequiredFields []string `json:"required_fields" binding:"omitempty,dive,min=1"`
+	// AllowedAuthors は投稿できる作成者のIDで、登録済みの作成者のみ指定できる
+	AllowedAuthors []uint `json:"allowed_authors" binding:"omitempty,dive,min=1"`
+	// FieldsSchema はカスタムフィールドを検証するJSON Schema
+	FieldsSchema map[string]interface{} `json:"fields_schema"`
+}
+
+// Update はコンテンツタイプの制約を更新するHTTPハンドラー
+// 変更は以降の作成・更新にのみ適用され、既存のコンテンツは再検証しない
+func (api *ContentTypeAPI) Update(c *gin.Context) {
+	var req UpdateContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	contentType, ok := api.find(c)
+	if !ok {
+		return
+	}
+
+	if err := contentType.Update(req.Description, req.MaxBodyLength, req.RequiredFields, req.AllowedAuthors, req.FieldsSchema); err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_content_type", "不正なリクエストです", err))
+		return
+	}
+
+	if !api.authorsExist(c, contentType.AllowedAuthors) {
+		return
+	}
+
+	if err := api.repo.Update(c.Request.Context(), contentType); err != nil {
+		apierror.Abort(c, apierror.Internal("コンテンツタイプの更新に失敗しました", err))
+		return
+	}
+
+	if !api.reload(c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, contentType)
+}
@@ -2,22 +2,60 @@ package health
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
+// DatabaseCheck はデータベースへの接続を確認するチェックの名前
+// /health のレスポンスの database はこの名前のチェックの結果を返す
+const DatabaseCheck = "database"
+
 // HealthAPI はヘルスチェック関連のHTTPハンドラーを提供する構造体
 type HealthAPI struct {
-	db *gorm.DB
+	checks registry
+	// shuttingDown はサーバーの停止を開始したかどうか
+	shuttingDown atomic.Bool
+}
+
+// Option はHealthAPIの振る舞いを変更するオプション
+type Option func(*HealthAPI)
+
+// WithCacheTTL はチェック結果をキャッシュする期間を設定する
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(api *HealthAPI) {
+		api.checks.cacheTTL = ttl
+	}
+}
+
+// WithCheckTimeout はチェック1つあたりの制限時間を設定する
+func WithCheckTimeout(timeout time.Duration) Option {
+	return func(api *HealthAPI) {
+		api.checks.timeout = timeout
+	}
 }
 
 // NewHealthAPI はHealthAPIの新しいインスタンスを作成する
-func NewHealthAPI(db *gorm.DB) *HealthAPI {
-	return &HealthAPI{
-		db: db,
+func NewHealthAPI(opts ...Option) *HealthAPI {
+	api := &HealthAPI{}
+	for _, opt := range opts {
+		opt(api)
 	}
+	return api
+}
+
+// Register は /readyz と /health で実行する依存先のチェックを登録する
+func (api *HealthAPI) Register(name string, check CheckFunc, opts ...CheckOption) {
+	api.checks.register(name, check, opts...)
+}
+
+// SetShuttingDown はサーバーの停止を開始したことを設定する
+// 以降のヘルスチェックは503を返し、ロードバランサーに振り分けを止めさせる
+func (api *HealthAPI) SetShuttingDown() {
+	api.shuttingDown.Store(true)
 }
 
 // HealthCheckResponse はヘルスチェックレスポンスの構造体
@@ -28,37 +66,115 @@ type HealthCheckResponse struct {
 	Message   string    `json:"message,omitempty"`
 }
 
+// ProbeResponse は /livez と /readyz のレスポンスの構造体
+// Checks は verbose を指定した場合のみ返す
+type ProbeResponse struct {
+	Status    string        `json:"status"`
+	Timestamp time.Time     `json:"timestamp"`
+	Message   string        `json:"message,omitempty"`
+	Checks    []CheckResult `json:"checks,omitempty"`
+}
+
 // Check はヘルスチェックを実行するHTTPハンドラー
+// 必須のチェックが失敗した場合は503を返す
 func (api *HealthAPI) Check(c *gin.Context) {
 	response := &HealthCheckResponse{
 		Timestamp: time.Now(),
 	}
 
-	var dbStatus string
-	var healthyStatus = true
+	if api.shuttingDown.Load() {
+		response.Status = "shutting_down"
+		response.Message = "サーバーを停止しています"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
 
-	if api.db != nil {
-		sqlDB, err := api.db.DB()
-		if err != nil {
-			dbStatus = "disconnected"
-			healthyStatus = false
+	results, healthy := api.checks.run(c.Request.Context())
+	for _, result := range results {
+		if result.Name != DatabaseCheck {
+			continue
+		}
+		if result.Status == CheckStatusFail {
+			response.Database = "disconnected"
 		} else {
-			if err := sqlDB.PingContext(c.Request.Context()); err != nil {
-				dbStatus = "disconnected"
-				healthyStatus = false
-			} else {
-				dbStatus = "connected"
-			}
+			response.Database = "connected"
 		}
-		response.Database = dbStatus
 	}
 
-	if healthyStatus {
+	if healthy {
 		response.Status = "healthy"
-	} else {
-		response.Status = "unhealthy"
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	response.Status = "unhealthy"
+	if response.Database == "disconnected" {
 		response.Message = "データベース接続に問題があります"
+	} else {
+		response.Message = "依存先に問題があります: " + strings.Join(failedChecks(results), ", ")
 	}
+	c.JSON(http.StatusServiceUnavailable, response)
+}
 
-	c.JSON(http.StatusOK, response)
+// Livez はプロセスが応答できるかを返すHTTPハンドラー
+// 依存先の障害で再起動されないよう、依存先のチェックは実行せず、停止処理中も200を返す
+func (api *HealthAPI) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, &ProbeResponse{
+		Status:    "ok",
+		Timestamp: time.Now(),
+	})
+}
+
+// Readyz はリクエストを受け付けられるかを返すHTTPハンドラー
+// 停止処理中または必須のチェックが失敗した場合は503を返す
+// クエリパラメータ verbose を指定すると、チェックごとの結果（所要時間・直近のエラー）を返す
+func (api *HealthAPI) Readyz(c *gin.Context) {
+	response := &ProbeResponse{
+		Timestamp: time.Now(),
+	}
+
+	if api.shuttingDown.Load() {
+		response.Status = "shutting_down"
+		response.Message = "サーバーを停止しています"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	results, healthy := api.checks.run(c.Request.Context())
+	if isVerbose(c) {
+		response.Checks = results
+	}
+
+	if healthy {
+		response.Status = "ready"
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	response.Status = "not_ready"
+	response.Message = "依存先に問題があります: " + strings.Join(failedChecks(results), ", ")
+	c.JSON(http.StatusServiceUnavailable, response)
+}
+
+// isVerbose は ?verbose または ?verbose=true のように指定された場合に true を返す
+func isVerbose(c *gin.Context) bool {
+	value, ok := c.GetQuery("verbose")
+	if !ok {
+		return false
+	}
+	if value == "" {
+		return true
+	}
+	verbose, err := strconv.ParseBool(value)
+	return err == nil && verbose
+}
+
+func failedChecks(results []CheckResult) []string {
+	var names []string
+	for _, result := range results {
+		if result.Status == CheckStatusFail {
+			names = append(names, result.Name)
+		}
+	}
+	return names
 }
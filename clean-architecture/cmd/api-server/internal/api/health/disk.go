@@ -0,0 +1,21 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// DiskSpaceCheck は path のファイルシステムの空き容量が minFree バイト未満の場合にエラーを返す
+// アップロードされたファイルなどを保存するディレクトリの確認に使う
+func DiskSpaceCheck(path string, minFree uint64) CheckFunc {
+	return func(ctx context.Context) error {
+		free, err := freeDiskSpace(path)
+		if err != nil {
+			return fmt.Errorf("%s の空き容量を取得できません: %w", path, err)
+		}
+		if free < minFree {
+			return fmt.Errorf("%s の空き容量が不足しています（%dMB、必要: %dMB）", path, free>>20, minFree>>20)
+		}
+		return nil
+	}
+}
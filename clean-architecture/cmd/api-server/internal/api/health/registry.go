@@ -0,0 +1,163 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CheckFunc は依存先の状態を確認し、問題がある場合はエラーを返す
+type CheckFunc func(ctx context.Context) error
+
+// チェック結果の状態
+const (
+	CheckStatusOK = "ok"
+	// CheckStatusWarn は準備完了の判定に影響しないチェックが失敗したことを示す
+	CheckStatusWarn = "warn"
+	CheckStatusFail = "fail"
+)
+
+// CheckOption はチェックの振る舞いを変更するオプション
+type CheckOption func(*checker)
+
+// NonCritical は失敗しても準備完了とみなすチェックにする（結果は warn として報告する）
+func NonCritical() CheckOption {
+	return func(c *checker) {
+		c.critical = false
+	}
+}
+
+// CheckResult は1つのチェックの結果
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	LatencyMS float64   `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+	// LastError と LastErrorAt は直近に失敗したときのエラー（回復した後も保持する）
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+type checker struct {
+	name     string
+	check    CheckFunc
+	critical bool
+
+	lastError   string
+	lastErrorAt *time.Time
+}
+
+// registry は登録されたチェックを実行し、結果を一定時間キャッシュする
+// プローブが頻繁に届いても、依存先へのチェックは cacheTTL に1回までしか実行しない
+type registry struct {
+	cacheTTL time.Duration
+	timeout  time.Duration
+
+	mu        sync.Mutex
+	checkers  []*checker
+	results   []CheckResult
+	healthy   bool
+	checkedAt time.Time
+}
+
+func (r *registry) register(name string, check CheckFunc, opts ...CheckOption) {
+	c := &checker{name: name, check: check, critical: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers = append(r.checkers, c)
+	// 次の確認で新しいチェックも実行する
+	r.checkedAt = time.Time{}
+}
+
+// run はキャッシュが有効な場合はその結果を、そうでない場合はすべてのチェックを並行に実行した結果を返す
+// healthy は失敗した必須のチェックがない場合に true になる
+func (r *registry) run(ctx context.Context) (results []CheckResult, healthy bool) {
+	// 実行中のチェックは他のプローブと共有するため、リクエストのキャンセルでは中断しない
+	ctx = context.WithoutCancel(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.cacheTTL {
+		return r.results, r.healthy
+	}
+
+	results = make([]CheckResult, len(r.checkers))
+	var wg sync.WaitGroup
+	for i, c := range r.checkers {
+		wg.Go(func() {
+			results[i] = r.runCheck(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	healthy = true
+	for i, c := range r.checkers {
+		if results[i].Status == CheckStatusFail {
+			healthy = false
+		}
+		// 直近のエラーは実行が終わってから記録し、チェックごとのゴルーチンから共有の状態を変更しない
+		if results[i].Error != "" {
+			c.lastError = results[i].Error
+			c.lastErrorAt = &results[i].CheckedAt
+		}
+		results[i].LastError = c.lastError
+		results[i].LastErrorAt = c.lastErrorAt
+	}
+
+	r.results = results
+	r.healthy = healthy
+	r.checkedAt = time.Now()
+	return results, healthy
+}
+
+func (r *registry) runCheck(ctx context.Context, c *checker) CheckResult {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	// コンテキストを確認しないチェックでも、制限時間を過ぎたら失敗として扱う
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- safeCheck(ctx, c.check)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("%s以内に完了しませんでした", r.timeout)
+	}
+	result := CheckResult{
+		Name:      c.name,
+		Status:    CheckStatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = CheckStatusFail
+		if !c.critical {
+			result.Status = CheckStatusWarn
+		}
+	}
+	return result
+}
+
+// safeCheck はチェックのパニックをエラーとして扱い、他のチェックやプローブに影響させない
+func safeCheck(ctx context.Context, check CheckFunc) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("チェック中にパニックが発生しました: %v", recovered)
+		}
+	}()
+	return check(ctx)
+}
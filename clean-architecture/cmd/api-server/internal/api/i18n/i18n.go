@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 対応している言語（Accept-Language の言語タグの主言語）
+const (
+	Japanese = "ja"
+	English  = "en"
+)
+
+// Default は Accept-Language が指定されていない、または対応している言語を含まない場合の言語
+const Default = Japanese
+
+// catalogs は言語ごとのメッセージカタログ
+// キーは problem.<エラーコード>、reason.<原因のコード>、field.<検証ルール> の形式
+var catalogs = map[string]map[string]string{
+	Japanese: messagesJa,
+	English:  messagesEn,
+}
+
+// Lookup は lang のカタログから key のメッセージの書式を取得する
+// lang のカタログにない場合は既定の言語のメッセージを返す
+func Lookup(lang, key string) (string, bool) {
+	if message, ok := catalogs[lang][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[Default][key]
+	return message, ok
+}
+
+// Format は key のメッセージに args を埋め込んで返す
+// カタログにない場合は fallback を書式として使用する
+func Format(lang, key, fallback string, args ...any) string {
+	message, ok := Lookup(lang, key)
+	if !ok {
+		message = fallback
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Negotiate は Accept-Language ヘッダーの値から対応している言語を選ぶ
+// 品質値（q）の高い順に、主言語（en-US の en）が一致する最初の言語を返す
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(tag, "-")
+		candidates = append(candidates, candidate{lang: strings.ToLower(primary), quality: quality})
+	}
+
+	// 同じ品質値の場合はヘッダーでの順序を優先する
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if c.lang == "*" {
+			return Default
+		}
+		if _, ok := catalogs[c.lang]; ok {
+			return c.lang
+		}
+	}
+	return Default
+}
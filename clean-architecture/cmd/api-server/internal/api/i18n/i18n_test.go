@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type I18nTestSuite struct {
+	suite.Suite
+}
+
+func (suite *I18nTestSuite) TestCatalogs() {
+	suite.Run("すべての言語のカタログに同じキーがある", func() {
+		for lang, catalog := range catalogs {
+			for key := range messagesJa {
+				assert.Contains(suite.T(), catalog, key, lang)
+			}
+			assert.Len(suite.T(), catalog, len(messagesJa), lang)
+		}
+	})
+}
+
+func (suite *I18nTestSuite) TestNegotiate() {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"未指定の場合は既定の言語", "", Japanese},
+		{"地域を含む言語タグは主言語で判定する", "en-US", English},
+		{"品質値の高い言語を優先する", "ja;q=0.5, en;q=0.8", English},
+		{"同じ品質値の場合は先に指定した言語を優先する", "en, ja", English},
+		{"対応していない言語は無視する", "fr-FR, en;q=0.7", English},
+		{"q=0 の言語は選ばない", "en;q=0, fr", Japanese},
+		{"ワイルドカードは既定の言語", "fr, *;q=0.5", Japanese},
+		{"大文字小文字を区別しない", "EN-gb", English},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Equal(suite.T(), tt.expected, Negotiate(tt.acceptLanguage))
+		})
+	}
+}
+
+func (suite *I18nTestSuite) TestFormat() {
+	suite.Run("書式に値を埋め込む", func() {
+		assert.Equal(suite.T(), "Must be at most 50 character(s)", Format(English, "field.max.string", "", "50"))
+	})
+
+	suite.Run("カタログにない言語は既定の言語のメッセージを返す", func() {
+		assert.Equal(suite.T(), "必須項目です", Format("fr", "field.required", ""))
+	})
+
+	suite.Run("カタログにないキーは fallback を返す", func() {
+		assert.Equal(suite.T(), "fallback 1", Format(English, "unknown", "fallback %d", 1))
+	})
+}
+
+func TestI18nTestSuite(t *testing.T) {
+	suite.Run(t, new(I18nTestSuite))
+}
@@ -0,0 +1,106 @@
+package i18n
+
+var messagesEn = map[string]string{
+	// エラーの概要（problem+json の title）
+	"problem.invalid_request":             "Invalid request",
+	"problem.invalid_query":               "Invalid query parameters",
+	"problem.invalid_id":                  "Invalid ID",
+	"problem.invalid_content":             "Invalid content",
+	"problem.invalid_revision_number":     "Invalid revision number",
+	"problem.invalid_api_key_request":     "Invalid API key request",
+	"problem.invalid_author":              "Invalid author",
+	"problem.invalid_content_type":        "Invalid content type",
+	"problem.invalid_tag":                 "Invalid tag",
+	"problem.merge_same_author":           "Invalid author merge",
+	"problem.merge_same_tag":              "Invalid tag merge",
+	"problem.invalid_idempotency_key":     "Invalid Idempotency-Key",
+	"problem.authentication_required":     "Authentication is required",
+	"problem.invalid_token":               "Invalid token",
+	"problem.invalid_api_key":             "Invalid API key",
+	"problem.forbidden":                   "You do not have permission to perform this operation",
+	"problem.content_not_found":           "The specified content was not found",
+	"problem.revision_not_found":          "The specified revision was not found",
+	"problem.content_not_in_trash":        "The specified content is not in the trash",
+	"problem.api_key_not_found":           "The specified API key was not found",
+	"problem.author_not_found":            "The specified author was not found",
+	"problem.content_type_not_found":      "The specified content type was not found",
+	"problem.tag_not_found":               "The specified tag was not found",
+	"problem.invalid_status_transition":   "The status cannot be changed",
+	"problem.api_key_already_revoked":     "The API key has already been revoked",
+	"problem.author_already_exists":       "An author with the same name already exists",
+	"problem.author_in_use":               "The author cannot be deleted because it is referenced by contents or content types",
+	"problem.content_type_already_exists": "A content type with the same name already exists",
+	"problem.content_type_in_use":         "The content type cannot be deleted because it is used by contents",
+	"problem.tag_name_conflict":           "A tag with the same name already exists",
+	"problem.idempotency_key_in_progress": "A request with the same Idempotency-Key is being processed",
+	"problem.idempotency_key_reused":      "The Idempotency-Key has been used with a different request",
+	"problem.if_match_required":           "The If-Match header is required",
+	"problem.version_conflict":            "The content has been updated by another request",
+	"problem.revision_author_unknown":     "The revision cannot be restored because its author is not registered",
+	"problem.rate_limited":                "Too many requests. Please try again later",
+	"problem.internal_error":              "An internal server error occurred",
+
+	// ドメインの検証エラーなど、エラーの原因（problem+json の detail）
+	"reason.invalid_title":               "The title must be between 1 and 200 characters",
+	"reason.invalid_body":                "The body must be at least 1 character",
+	"reason.unknown_content_type":        "The specified content type is not registered",
+	"reason.invalid_author_name":         "The author name must be between 1 and 100 characters",
+	"reason.invalid_status_transition":   "The content cannot be changed to the specified status from its current status",
+	"reason.revision_content_mismatch":   "The specified revision does not belong to the content",
+	"reason.invalid_author_email":        "The email address is invalid",
+	"reason.merge_same_author":           "An author cannot be merged into itself",
+	"reason.unknown_author":              "The specified author is not registered",
+	"reason.invalid_api_key_name":        "The API key name must be between 1 and 100 characters",
+	"reason.invalid_api_key_scope":       "The API key scope is invalid",
+	"reason.api_key_scope_required":      "Specify at least one API key scope",
+	"reason.api_key_expiry_in_past":      "The API key expiry must be in the future",
+	"reason.api_key_already_revoked":     "The API key has already been revoked",
+	"reason.invalid_fields_schema":       "The custom fields schema is invalid",
+	"reason.invalid_fields":              "The custom fields do not match the content type schema",
+	"reason.duplicate_field_name":        "Custom field names must be unique after trimming surrounding spaces",
+	"reason.invalid_content_type_name":   "The content type name must start with a lowercase letter and contain up to 50 lowercase letters, digits, or underscores",
+	"reason.invalid_max_body_length":     "The maximum body length must be 0 or greater",
+	"reason.unsupported_required_field":  "The field cannot be specified as a required field",
+	"reason.invalid_allowed_author":      "Allowed author IDs must be 1 or greater",
+	"reason.body_too_long":               "The body exceeds the maximum length of the content type",
+	"reason.missing_required_field":      "A field required by the content type is missing",
+	"reason.author_not_allowed_for_type": "This author is not allowed to post to the content type",
+	"reason.invalid_idempotency_key":     "The Idempotency-Key must be 1 to 255 printable ASCII characters",
+	"reason.invalid_tag_name":            "Tag names must be between 1 and 50 characters",
+	"reason.too_many_tags":               "Specify 20 or fewer tags",
+	"reason.merge_same_tag":              "A tag cannot be merged into itself",
+	"reason.invalid_sort":                "The sort is invalid",
+	"reason.unsortable_field":            "%q cannot be used for sorting",
+	"reason.duplicate_sort_field":        "%q is specified more than once",
+	"reason.too_many_sort_fields":        "Up to %d sort fields can be specified",
+	"reason.invalid_field_filter":        "The custom field filter is invalid",
+	"reason.too_many_field_filters":      "Specify %d or fewer conditions",
+	"reason.invalid_field_filter_name":   "The field name %q cannot be used",
+	"reason.missing_field_filter_value":  "Specify a value for %s",
+	"reason.invalid_cursor":              "The cursor is invalid",
+	"reason.cursor_with_offset":          "cursor and offset cannot be specified together",
+	"reason.cursor_with_sort":            "cursor can only be specified with the default sort (-created_at)",
+	"reason.invalid_date_range":          "%s must be earlier than %s",
+	"reason.empty_search_query":          "Specify a search keyword",
+	"reason.too_many_search_terms":       "Specify %d or fewer search keywords",
+	"reason.negated_only_search_query":   "Specify at least one search keyword that is not an exclusion",
+	"reason.missing_subject":             "The JWT has no sub claim",
+	"reason.unknown_key_id":              "No verification key matches the JWT key ID",
+	"reason.token_expired":               "The token has expired",
+	"reason.token_signature_invalid":     "The token signature is invalid",
+	"reason.token_malformed":             "The token is malformed",
+	"reason.malformed_request":           "The request is malformed",
+
+	// リクエストの項目ごとの検証エラー（problem+json の errors[].message）
+	"field.required":         "This field is required",
+	"field.min":              "Must be %s or greater",
+	"field.min.string":       "Must be at least %s character(s)",
+	"field.min.items":        "Must contain at least %s item(s)",
+	"field.max":              "Must be %s or less",
+	"field.max.string":       "Must be at most %s character(s)",
+	"field.max.items":        "Must contain at most %s item(s)",
+	"field.oneof":            "Must be one of: %s",
+	"field.type":             "Must be of type %s",
+	"field.positive_integer": "Must be a positive integer",
+	"field.invalid":          "The value is invalid",
+}
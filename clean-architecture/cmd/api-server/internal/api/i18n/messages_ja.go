@@ -0,0 +1,106 @@
+package i18n
+
+var messagesJa = map[string]string{
+	// エラーの概要（problem+json の title）
+	"problem.invalid_request":             "不正なリクエストです",
+	"problem.invalid_query":               "不正なクエリパラメータです",
+	"problem.invalid_id":                  "不正なIDです",
+	"problem.invalid_content":             "不正なリクエストです",
+	"problem.invalid_revision_number":     "不正なリビジョン番号です",
+	"problem.invalid_api_key_request":     "不正なリクエストです",
+	"problem.invalid_author":              "不正なリクエストです",
+	"problem.invalid_content_type":        "不正なリクエストです",
+	"problem.invalid_tag":                 "不正なリクエストです",
+	"problem.merge_same_author":           "不正なリクエストです",
+	"problem.merge_same_tag":              "不正なリクエストです",
+	"problem.invalid_idempotency_key":     "不正なIdempotency-Keyです",
+	"problem.authentication_required":     "認証が必要です",
+	"problem.invalid_token":               "トークンが不正です",
+	"problem.invalid_api_key":             "APIキーが不正です",
+	"problem.forbidden":                   "この操作を行う権限がありません",
+	"problem.content_not_found":           "指定されたコンテンツが見つかりません",
+	"problem.revision_not_found":          "指定されたリビジョンが見つかりません",
+	"problem.content_not_in_trash":        "指定されたコンテンツはゴミ箱にありません",
+	"problem.api_key_not_found":           "指定されたAPIキーが見つかりません",
+	"problem.author_not_found":            "指定された作成者が見つかりません",
+	"problem.content_type_not_found":      "指定されたコンテンツタイプが見つかりません",
+	"problem.tag_not_found":               "指定されたタグが見つかりません",
+	"problem.invalid_status_transition":   "ステータスを変更できません",
+	"problem.api_key_already_revoked":     "APIキーは既に失効しています",
+	"problem.author_already_exists":       "同じ名前の作成者が既に存在します",
+	"problem.author_in_use":               "作成者を参照しているコンテンツまたはコンテンツタイプが存在するため削除できません",
+	"problem.content_type_already_exists": "同じ名前のコンテンツタイプが既に存在します",
+	"problem.content_type_in_use":         "コンテンツタイプを使用しているコンテンツが存在するため削除できません",
+	"problem.tag_name_conflict":           "同じ名前のタグが既に存在します",
+	"problem.idempotency_key_in_progress": "同じIdempotency-Keyのリクエストを処理中です",
+	"problem.idempotency_key_reused":      "Idempotency-Keyが異なるリクエストで使用されています",
+	"problem.if_match_required":           "If-Matchヘッダーを指定してください",
+	"problem.version_conflict":            "コンテンツは他のリクエストにより更新されています",
+	"problem.revision_author_unknown":     "リビジョンの作成者が登録されていないため復元できません",
+	"problem.rate_limited":                "リクエストが多すぎます。しばらくしてから再度お試しください",
+	"problem.internal_error":              "内部サーバーエラーが発生しました",
+
+	// ドメインの検証エラーなど、エラーの原因（problem+json の detail）
+	"reason.invalid_title":               "タイトルは1文字以上200文字以下で入力してください",
+	"reason.invalid_body":                "本文は1文字以上で入力してください",
+	"reason.unknown_content_type":        "指定されたコンテンツタイプは登録されていません",
+	"reason.invalid_author_name":         "作成者名は1文字以上100文字以下で入力してください",
+	"reason.invalid_status_transition":   "現在のステータスからは指定されたステータスに変更できません",
+	"reason.revision_content_mismatch":   "指定されたリビジョンは対象コンテンツのものではありません",
+	"reason.invalid_author_email":        "メールアドレスの形式が正しくありません",
+	"reason.merge_same_author":           "同じ作成者同士はマージできません",
+	"reason.unknown_author":              "指定された作成者は登録されていません",
+	"reason.invalid_api_key_name":        "APIキー名は1文字以上100文字以下で入力してください",
+	"reason.invalid_api_key_scope":       "APIキーのスコープが不正です",
+	"reason.api_key_scope_required":      "APIキーのスコープを1つ以上指定してください",
+	"reason.api_key_expiry_in_past":      "APIキーの有効期限は現在より後の日時を指定してください",
+	"reason.api_key_already_revoked":     "APIキーは既に失効しています",
+	"reason.invalid_fields_schema":       "カスタムフィールドのスキーマが不正です",
+	"reason.invalid_fields":              "カスタムフィールドがコンテンツタイプのスキーマに一致しません",
+	"reason.duplicate_field_name":        "前後の空白を除くと同じになるカスタムフィールド名は指定できません",
+	"reason.invalid_content_type_name":   "コンテンツタイプ名は英小文字で始まる50文字以下の英小文字・数字・アンダースコアで入力してください",
+	"reason.invalid_max_body_length":     "本文の最大文字数は0以上で指定してください",
+	"reason.unsupported_required_field":  "必須フィールドに指定できないフィールドです",
+	"reason.invalid_allowed_author":      "許可する作成者のIDは1以上で指定してください",
+	"reason.body_too_long":               "本文がコンテンツタイプの最大文字数を超えています",
+	"reason.missing_required_field":      "コンテンツタイプで必須とされているフィールドが指定されていません",
+	"reason.author_not_allowed_for_type": "この作成者はコンテンツタイプに投稿できません",
+	"reason.invalid_idempotency_key":     "Idempotency-Keyは1文字以上255文字以下の表示可能なASCII文字で指定してください",
+	"reason.invalid_tag_name":            "タグ名は1文字以上50文字以下で入力してください",
+	"reason.too_many_tags":               "タグは20個以下で指定してください",
+	"reason.merge_same_tag":              "同じタグ同士はマージできません",
+	"reason.invalid_sort":                "並び替えの指定が不正です",
+	"reason.unsortable_field":            "%q は並び替えに使用できません",
+	"reason.duplicate_sort_field":        "%q が重複しています",
+	"reason.too_many_sort_fields":        "並び替えの項目は%d個までです",
+	"reason.invalid_field_filter":        "カスタムフィールドの絞り込み条件が不正です",
+	"reason.too_many_field_filters":      "条件は%d件以下で指定してください",
+	"reason.invalid_field_filter_name":   "フィールド名 %q は使用できません",
+	"reason.missing_field_filter_value":  "%s の値を指定してください",
+	"reason.invalid_cursor":              "カーソルが不正です",
+	"reason.cursor_with_offset":          "cursor と offset は同時に指定できません",
+	"reason.cursor_with_sort":            "cursor は既定の並び順（-created_at）でのみ指定できます",
+	"reason.invalid_date_range":          "%s は %s より前の日時を指定してください",
+	"reason.empty_search_query":          "検索キーワードを指定してください",
+	"reason.too_many_search_terms":       "検索キーワードは%d個以下で指定してください",
+	"reason.negated_only_search_query":   "除外条件以外の検索キーワードを1つ以上指定してください",
+	"reason.missing_subject":             "JWTにsubクレームがありません",
+	"reason.unknown_key_id":              "JWTの鍵IDに対応する検証鍵がありません",
+	"reason.token_expired":               "トークンの有効期限が切れています",
+	"reason.token_signature_invalid":     "トークンの署名が不正です",
+	"reason.token_malformed":             "トークンの形式が正しくありません",
+	"reason.malformed_request":           "リクエストの形式が正しくありません",
+
+	// リクエストの項目ごとの検証エラー（problem+json の errors[].message）
+	"field.required":         "必須項目です",
+	"field.min":              "%s以上で指定してください",
+	"field.min.string":       "%s文字以上で指定してください",
+	"field.min.items":        "%s件以上で指定してください",
+	"field.max":              "%s以下で指定してください",
+	"field.max.string":       "%s文字以下で指定してください",
+	"field.max.items":        "%s件以下で指定してください",
+	"field.oneof":            "%s のいずれかを指定してください",
+	"field.type":             "%s型で指定してください",
+	"field.positive_integer": "正の整数で指定してください",
+	"field.invalid":          "値が正しくありません",
+}
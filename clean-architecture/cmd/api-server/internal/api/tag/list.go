@@ -0,0 +1,27 @@
+package tag
+
+import (
+	"net/http"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListTagsResponse はタグ一覧レスポンスの構造体
+type ListTagsResponse struct {
+	Tags []*TagUsage `json:"tags"`
+}
+
+// List は利用件数付きのタグ一覧を取得するHTTPハンドラー
+func (api *TagAPI) List(c *gin.Context) {
+	tags, err := api.repo.ListWithUsage(c.Request.Context())
+	if err != nil {
+		apierror.Abort(c, apierror.Internal("タグ一覧の取得に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, &ListTagsResponse{
+		Tags: tags,
+	})
+}
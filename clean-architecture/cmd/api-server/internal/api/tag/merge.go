@@ -0,0 +1,63 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MergeTagRequest はタグマージリクエストの構造体
+type MergeTagRequest struct {
+	TargetID uint `json:"target_id" binding:"required,min=1"`
+}
+
+// Merge は指定されたタグをマージ先のタグに統合するHTTPハンドラー
+// マージ元のタグが付与されていたコンテンツにはマージ先のタグが付与され、マージ元のタグは削除される
+func (api *TagAPI) Merge(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	var req MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	if uint(id) == req.TargetID {
+		apierror.Abort(c, apierror.Validation("merge_same_tag", "不正なリクエストです", entities.ErrMergeSameTag))
+		return
+	}
+
+	// マージ元・マージ先のタグを取得
+	tags := make([]*entities.Tag, 0, 2)
+	for _, tagID := range []uint{uint(id), req.TargetID} {
+		tag, err := api.repo.GetByID(c.Request.Context(), tagID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				apierror.Abort(c, errTagNotFound)
+				return
+			}
+
+			apierror.Abort(c, apierror.Internal("タグの取得に失敗しました", err))
+			return
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := api.repo.Merge(c.Request.Context(), tags[0], tags[1]); err != nil {
+		apierror.Abort(c, apierror.Internal("タグのマージに失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, tags[1])
+}
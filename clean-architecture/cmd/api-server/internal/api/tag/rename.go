@@ -0,0 +1,61 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RenameTagRequest はタグ名変更リクエストの構造体
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+// Rename はタグ名を変更するHTTPハンドラー
+func (api *TagAPI) Rename(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		apierror.Abort(c, apierror.InvalidID(err))
+		return
+	}
+
+	var req RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Abort(c, apierror.Bind(err))
+		return
+	}
+
+	tag, err := api.repo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			apierror.Abort(c, errTagNotFound)
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("タグの取得に失敗しました", err))
+		return
+	}
+
+	if err := tag.Rename(req.Name); err != nil {
+		apierror.Abort(c, apierror.Validation("invalid_tag", "不正なリクエストです", err))
+		return
+	}
+
+	if err := api.repo.Rename(c.Request.Context(), tag); err != nil {
+		if errors.Is(err, ErrTagNameConflict) {
+			apierror.Abort(c, apierror.Conflict("tag_name_conflict", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("タグ名の変更に失敗しました", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
@@ -0,0 +1,42 @@
+package tag
+
+import (
+	"context"
+	"errors"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+)
+
+// ErrTagNameConflict は同名のタグが既に存在することを表す
+var ErrTagNameConflict = errors.New("同じ名前のタグが既に存在します")
+
+var errTagNotFound = apierror.NotFound("tag_not_found", "指定されたタグが見つかりません")
+
+// TagRepository はタグの永続化を担当するリポジトリインターフェース
+type TagRepository interface {
+	ListWithUsage(ctx context.Context) ([]*TagUsage, error)
+	GetByID(ctx context.Context, id uint) (*entities.Tag, error)
+	// Rename はタグ名を変更し、同名のタグが存在する場合は ErrTagNameConflict を返す
+	Rename(ctx context.Context, tag *entities.Tag) error
+	// Merge は source に付与されたコンテンツを target に付け替え、source を削除する
+	Merge(ctx context.Context, source, target *entities.Tag) error
+}
+
+// TagUsage はタグと、そのタグが付与された削除されていないコンテンツの件数
+type TagUsage struct {
+	entities.Tag
+	UsageCount int64 `json:"usage_count"`
+}
+
+// TagAPI はTag関連のHTTPハンドラーを提供する構造体
+type TagAPI struct {
+	repo TagRepository
+}
+
+// NewTagAPI はTagAPIの新しいインスタンスを作成する
+func NewTagAPI(repo TagRepository) *TagAPI {
+	return &TagAPI{
+		repo: repo,
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace はメトリクス名の接頭辞
+const namespace = "api"
+
+// Metrics はPrometheus形式のメトリクスを集計する
+// middleware.RequestMetrics と content.EventRecorder を実装する
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+	contentEvents    *prometheus.CounterVec
+}
+
+// New はMetricsの新しいインスタンスを作成する
+// db が nil でない場合はコネクションプールの統計（sql.DBStats）も公開する
+func New(db *sql.DB, dbName string) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "処理したHTTPリクエストの件数",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTPリクエストの処理時間（秒）",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "処理中のHTTPリクエストの件数",
+		}),
+		contentEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "content_events_total",
+			Help:      "コンテンツの作成・更新・削除の件数",
+		}, []string{"event", "content_type"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.requestsInFlight,
+		m.contentEvents,
+	)
+	if db != nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+	}
+
+	return m
+}
+
+// Handler はメトリクスをPrometheusのテキスト形式で返すHTTPハンドラーを返す
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) RequestStarted() func() {
+	m.requestsInFlight.Inc()
+	return m.requestsInFlight.Dec
+}
+
+func (m *Metrics) ObserveRequest(method, route, status string, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, status).Inc()
+	m.requestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordContentEvent(event content.ContentEvent, contentType string) {
+	m.contentEvents.WithLabelValues(string(event), contentType).Inc()
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *Metrics
+}
+
+func (suite *MetricsTestSuite) SetupSubTest() {
+	suite.metrics = New(nil, "")
+}
+
+func (suite *MetricsTestSuite) scrape() string {
+	w := httptest.NewRecorder()
+	suite.metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func (suite *MetricsTestSuite) TestRequests() {
+	suite.Run("ルートとステータスごとにリクエストの件数と処理時間を集計する", func() {
+		// When
+		suite.metrics.ObserveRequest("GET", "/api/v1/contents/:id", "200", 250*time.Millisecond)
+		suite.metrics.ObserveRequest("GET", "/api/v1/contents/:id", "200", 500*time.Millisecond)
+		suite.metrics.ObserveRequest("GET", "/api/v1/contents/:id", "404", 10*time.Millisecond)
+
+		// Then
+		assert.Equal(suite.T(), float64(2), testutil.ToFloat64(suite.metrics.requests.WithLabelValues("GET", "/api/v1/contents/:id", "200")))
+		assert.Equal(suite.T(), float64(1), testutil.ToFloat64(suite.metrics.requests.WithLabelValues("GET", "/api/v1/contents/:id", "404")))
+
+		body := suite.scrape()
+		assert.Contains(suite.T(), body, `api_http_request_duration_seconds_count{method="GET",route="/api/v1/contents/:id",status="200"} 2`)
+		assert.Contains(suite.T(), body, `api_http_request_duration_seconds_sum{method="GET",route="/api/v1/contents/:id",status="200"} 0.75`)
+	})
+
+	suite.Run("処理中のリクエスト数は終了時に減る", func() {
+		// When
+		done := suite.metrics.RequestStarted()
+
+		// Then
+		assert.Equal(suite.T(), float64(1), testutil.ToFloat64(suite.metrics.requestsInFlight))
+		done()
+		assert.Equal(suite.T(), float64(0), testutil.ToFloat64(suite.metrics.requestsInFlight))
+	})
+}
+
+func (suite *MetricsTestSuite) TestContentEvents() {
+	suite.Run("コンテンツタイプごとに変更の件数を集計する", func() {
+		// When
+		suite.metrics.RecordContentEvent(content.ContentCreated, "article")
+		suite.metrics.RecordContentEvent(content.ContentCreated, "article")
+		suite.metrics.RecordContentEvent(content.ContentDeleted, "news")
+
+		// Then
+		assert.Equal(suite.T(), float64(2), testutil.ToFloat64(suite.metrics.contentEvents.WithLabelValues("created", "article")))
+		assert.Equal(suite.T(), float64(1), testutil.ToFloat64(suite.metrics.contentEvents.WithLabelValues("deleted", "news")))
+		assert.Equal(suite.T(), float64(0), testutil.ToFloat64(suite.metrics.contentEvents.WithLabelValues("created", "news")))
+	})
+}
+
+func (suite *MetricsTestSuite) TestDBStats() {
+	suite.Run("コネクションプールの統計を公開する", func() {
+		// Given: 接続はメトリクスの取得では行われない
+		db, err := sql.Open("pgx", "postgres://localhost:1/test")
+		suite.Require().NoError(err)
+		defer db.Close()
+		suite.metrics = New(db, "test")
+
+		// When
+		body := suite.scrape()
+
+		// Then
+		for _, name := range []string{
+			"go_sql_open_connections",
+			"go_sql_idle_connections",
+			"go_sql_wait_count_total",
+			"go_sql_wait_duration_seconds_total",
+		} {
+			assert.Contains(suite.T(), body, name+`{db_name="test"}`)
+		}
+	})
+
+	suite.Run("データベースを指定しない場合は公開しない", func() {
+		assert.False(suite.T(), strings.Contains(suite.scrape(), "go_sql_"))
+	})
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apikey"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) apikey.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	err := r.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uint) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, key *entities.APIKey) error {
+	return r.db.WithContext(ctx).Model(key).Update("revoked_at", key.RevokedAt).Error
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, key *entities.APIKey) error {
+	return r.db.WithContext(ctx).Model(key).Update("last_used_at", key.LastUsedAt).Error
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apikey"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      apikey.APIKeyRepository
+}
+
+func (suite *APIKeyRepositoryTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.APIKey{})
+	suite.Require().NoError(err)
+
+	suite.repo = NewAPIKeyRepository(suite.db)
+}
+
+func (suite *APIKeyRepositoryTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *APIKeyRepositoryTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM api_keys")
+}
+
+func (suite *APIKeyRepositoryTestSuite) createKey(name string) (*entities.APIKey, string) {
+	key, plaintext, err := entities.NewAPIKey(name, []string{entities.APIKeyScopeContentsRead}, nil, "admin")
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.repo.Create(context.Background(), key))
+	return key, plaintext
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestCreate() {
+	suite.Run("長い識別子のプリンシパルが発行したキーも保存できる", func() {
+		ctx := context.Background()
+		subject := strings.Repeat("s", 300)
+		key, _, err := entities.NewAPIKey("取り込みバッチ", []string{entities.APIKeyScopeContentsRead}, nil, subject)
+		suite.Require().NoError(err)
+
+		err = suite.repo.Create(ctx, key)
+
+		suite.Require().NoError(err)
+		found, err := suite.repo.GetByID(ctx, key.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), subject, found.CreatedBy)
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestGetByHash() {
+	suite.Run("平文のキーのハッシュ値で取得できる", func() {
+		ctx := context.Background()
+		key, plaintext := suite.createKey("取り込みバッチ")
+
+		found, err := suite.repo.GetByHash(ctx, entities.HashAPIKey(plaintext))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), key.ID, found.ID)
+		assert.Equal(suite.T(), []string{entities.APIKeyScopeContentsRead}, found.Scopes)
+	})
+
+	suite.Run("一致するキーがない場合は見つからない", func() {
+		suite.createKey("取り込みバッチ")
+
+		_, err := suite.repo.GetByHash(context.Background(), entities.HashAPIKey("gas_unknown"))
+
+		assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestList() {
+	suite.Run("失効済みを含めて作成日時の新しい順に取得できる", func() {
+		ctx := context.Background()
+		older, _ := suite.createKey("古いキー")
+		newer, _ := suite.createKey("新しいキー")
+		suite.Require().NoError(older.Revoke(time.Now()))
+		suite.Require().NoError(suite.repo.Revoke(ctx, older))
+
+		keys, err := suite.repo.List(ctx)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(keys, 2)
+		assert.Equal(suite.T(), newer.ID, keys[0].ID)
+		assert.Equal(suite.T(), older.ID, keys[1].ID)
+		assert.NotNil(suite.T(), keys[1].RevokedAt)
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestUpdateLastUsed() {
+	suite.Run("最終使用日時のみが更新される", func() {
+		ctx := context.Background()
+		key, _ := suite.createKey("取り込みバッチ")
+		usedAt := time.Now()
+		key.LastUsedAt = &usedAt
+		key.Name = "保存されない名前"
+
+		err := suite.repo.UpdateLastUsed(ctx, key)
+
+		assert.NoError(suite.T(), err)
+		updated, err := suite.repo.GetByID(ctx, key.ID)
+		suite.Require().NoError(err)
+		suite.Require().NotNil(updated.LastUsedAt)
+		assert.WithinDuration(suite.T(), usedAt, *updated.LastUsedAt, time.Millisecond)
+		assert.Equal(suite.T(), "取り込みバッチ", updated.Name)
+	})
+}
+
+func TestAPIKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyRepositoryTestSuite))
+}
@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go-api-server-sample/cmd/api-server/internal/api/author"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type authorRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(db *gorm.DB) author.AuthorRepository {
+	return &authorRepository{
+		db: db,
+	}
+}
+
+func (r *authorRepository) ListWithUsage(ctx context.Context) ([]*author.AuthorUsage, error) {
+	var usages []*author.AuthorUsage
+	err := r.db.WithContext(ctx).
+		Table("authors").
+		Select("authors.*, COUNT(contents.id) AS content_count").
+		Joins("LEFT JOIN contents ON contents.author_id = authors.id AND contents.deleted_at IS NULL").
+		Group("authors.id").
+		Order("authors.name").
+		Scan(&usages).Error
+	if err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (r *authorRepository) GetByID(ctx context.Context, id uint) (*entities.Author, error) {
+	var a entities.Author
+	err := r.db.WithContext(ctx).First(&a, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *authorRepository) Create(ctx context.Context, a *entities.Author) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(a)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return author.ErrAuthorAlreadyExists
+	}
+	return nil
+}
+
+func (r *authorRepository) Update(ctx context.Context, a *entities.Author, editor string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&entities.Author{}).Where("name = ? AND id <> ?", a.Name, a.ID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return author.ErrAuthorAlreadyExists
+		}
+
+		if err := tx.Model(a).Select("name", "email").Updates(a).Error; err != nil {
+			if isUniqueViolation(err) {
+				return author.ErrAuthorAlreadyExists
+			}
+			return err
+		}
+
+		return reassignContents(tx, a.ID, a, editor)
+	})
+}
+
+func (r *authorRepository) Delete(ctx context.Context, a *entities.Author) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// ゴミ箱から復元される可能性があるため、削除済みのコンテンツも参照として扱う
+		var count int64
+		err := tx.Unscoped().Model(&entities.Content{}).Where("author_id = ?", a.ID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return author.ErrAuthorInUse
+		}
+
+		// 許可する作成者から取り除くと制限が外れる場合があるため、参照されている間は削除しない
+		err = tx.Model(&entities.ContentType{}).Where("allowed_authors @> ?::jsonb", allowedAuthorJSON(a.ID)).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return author.ErrAuthorInUse
+		}
+
+		return tx.Delete(a).Error
+	})
+}
+
+func (r *authorRepository) Merge(ctx context.Context, source, target *entities.Author, editor string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reassignContents(tx, source.ID, target, editor); err != nil {
+			return err
+		}
+
+		if err := reassignAllowedAuthors(tx, source.ID, target.ID); err != nil {
+			return err
+		}
+
+		return tx.Delete(&entities.Author{}, source.ID).Error
+	})
+}
+
+// reassignAllowedAuthors はコンテンツタイプの許可する作成者の fromID を toID に置き換える
+// 他のインスタンスのコンテンツタイプのレジストリには定期的な再読み込みで反映される
+func reassignAllowedAuthors(tx *gorm.DB, fromID, toID uint) error {
+	return tx.Exec(`
+		UPDATE content_types
+		SET allowed_authors = (
+				SELECT jsonb_agg(DISTINCT CASE WHEN id::bigint = ? THEN ? ELSE id::bigint END)
+				FROM jsonb_array_elements_text(allowed_authors) AS id
+			),
+			updated_at = NOW()
+		WHERE allowed_authors @> ?::jsonb`,
+		fromID, toID, allowedAuthorJSON(fromID),
+	).Error
+}
+
+// allowedAuthorJSON は作成者IDを許可する作成者の包含検索に使うJSON配列に変換する
+func allowedAuthorJSON(id uint) string {
+	return fmt.Sprintf("[%d]", id)
+}
+
+// reassignContents は作成者 fromID のコンテンツ（削除済みを含む）を作成者 to に付け替える
+// 作成者名が変わったコンテンツには作成者の変更を記録したリビジョンを追加し、バージョンと更新日時を進めて取得済みのETagを無効にする
+// 所有者は作成者名ではなくプリンシパルの識別子で管理するため、付け替えても変更しない
+func reassignContents(tx *gorm.DB, fromID uint, to *entities.Author, editor string) error {
+	// 同一コンテンツへの同時更新とリビジョン番号の採番が衝突しないよう行ロックを取得
+	var ids []uint
+	err := tx.Unscoped().Model(&entities.Content{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("author_id = ? AND author <> ?", fromID, to.Name).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	if len(ids) > 0 {
+		changedFields, err := json.Marshal([]string{entities.FieldAuthor})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+			INSERT INTO content_revisions
+				(content_id, revision_number, title, body, content_type, author, fields, changed_fields, editor, created_at)
+			SELECT c.id,
+				COALESCE((SELECT MAX(r.revision_number) FROM content_revisions r WHERE r.content_id = c.id), 0) + 1,
+				c.title, c.body, c.content_type, ?, c.fields, ?::jsonb, ?, NOW()
+			FROM contents c
+			WHERE c.id IN ?`,
+			to.Name, string(changedFields), editor, ids,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(`
+		UPDATE contents
+		SET author_id = ?, author = ?,
+			version = CASE WHEN author <> ? THEN version + 1 ELSE version END,
+			updated_at = CASE WHEN author <> ? THEN NOW() ELSE updated_at END
+		WHERE author_id = ?`,
+		to.ID, to.Name, to.Name, to.Name, fromID,
+	).Error
+}
@@ -0,0 +1,335 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/author"
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type AuthorRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      author.AuthorRepository
+	contents  content.ContentRepository
+}
+
+func (suite *AuthorRepositoryTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	suite.repo = NewAuthorRepository(suite.db)
+	suite.contents = NewContentRepository(suite.db)
+}
+
+func (suite *AuthorRepositoryTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *AuthorRepositoryTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM content_types WHERE name = 'podcast'")
+	suite.db.Exec("DELETE FROM authors")
+}
+
+// createPodcastType は投稿できる作成者を制限したコンテンツタイプを作成する
+func (suite *AuthorRepositoryTestSuite) createPodcastType(allowedAuthors ...uint) {
+	podcast, err := entities.NewContentType("podcast", "", 0, nil, allowedAuthors, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.db.Create(podcast).Error)
+}
+
+// findContentType はコンテンツタイプを取得する
+func (suite *AuthorRepositoryTestSuite) findContentType(name string) *entities.ContentType {
+	var t entities.ContentType
+	suite.Require().NoError(suite.db.Where("name = ?", name).First(&t).Error)
+	return &t
+}
+
+func (suite *AuthorRepositoryTestSuite) createContent(title, authorName string) *entities.Content {
+	c, _ := entities.NewContent(title, "本文", "article", authorName, entities.RegisterAuthor())
+	suite.Require().NoError(suite.contents.Create(context.Background(), c))
+	return c
+}
+
+func (suite *AuthorRepositoryTestSuite) findAuthor(name string) *entities.Author {
+	var a entities.Author
+	suite.Require().NoError(suite.db.Where("name = ?", name).First(&a).Error)
+	return &a
+}
+
+func (suite *AuthorRepositoryTestSuite) TestResolveAuthor() {
+	suite.Run("コンテンツの作成時に作成者名から作成者が登録され参照される", func() {
+		ctx := context.Background()
+		first := suite.createContent("記事1", "運営チーム")
+		second := suite.createContent("記事2", " 運営チーム ")
+
+		var count int64
+		suite.db.Model(&entities.Author{}).Count(&count)
+		assert.Equal(suite.T(), int64(1), count)
+
+		for _, id := range []uint{first.ID, second.ID} {
+			found, err := suite.contents.GetByID(ctx, id)
+			suite.Require().NoError(err)
+			suite.Require().NotNil(found.AuthorSummary)
+			assert.Equal(suite.T(), "運営チーム", found.AuthorSummary.Name)
+			assert.Equal(suite.T(), found.AuthorSummary.ID, *found.AuthorID)
+		}
+	})
+
+	suite.Run("コンテンツの作成者名を変更すると別の作成者を参照する", func() {
+		ctx := context.Background()
+		c := suite.createContent("記事1", "運営チーム")
+		suite.Require().NoError(c.Update(c.Title, c.Body, c.ContentType, "法務チーム"))
+
+		suite.Require().NoError(suite.contents.Update(ctx, c))
+
+		found, err := suite.contents.GetByID(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), suite.findAuthor("法務チーム").ID, *found.AuthorID)
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestListWithUsage() {
+	suite.Run("削除されていないコンテンツの件数とともに取得できる", func() {
+		ctx := context.Background()
+		suite.createContent("記事1", "運営チーム")
+		deleted := suite.createContent("記事2", "運営チーム")
+		suite.Require().NoError(suite.contents.Delete(ctx, deleted))
+		unused, _ := entities.NewAuthor("法務チーム", "")
+		suite.Require().NoError(suite.repo.Create(ctx, unused))
+
+		usages, err := suite.repo.ListWithUsage(ctx)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(usages, 2)
+		assert.Equal(suite.T(), "法務チーム", usages[0].Name)
+		assert.Equal(suite.T(), int64(0), usages[0].ContentCount)
+		assert.Equal(suite.T(), "運営チーム", usages[1].Name)
+		assert.Equal(suite.T(), int64(1), usages[1].ContentCount)
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestUpdate() {
+	suite.Run("作成者名の変更がコンテンツの作成者名に反映され、バージョンが進む", func() {
+		ctx := context.Background()
+		c, _ := entities.NewContent("記事1", "本文", "article", "運営チーム", entities.WithOwner("user-1"), entities.RegisterAuthor())
+		suite.Require().NoError(suite.contents.Create(ctx, c))
+		a := suite.findAuthor("運営チーム")
+
+		suite.Require().NoError(a.Update("運営部", ""))
+		err := suite.repo.Update(ctx, a, "管理者")
+
+		assert.NoError(suite.T(), err)
+		found, err := suite.contents.GetByID(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "運営部", found.Author)
+		assert.Equal(suite.T(), c.Version+1, found.Version)
+		assert.True(suite.T(), found.UpdatedAt.After(c.UpdatedAt))
+		assert.True(suite.T(), found.IsOwnedBy("user-1"))
+
+		// 作成者の変更がリビジョンとして記録される
+		revisions, err := suite.contents.ListRevisions(ctx, c.ID)
+		suite.Require().NoError(err)
+		suite.Require().Len(revisions, 2)
+		assert.Equal(suite.T(), 2, revisions[0].RevisionNumber)
+		assert.Equal(suite.T(), "運営部", revisions[0].Author)
+		assert.Equal(suite.T(), []string{entities.FieldAuthor}, revisions[0].ChangedFields)
+		assert.Equal(suite.T(), "管理者", revisions[0].Editor)
+	})
+
+	suite.Run("メールアドレスのみの変更ではリビジョンを記録しない", func() {
+		ctx := context.Background()
+		c := suite.createContent("記事1", "運営チーム")
+		a := suite.findAuthor("運営チーム")
+
+		suite.Require().NoError(a.Update("運営チーム", "ops@example.com"))
+		err := suite.repo.Update(ctx, a, "管理者")
+
+		assert.NoError(suite.T(), err)
+		found, err := suite.contents.GetByID(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), c.Version, found.Version)
+
+		revisions, err := suite.contents.ListRevisions(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Len(suite.T(), revisions, 1)
+	})
+
+	suite.Run("既存の作成者名には変更できない", func() {
+		ctx := context.Background()
+		suite.createContent("記事1", "運営チーム")
+		suite.createContent("記事2", "法務チーム")
+		a := suite.findAuthor("運営チーム")
+
+		suite.Require().NoError(a.Update("法務チーム", ""))
+		err := suite.repo.Update(ctx, a, "管理者")
+
+		assert.ErrorIs(suite.T(), err, author.ErrAuthorAlreadyExists)
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestDelete() {
+	suite.Run("削除済みのコンテンツから参照されている作成者は削除できない", func() {
+		ctx := context.Background()
+		c := suite.createContent("記事1", "運営チーム")
+		suite.Require().NoError(suite.contents.Delete(ctx, c))
+
+		err := suite.repo.Delete(ctx, suite.findAuthor("運営チーム"))
+
+		assert.ErrorIs(suite.T(), err, author.ErrAuthorInUse)
+	})
+
+	suite.Run("コンテンツタイプの許可する作成者に含まれる作成者は削除できない", func() {
+		// Given
+		ctx := context.Background()
+		a := &entities.Author{Name: "配信者"}
+		suite.Require().NoError(suite.db.Create(a).Error)
+		suite.createPodcastType(a.ID)
+
+		// When
+		err := suite.repo.Delete(ctx, a)
+
+		// Then
+		assert.ErrorIs(suite.T(), err, author.ErrAuthorInUse)
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestMerge() {
+	suite.Run("マージ元の作成者のコンテンツが付け替えられ、マージ元は削除される", func() {
+		ctx := context.Background()
+		c := suite.createContent("記事1", "運営チ-ム")
+		suite.createContent("記事2", "運営チーム")
+		source, target := suite.findAuthor("運営チ-ム"), suite.findAuthor("運営チーム")
+
+		err := suite.repo.Merge(ctx, source, target, "管理者")
+
+		assert.NoError(suite.T(), err)
+
+		_, err = suite.repo.GetByID(ctx, source.ID)
+		assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+		found, err := suite.contents.GetByID(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "運営チーム", found.Author)
+		assert.Equal(suite.T(), target.ID, *found.AuthorID)
+		assert.Equal(suite.T(), c.Version+1, found.Version)
+
+		revisions, err := suite.contents.ListRevisions(ctx, c.ID)
+		suite.Require().NoError(err)
+		suite.Require().Len(revisions, 2)
+		assert.Equal(suite.T(), "運営チーム", revisions[0].Author)
+		assert.Equal(suite.T(), "管理者", revisions[0].Editor)
+	})
+
+	suite.Run("コンテンツタイプの許可する作成者がマージ先に置き換えられる", func() {
+		// Given
+		ctx := context.Background()
+		source, target := &entities.Author{Name: "配信者"}, &entities.Author{Name: "配信チーム"}
+		suite.Require().NoError(suite.db.Create(source).Error)
+		suite.Require().NoError(suite.db.Create(target).Error)
+		suite.createPodcastType(source.ID, target.ID)
+
+		// When
+		err := suite.repo.Merge(ctx, source, target, "管理者")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), []uint{target.ID}, suite.findContentType("podcast").AllowedAuthors)
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestBackfill() {
+	suite.Run("作成者を参照していないコンテンツの作成者名から作成者が登録される", func() {
+		suite.Require().NoError(suite.db.Exec(`
+			INSERT INTO contents (title, body, content_type, author, fields, created_at, updated_at)
+			VALUES ('記事1', '本文', 'article', '運営チーム', '{}', NOW(), NOW()),
+			       ('記事2', '本文', 'article', '運営チーム ', '{}', NOW(), NOW()),
+			       ('記事3', '本文', 'article', '　運営チーム　', '{}', NOW(), NOW())`,
+		).Error)
+
+		err := database.Migrate(suite.db)
+
+		assert.NoError(suite.T(), err)
+
+		var authors []entities.Author
+		suite.db.Find(&authors)
+		suite.Require().Len(authors, 1)
+		assert.Equal(suite.T(), "運営チーム", authors[0].Name)
+
+		var contents []entities.Content
+		suite.db.Find(&contents)
+		for _, c := range contents {
+			suite.Require().NotNil(c.AuthorID)
+			assert.Equal(suite.T(), authors[0].ID, *c.AuthorID)
+			assert.Equal(suite.T(), "運営チーム", c.Author)
+		}
+	})
+}
+
+func (suite *AuthorRepositoryTestSuite) TestMigrateAllowedAuthors() {
+	suite.Run("コンテンツタイプの許可する作成者名が作成者IDに置き換えられる", func() {
+		// Given: 作成者名で許可する作成者を保持していたコンテンツタイプ
+		registered := &entities.Author{Name: "運営チーム"}
+		suite.Require().NoError(suite.db.Create(registered).Error)
+		suite.Require().NoError(suite.db.Exec(`
+			INSERT INTO content_types (name, description, max_body_length, required_fields, allowed_authors, created_at, updated_at)
+			VALUES ('podcast', '', 0, '[]', '["運営チーム", " 配信者 "]', NOW(), NOW())`,
+		).Error)
+
+		// When
+		err := database.Migrate(suite.db)
+
+		// Then: 未登録の作成者名は作成者として登録される
+		suite.Require().NoError(err)
+		unregistered := suite.findAuthor("配信者")
+		assert.ElementsMatch(suite.T(), []uint{registered.ID, unregistered.ID}, suite.findContentType("podcast").AllowedAuthors)
+	})
+}
+
+func TestAuthorRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorRepositoryTestSuite))
+}
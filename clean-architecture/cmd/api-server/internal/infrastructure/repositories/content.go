@@ -2,11 +2,18 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"go-api-server-sample/cmd/api-server/internal/api/content"
 	"go-api-server-sample/internal/domain/entities"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type contentRepository struct {
@@ -20,24 +27,100 @@ func NewContentRepository(db *gorm.DB) content.ContentRepository {
 }
 
 func (r *contentRepository) Create(ctx context.Context, content *entities.Content) error {
-	return r.db.WithContext(ctx).Create(content).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := resolveAuthor(tx, content); err != nil {
+			return err
+		}
+
+		if err := resolveTags(tx, content.Tags); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(content).Error; err != nil {
+			return err
+		}
+
+		if err := replaceTags(tx, content); err != nil {
+			return err
+		}
+
+		// 作成時点の内容を最初のリビジョンとして記録
+		revision := entities.NewInitialRevision(content)
+		revision.RevisionNumber = 1
+		return tx.Create(revision).Error
+	})
 }
 
 func (r *contentRepository) GetByID(ctx context.Context, id uint) (*entities.Content, error) {
 	var content entities.Content
-	err := r.db.WithContext(ctx).First(&content, id).Error
+	err := r.db.WithContext(ctx).Scopes(preloadAssociations).First(&content, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &content, nil
 }
 
+func (r *contentRepository) GetAuthorByID(ctx context.Context, id uint) (*entities.Author, error) {
+	var author entities.Author
+	err := r.db.WithContext(ctx).First(&author, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (r *contentRepository) GetAuthorByName(ctx context.Context, name string) (*entities.Author, error) {
+	var author entities.Author
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&author).Error
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
 func (r *contentRepository) List(ctx context.Context, filters content.ContentFilters) ([]*entities.Content, int64, error) {
 	var contents []*entities.Content
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&entities.Content{})
 
+	query = whereFilters(query, filters)
+
+	if !filters.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	order := sortOrder(filters.Sort)
+	if cursor := filters.Cursor; cursor != nil {
+		// カーソルは既定の順序（作成日時の新しい順）でのみ指定される
+		if cursor.Backward {
+			// カーソルより新しい側を近い順に取得し、取得後に並びを戻す
+			query = query.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+			order = sortOrder([]content.SortField{{Column: "created_at"}})
+		} else {
+			query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+	} else {
+		query = query.Offset(filters.Offset)
+	}
+
+	err := query.Scopes(preloadAssociations).Limit(filters.Limit).Order(order).Find(&contents).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Cursor != nil && filters.Cursor.Backward {
+		slices.Reverse(contents)
+	}
+
+	return contents, total, nil
+}
+
+// whereFilters は一覧と検索で共通の絞り込み条件を適用する
+// 件数や並び順に関する Limit、Offset、Sort、Cursor、SkipTotal は呼び出し側で扱う
+func whereFilters(query *gorm.DB, filters content.ContentFilters) *gorm.DB {
 	if filters.ContentType != nil {
 		query = query.Where("content_type = ?", *filters.ContentType)
 	}
@@ -46,22 +129,254 @@ func (r *contentRepository) List(ctx context.Context, filters content.ContentFil
 		query = query.Where("author = ?", *filters.Author)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if filters.AuthorPrefix != nil {
+		query = query.Where("author ILIKE ?", prefixPattern(*filters.AuthorPrefix))
 	}
 
-	err := query.Limit(filters.Limit).Offset(filters.Offset).Order("created_at DESC").Find(&contents).Error
-	if err != nil {
-		return nil, 0, err
+	if filters.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedAfter)
 	}
 
-	return contents, total, nil
+	if filters.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filters.CreatedBefore)
+	}
+
+	if filters.UpdatedAfter != nil {
+		query = query.Where("updated_at >= ?", *filters.UpdatedAfter)
+	}
+
+	if filters.UpdatedBefore != nil {
+		query = query.Where("updated_at < ?", *filters.UpdatedBefore)
+	}
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if filters.OwnerSubject != nil {
+		query = query.Where("owner_subject = ?", *filters.OwnerSubject)
+	}
+
+	if len(filters.Tags) > 0 {
+		// いずれかのタグを持つコンテンツ
+		query = query.Where(
+			"id IN (SELECT ct.content_id FROM content_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name IN ?)",
+			filters.Tags,
+		)
+	}
+
+	if len(filters.TagsAll) > 0 {
+		// 指定されたすべてのタグを持つコンテンツ
+		query = query.Where(
+			"id IN (SELECT ct.content_id FROM content_tags ct JOIN tags t ON t.id = ct.tag_id WHERE t.name IN ? GROUP BY ct.content_id HAVING COUNT(DISTINCT t.id) = ?)",
+			filters.TagsAll, len(filters.TagsAll),
+		)
+	}
+
+	for _, filter := range filters.Fields {
+		query = whereField(query, filter)
+	}
+
+	return query
+}
+
+// sortOrder は並び替えの指定からORDER BY句を作成する
+// 同順位のコンテンツは最後の項目と同じ方向のIDで順序を確定させる
+func sortOrder(fields []content.SortField) clause.OrderBy {
+	if len(fields) == 0 {
+		fields = content.DefaultSort()
+	}
+
+	columns := make([]clause.OrderByColumn, 0, len(fields)+1)
+	for _, field := range fields {
+		columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+	}
+	columns = append(columns, clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: fields[len(fields)-1].Desc})
+
+	return clause.OrderBy{Columns: columns}
+}
+
+// prefixPattern は前方一致のLIKEパターンを作成する
+func prefixPattern(prefix string) string {
+	return escapeLike(prefix) + "%"
+}
+
+// escapeLike はLIKEのワイルドカードをエスケープする
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
 
 func (r *contentRepository) Update(ctx context.Context, content *entities.Content) error {
-	return r.db.WithContext(ctx).Save(content).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveWithVersion(tx, content)
+	})
+}
+
+// Delete は読み込み時のバージョンと一致する場合のみコンテンツを削除（ゴミ箱に移動）する
+// 確認後に他のリクエストが更新していた場合は ErrVersionConflict を返す
+func (r *contentRepository) Delete(ctx context.Context, c *entities.Content) error {
+	result := r.db.WithContext(ctx).Where("version = ?", c.Version).Delete(&entities.Content{}, c.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return content.ErrVersionConflict
+	}
+	return nil
+}
+
+// saveWithVersion は読み込み時のバージョンと一致する行のみを更新し、バージョンを1つ進める
+// タグの付け替えも同じトランザクション内で行うため、tx はトランザクションである必要がある
+func saveWithVersion(tx *gorm.DB, c *entities.Content) error {
+	if err := resolveAuthor(tx, c); err != nil {
+		return err
+	}
+
+	if err := resolveTags(tx, c.Tags); err != nil {
+		return err
+	}
+
+	expected := c.Version
+	c.Version = expected + 1
+
+	result := tx.Model(c).Where("version = ?", expected).Select("*").Omit(clause.Associations).Updates(c)
+	if result.Error != nil {
+		c.Version = expected
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		c.Version = expected
+		return content.ErrVersionConflict
+	}
+
+	return replaceTags(tx, c)
+}
+
+// resolveTags はタグ名に対応するタグを取得し、存在しなければ作成してIDを設定する
+func resolveTags(tx *gorm.DB, tags []*entities.Tag) error {
+	for _, tag := range tags {
+		if tag.ID != 0 {
+			continue
+		}
+
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(tag).Error
+		if err != nil {
+			return err
+		}
+
+		// 既存のタグと衝突した場合はIDが設定されないため取得し直す
+		if tag.ID == 0 {
+			if err := tx.Where("name = ?", tag.Name).First(tag).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveAuthor は作成者名に対応する作成者を取得して参照を設定する
+// 作成者が存在しない場合、作成者の登録を許可されたコンテンツ（プリンシパルの表示名）のみ作成し、それ以外は entities.ErrUnknownAuthor を返す
+func resolveAuthor(tx *gorm.DB, c *entities.Content) error {
+	if c.AuthorID != nil && c.AuthorSummary != nil && c.AuthorSummary.Name == c.Author {
+		return nil
+	}
+
+	if !c.RegistersAuthor() {
+		var author entities.Author
+		if err := tx.Where("name = ?", c.Author).First(&author).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entities.ErrUnknownAuthor
+			}
+			return err
+		}
+
+		c.AuthorID = &author.ID
+		c.AuthorSummary = author.Summary()
+		return nil
+	}
+
+	author := &entities.Author{Name: c.Author}
+	err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(author).Error
+	if err != nil {
+		return err
+	}
+
+	// 既存の作成者と衝突した場合はIDが設定されないため取得し直す
+	if author.ID == 0 {
+		if err := tx.Where("name = ?", author.Name).First(author).Error; err != nil {
+			return err
+		}
+	}
+
+	c.AuthorID = &author.ID
+	c.AuthorSummary = author.Summary()
+	return nil
+}
+
+// replaceTags はコンテンツとタグの関連付けを現在のタグ一覧で置き換える
+func replaceTags(tx *gorm.DB, c *entities.Content) error {
+	tags := c.Tags
+	if tags == nil {
+		tags = []*entities.Tag{}
+	}
+	return tx.Model(c).Association("Tags").Replace(tags)
+}
+
+// whereField はカスタムフィールドの絞り込み条件を追加する
+// 値は数値・真偽値として解釈できればその型で、できなければ文字列として比較する
+func whereField(query *gorm.DB, filter content.FieldFilter) *gorm.DB {
+	typed, jsonType := fieldFilterValue(filter.Value)
+
+	// 等価比較は文字列として保存された値にも一致させ、GINインデックスが使える包含演算子で比較する
+	name := quoteJSON(filter.Name)
+	typedObject := fmt.Sprintf("{%s:%s}", name, typed)
+	stringObject := fmt.Sprintf("{%s:%s}", name, quoteJSON(filter.Value))
+
+	switch filter.Operator {
+	case content.FieldOpEq:
+		return query.Where("(fields @> ?::jsonb OR fields @> ?::jsonb)", typedObject, stringObject)
+	case content.FieldOpNe:
+		return query.Where(
+			"fields -> ?::text IS NOT NULL AND NOT (fields @> ?::jsonb OR fields @> ?::jsonb)",
+			filter.Name, typedObject, stringObject,
+		)
+	case content.FieldOpGt, content.FieldOpGte, content.FieldOpLt, content.FieldOpLte:
+		// 大小比較は型が異なる値と比較しないよう、保存された値の型を揃える
+		return query.Where(
+			"jsonb_typeof(fields -> ?::text) = ? AND fields -> ?::text "+string(filter.Operator)+" ?::jsonb",
+			filter.Name, jsonType, filter.Name, typed,
+		)
+	}
+
+	query.AddError(fmt.Errorf("%w: 演算子 %q には対応していません", content.ErrInvalidFieldFilter, filter.Operator))
+	return query
+}
+
+// fieldFilterValue は絞り込みの値をJSONリテラルとその型名に変換する
+func fieldFilterValue(value string) (string, string) {
+	if value == "true" || value == "false" {
+		return value, "boolean"
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil && json.Valid([]byte(value)) {
+		return value, "number"
+	}
+
+	return quoteJSON(value), "string"
+}
+
+func quoteJSON(value string) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func orderTagsByName(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name")
 }
 
-func (r *contentRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&entities.Content{}, id).Error
+// preloadAssociations はコンテンツのレスポンスに含めるタグと作成者の概要を読み込む
+func preloadAssociations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags", orderTagsByName).Preload("AuthorSummary")
 }
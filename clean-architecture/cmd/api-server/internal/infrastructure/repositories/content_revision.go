@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *contentRepository) UpdateWithRevision(ctx context.Context, content *entities.Content, revision *entities.ContentRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同一コンテンツへの同時更新で採番が衝突しないよう行ロックを取得
+		var locked entities.Content
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, content.ID).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		err := tx.Model(&entities.ContentRevision{}).
+			Where("content_id = ?", content.ID).
+			Select("COALESCE(MAX(revision_number), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		if err := saveWithVersion(tx, content); err != nil {
+			return err
+		}
+
+		revision.ContentID = content.ID
+		revision.RevisionNumber = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+func (r *contentRepository) ListRevisions(ctx context.Context, contentID uint) ([]*entities.ContentRevision, error) {
+	var revisions []*entities.ContentRevision
+	err := r.db.WithContext(ctx).
+		Where("content_id = ?", contentID).
+		Order("revision_number DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *contentRepository) GetRevision(ctx context.Context, contentID uint, revisionNumber int) (*entities.ContentRevision, error) {
+	var revision entities.ContentRevision
+	err := r.db.WithContext(ctx).
+		Where("content_id = ? AND revision_number = ?", contentID, revisionNumber).
+		First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
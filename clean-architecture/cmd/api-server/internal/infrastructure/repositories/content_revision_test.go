@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ContentRepositoryTestSuite) TestRevisions() {
+	suite.Run("作成時に最初のリビジョンが記録される", func() {
+		ctx := context.Background()
+		content, _ := entities.NewContent("タイトル", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, content))
+
+		revisions, err := suite.repo.ListRevisions(ctx, content.ID)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(revisions, 1)
+		assert.Equal(suite.T(), 1, revisions[0].RevisionNumber)
+		assert.Equal(suite.T(), "タイトル", revisions[0].Title)
+	})
+
+	suite.Run("更新ごとにリビジョン番号が採番される", func() {
+		ctx := context.Background()
+		content, _ := entities.NewContent("タイトル1", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, content))
+
+		for _, title := range []string{"タイトル2", "タイトル3"} {
+			before := *content
+			suite.Require().NoError(content.Update(title, "本文", "article", "作成者"))
+			revision := entities.NewContentRevision(content, content.ChangedFieldsFrom(&before), "編集者")
+			suite.Require().NoError(suite.repo.UpdateWithRevision(ctx, content, revision))
+		}
+
+		revisions, err := suite.repo.ListRevisions(ctx, content.ID)
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(revisions, 3)
+		assert.Equal(suite.T(), 3, revisions[0].RevisionNumber)
+		assert.Equal(suite.T(), []string{entities.FieldTitle}, revisions[0].ChangedFields)
+		assert.Equal(suite.T(), "編集者", revisions[0].Editor)
+
+		revision, err := suite.repo.GetRevision(ctx, content.ID, 2)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "タイトル2", revision.Title)
+
+		updated, err := suite.repo.GetByID(ctx, content.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "タイトル3", updated.Title)
+	})
+
+	suite.Run("長い編集者名でもリビジョンが記録される", func() {
+		ctx := context.Background()
+		content, _ := entities.NewContent("タイトル1", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, content))
+
+		// Given: トークンの表示名は長さが制限されない
+		editor := strings.Repeat("長", 300)
+		before := *content
+		suite.Require().NoError(content.Update("タイトル2", "本文", "article", "作成者"))
+		revision := entities.NewContentRevision(content, content.ChangedFieldsFrom(&before), editor)
+
+		// When
+		err := suite.repo.UpdateWithRevision(ctx, content, revision)
+
+		// Then
+		suite.Require().NoError(err)
+		stored, err := suite.repo.GetRevision(ctx, content.ID, 2)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), editor, stored.Editor)
+	})
+
+	suite.Run("存在しないリビジョンはエラー", func() {
+		ctx := context.Background()
+
+		_, err := suite.repo.GetRevision(ctx, 99999, 1)
+
+		assert.Error(suite.T(), err)
+	})
+}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+// headlineOptions は ts_headline の抜粋の設定
+const headlineOptions = `StartSel="<mark>", StopSel="</mark>", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+
+// escapedBody は ts_headline の結果をそのままHTMLとして扱えるよう本文をエスケープする式
+const escapedBody = "replace(replace(replace(body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;')"
+
+// 部分一致検索の抜粋で、最初に一致した位置の前後に含める文字数
+const (
+	headlineBefore = 30
+	headlineAfter  = 90
+)
+
+// searchHit は検索に一致したコンテンツのIDと関連度、抜粋
+type searchHit struct {
+	ID       uint
+	Rank     float64
+	Headline string
+}
+
+func (r *contentRepository) Search(ctx context.Context, q content.SearchQuery, filters content.ContentFilters) ([]*content.SearchResult, int64, error) {
+	var total int64
+	var hits []searchHit
+
+	// 並び順は関連度で決まるため、並び替えとカーソルは指定できない
+	if len(filters.Sort) > 0 || filters.Cursor != nil {
+		return nil, 0, content.ErrUnsupportedSearchFilter
+	}
+
+	query := whereFilters(r.db.WithContext(ctx).Model(&entities.Content{}), filters)
+
+	// 日本語は空白で分かち書きされず全文検索の語として扱えないため、部分一致で検索する
+	partial := q.HasCJK()
+	if partial {
+		query = wherePartialMatch(query, q)
+	} else {
+		query = query.Where("search_vector @@ to_tsquery('simple', ?)", toTSQuery(q))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if partial {
+		rank, args := partialMatchRank(q)
+		query = query.Select("id, "+rank+" AS rank, '' AS headline", args...)
+	} else {
+		tsquery := toTSQuery(q)
+		query = query.Select(
+			"id, ts_rank(search_vector, to_tsquery('simple', ?)) AS rank, "+
+				"ts_headline('simple', "+escapedBody+", to_tsquery('simple', ?), '"+headlineOptions+"') AS headline",
+			tsquery, tsquery,
+		)
+	}
+
+	err := query.Order("rank DESC, created_at DESC, id DESC").Limit(filters.Limit).Offset(filters.Offset).Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := r.loadSearchResults(ctx, hits)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if partial {
+		for _, result := range results {
+			result.Headline = highlight(result.Body, q)
+		}
+	}
+
+	return results, total, nil
+}
+
+// loadSearchResults は一致したコンテンツをタグとともに取得し、関連度順に並べる
+func (r *contentRepository) loadSearchResults(ctx context.Context, hits []searchHit) ([]*content.SearchResult, error) {
+	results := make([]*content.SearchResult, 0, len(hits))
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	ids := make([]uint, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+
+	var contents []*entities.Content
+	if err := r.db.WithContext(ctx).Scopes(preloadAssociations).Where("id IN ?", ids).Find(&contents).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*entities.Content, len(contents))
+	for _, c := range contents {
+		byID[c.ID] = c
+	}
+
+	for _, hit := range hits {
+		// 検索後に削除されたコンテンツは結果から除く
+		c, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &content.SearchResult{Content: c, Rank: hit.Rank, Headline: hit.Headline})
+	}
+	return results, nil
+}
+
+// toTSQuery は検索クエリを to_tsquery に渡す文字列に変換する
+// 語はすべて引用符で囲むため、利用者の入力がtsqueryの演算子として解釈されることはない
+func toTSQuery(q content.SearchQuery) string {
+	parts := make([]string, 0, len(q.Terms))
+	for _, term := range q.Terms {
+		lexemes := make([]string, 0, len(term.Words))
+		for _, word := range term.Words {
+			lexemes = append(lexemes, quoteLexeme(word))
+		}
+		if term.Prefix {
+			lexemes[len(lexemes)-1] += ":*"
+		}
+
+		expr := strings.Join(lexemes, " <-> ")
+		if len(lexemes) > 1 {
+			expr = "(" + expr + ")"
+		}
+		if term.Negated {
+			expr = "!" + expr
+		}
+		parts = append(parts, expr)
+	}
+	return strings.Join(parts, " & ")
+}
+
+func quoteLexeme(word string) string {
+	word = strings.ReplaceAll(word, `\`, `\\`)
+	word = strings.ReplaceAll(word, "'", "''")
+	return "'" + word + "'"
+}
+
+// wherePartialMatch はタイトルまたは本文への部分一致で絞り込む
+func wherePartialMatch(query *gorm.DB, q content.SearchQuery) *gorm.DB {
+	for _, term := range q.Terms {
+		pattern := likePattern(term)
+		if term.Negated {
+			query = query.Where("NOT (title ILIKE ? OR body ILIKE ?)", pattern, pattern)
+		} else {
+			query = query.Where("(title ILIKE ? OR body ILIKE ?)", pattern, pattern)
+		}
+	}
+	return query
+}
+
+// partialMatchRank は部分一致検索の関連度の式を返す
+// ts_rank の既定の重みに合わせ、タイトルへの一致を本文より高く評価する
+func partialMatchRank(q content.SearchQuery) (string, []interface{}) {
+	exprs := []string{}
+	args := []interface{}{}
+	for _, term := range q.Terms {
+		if term.Negated {
+			continue
+		}
+		pattern := likePattern(term)
+		exprs = append(exprs, "(CASE WHEN title ILIKE ? THEN 1.0 ELSE 0 END + CASE WHEN body ILIKE ? THEN 0.4 ELSE 0 END)")
+		args = append(args, pattern, pattern)
+	}
+	return "(" + strings.Join(exprs, " + ") + ")", args
+}
+
+func likePattern(term content.SearchTerm) string {
+	return "%" + escapeLike(strings.Join(term.Words, " ")) + "%"
+}
+
+// highlight は本文のうち最初に一致した箇所の前後を切り出し、HTMLエスケープしたうえで一致箇所を <mark> で囲む
+func highlight(body string, q content.SearchQuery) string {
+	phrases := [][]rune{}
+	for _, term := range q.Terms {
+		if !term.Negated {
+			phrases = append(phrases, []rune(strings.ToLower(strings.Join(term.Words, " "))))
+		}
+	}
+
+	text := []rune(body)
+	lower := []rune(strings.ToLower(body))
+	if len(lower) != len(text) {
+		// 大文字小文字の変換で文字数が変わる場合は大文字小文字を区別して比較する
+		lower = text
+	}
+
+	matchAt := func(i int) int {
+		for _, phrase := range phrases {
+			if i+len(phrase) <= len(lower) && string(lower[i:i+len(phrase)]) == string(phrase) {
+				return len(phrase)
+			}
+		}
+		return 0
+	}
+
+	first := 0
+	for i := range lower {
+		if matchAt(i) > 0 {
+			first = i
+			break
+		}
+	}
+
+	start := max(first-headlineBefore, 0)
+	end := min(first+headlineAfter, len(text))
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("… ")
+	}
+	for i := start; i < end; {
+		if n := matchAt(i); n > 0 {
+			b.WriteString("<mark>" + html.EscapeString(string(text[i:i+n])) + "</mark>")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+		i++
+	}
+	if end < len(text) {
+		b.WriteString(" …")
+	}
+	return b.String()
+}
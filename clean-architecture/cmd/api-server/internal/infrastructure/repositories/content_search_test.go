@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentSearchTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      content.ContentRepository
+}
+
+func (suite *ContentSearchTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	// 全文検索用の生成列とインデックスを作成するため、本番と同じマイグレーションを実行
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	suite.repo = NewContentRepository(suite.db)
+}
+
+func (suite *ContentSearchTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentSearchTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentSearchTestSuite) create(title, body string) *entities.Content {
+	c, _ := entities.NewContent(title, body, "article", "作成者", entities.RegisterAuthor())
+	suite.Require().NoError(suite.repo.Create(context.Background(), c))
+	return c
+}
+
+// search はクエリを解析して検索し、一致したコンテンツのタイトルを関連度順に返す
+func (suite *ContentSearchTestSuite) search(q string) ([]string, []*content.SearchResult) {
+	query, err := content.ParseSearchQuery(q)
+	suite.Require().NoError(err)
+
+	results, total, err := suite.repo.Search(context.Background(), query, content.NewContentFilters())
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(len(results)), total)
+
+	titles := make([]string, 0, len(results))
+	for _, r := range results {
+		titles = append(titles, r.Title)
+	}
+	return titles, results
+}
+
+func (suite *ContentSearchTestSuite) TestSearch() {
+	suite.Run("タイトルに一致したコンテンツを本文より上位に返す", func() {
+		suite.create("Go testing guide", "How to write table driven tests")
+		suite.create("Release notes", "The Go team shipped a new testing package")
+
+		titles, results := suite.search("testing")
+
+		assert.Equal(suite.T(), []string{"Go testing guide", "Release notes"}, titles)
+		assert.Greater(suite.T(), results[0].Rank, results[1].Rank)
+	})
+
+	suite.Run("フレーズ・前方一致・除外を指定できる", func() {
+		suite.create("Database tuning", "Use a partial index for hot rows")
+		suite.create("Index basics", "A partial list of index types")
+		suite.create("Datastore notes", "Notes about index maintenance")
+
+		titles, _ := suite.search(`"partial index"`)
+		assert.Equal(suite.T(), []string{"Database tuning"}, titles)
+
+		titles, _ = suite.search("data*")
+		assert.ElementsMatch(suite.T(), []string{"Database tuning", "Datastore notes"}, titles)
+
+		titles, _ = suite.search("index -partial")
+		assert.Equal(suite.T(), []string{"Datastore notes"}, titles)
+	})
+
+	suite.Run("一致箇所をエスケープして強調した抜粋を返す", func() {
+		suite.create("Markup", "Use <script> tags carefully when rendering search results")
+
+		_, results := suite.search("rendering")
+
+		suite.Require().Len(results, 1)
+		assert.Contains(suite.T(), results[0].Headline, "<mark>rendering</mark>")
+		assert.Contains(suite.T(), results[0].Headline, "&lt;script&gt;")
+	})
+
+	suite.Run("日本語は部分一致で検索できる", func() {
+		suite.create("システムメンテナンスのお知らせ", "明日の深夜にメンテナンスを実施します。")
+		suite.create("新機能の紹介", "メンテナンス画面が新しくなりました。")
+		suite.create("利用規約", "本サービスの利用規約です。")
+
+		titles, results := suite.search("メンテナンス")
+
+		assert.Equal(suite.T(), []string{"システムメンテナンスのお知らせ", "新機能の紹介"}, titles)
+		assert.Contains(suite.T(), results[0].Headline, "<mark>メンテナンス</mark>")
+
+		titles, _ = suite.search("メンテナンス -深夜")
+		assert.Equal(suite.T(), []string{"新機能の紹介"}, titles)
+	})
+
+	suite.Run("特殊文字を含むクエリでもエラーにならない", func() {
+		suite.create("Quotes", "It's a test")
+
+		_, _ = suite.search(`it's & | ! ( ) :* \`)
+		_, _ = suite.search("100%_完了")
+	})
+
+	suite.Run("削除されたコンテンツは検索されない", func() {
+		deleted := suite.create("Deleted testing", "testing")
+		suite.Require().NoError(suite.repo.Delete(context.Background(), deleted))
+
+		titles, _ := suite.search("testing")
+
+		assert.Empty(suite.T(), titles)
+	})
+}
+
+func (suite *ContentSearchTestSuite) TestSearchFilters() {
+	suite.Run("一覧と同じ絞り込み条件を適用する", func() {
+		ctx := context.Background()
+		// Given
+		tagged, _ := entities.NewContent("Go testing guide", "testing", "article", "作成者", entities.RegisterAuthor(), entities.WithTags([]string{"Go"}))
+		suite.Require().NoError(suite.repo.Create(ctx, tagged))
+		suite.create("Release notes", "testing")
+
+		query, err := content.ParseSearchQuery("testing")
+		suite.Require().NoError(err)
+		filters := content.NewContentFilters()
+		filters.Tags = []string{"Go"}
+
+		// When
+		results, total, err := suite.repo.Search(ctx, query, filters)
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), int64(1), total)
+		suite.Require().Len(results, 1)
+		assert.Equal(suite.T(), tagged.ID, results[0].ID)
+	})
+
+	suite.Run("日時の範囲で絞り込める", func() {
+		ctx := context.Background()
+		// Given
+		suite.create("Go testing guide", "testing")
+		query, err := content.ParseSearchQuery("testing")
+		suite.Require().NoError(err)
+		future := time.Now().Add(time.Hour)
+		filters := content.NewContentFilters()
+		filters.CreatedAfter = &future
+
+		// When
+		results, total, err := suite.repo.Search(ctx, query, filters)
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Zero(suite.T(), total)
+		assert.Empty(suite.T(), results)
+	})
+
+	suite.Run("並び替えを指定するとエラー", func() {
+		query, err := content.ParseSearchQuery("testing")
+		suite.Require().NoError(err)
+		filters := content.NewContentFilters()
+		filters.Sort = []content.SortField{{Column: "title"}}
+
+		_, _, err = suite.repo.Search(context.Background(), query, filters)
+
+		assert.ErrorIs(suite.T(), err, content.ErrUnsupportedSearchFilter)
+	})
+}
+
+func TestContentSearchTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentSearchTestSuite))
+}
@@ -51,7 +51,7 @@ func (suite *ContentRepositoryTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	suite.repo = NewContentRepository(suite.db)
@@ -66,13 +66,16 @@ func (suite *ContentRepositoryTestSuite) TearDownSuite() {
 
 func (suite *ContentRepositoryTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
 }
 
 func (suite *ContentRepositoryTestSuite) TestCreate() {
 	suite.Run("正常にコンテンツを作成できる", func() {
 		ctx := context.Background()
-		content, _ := entities.NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+		content, _ := entities.NewContent("テストタイトル", "テスト本文", "article", "テスト作成者", entities.RegisterAuthor())
 
 		err := suite.repo.Create(ctx, content)
 
@@ -88,7 +91,7 @@ func (suite *ContentRepositoryTestSuite) TestGetByID() {
 		ctx := context.Background()
 
 		// テストデータ作成
-		original, _ := entities.NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+		original, _ := entities.NewContent("テストタイトル", "テスト本文", "article", "テスト作成者", entities.RegisterAuthor())
 		err := suite.repo.Create(ctx, original)
 		suite.Require().NoError(err)
 
@@ -119,15 +122,15 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 		// テストデータ作成
 		contents := []*entities.Content{
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A")
+				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B")
+				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A")
+				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 		}
@@ -152,15 +155,15 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 		// テストデータ作成（上記と同じ）
 		contents := []*entities.Content{
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A")
+				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B")
+				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A")
+				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 		}
@@ -191,15 +194,15 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 		// テストデータ作成（上記と同じ）
 		contents := []*entities.Content{
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A")
+				c, _ := entities.NewContent("記事1", "本文1", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B")
+				c, _ := entities.NewContent("ブログ1", "本文2", "blog", "作成者B", entities.RegisterAuthor())
 				return c
 			}(),
 			func() *entities.Content {
-				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A")
+				c, _ := entities.NewContent("記事2", "本文3", "article", "作成者A", entities.RegisterAuthor())
 				return c
 			}(),
 		}
@@ -224,6 +227,114 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 		}
 	})
 
+	suite.Run("ステータスでフィルタリングできる", func() {
+		ctx := context.Background()
+
+		draft, _ := entities.NewContent("下書き", "本文1", "article", "作成者A", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, draft))
+
+		published, _ := entities.NewContent("公開記事", "本文2", "article", "作成者A", entities.RegisterAuthor())
+		suite.Require().NoError(published.SubmitForReview())
+		suite.Require().NoError(published.Publish())
+		suite.Require().NoError(suite.repo.Create(ctx, published))
+
+		filters := content.NewContentFilters()
+		publishedStatus := entities.StatusPublished
+		filters.Status = &publishedStatus
+		result, total, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+		assert.Equal(suite.T(), published.ID, result[0].ID)
+
+		draftStatus := entities.StatusDraft
+		filters.Status = &draftStatus
+		result, total, err = suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+		assert.Equal(suite.T(), draft.ID, result[0].ID)
+	})
+
+	suite.Run("タグでフィルタリングできる", func() {
+		ctx := context.Background()
+
+		both, _ := entities.NewContent("記事1", "本文1", "article", "作成者A", entities.RegisterAuthor(), entities.WithTags([]string{"Go", "API"}))
+		suite.Require().NoError(suite.repo.Create(ctx, both))
+
+		goOnly, _ := entities.NewContent("記事2", "本文2", "article", "作成者A", entities.RegisterAuthor(), entities.WithTags([]string{"Go"}))
+		suite.Require().NoError(suite.repo.Create(ctx, goOnly))
+
+		untagged, _ := entities.NewContent("記事3", "本文3", "article", "作成者A", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, untagged))
+
+		// いずれかのタグを持つ
+		filters := content.NewContentFilters()
+		filters.Tags = []string{"API", "Go"}
+		result, total, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), total)
+		assert.Len(suite.T(), result, 2)
+
+		// すべてのタグを持つ
+		filters = content.NewContentFilters()
+		filters.TagsAll = []string{"API", "Go"}
+		result, total, err = suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+		assert.Equal(suite.T(), both.ID, result[0].ID)
+		assert.Equal(suite.T(), []string{"API", "Go"}, tagNames(result[0]))
+	})
+
+	suite.Run("カスタムフィールドの値でフィルタリングできる", func() {
+		ctx := context.Background()
+
+		// テストデータ作成
+		create := func(title string, fields map[string]interface{}) *entities.Content {
+			c, _ := entities.NewContent(title, "本文", "news", "作成者", entities.WithFields(fields), entities.RegisterAuthor())
+			suite.Require().NoError(suite.repo.Create(ctx, c))
+			return c
+		}
+		january := create("1月のイベント", map[string]interface{}{"event_date": "2026-01-15", "capacity": 100, "zip": "01234"})
+		march := create("3月のイベント", map[string]interface{}{"event_date": "2026-03-01", "capacity": 30})
+		create("日付なし", map[string]interface{}{"capacity": "未定"})
+
+		list := func(fieldFilters ...content.FieldFilter) []uint {
+			filters := content.NewContentFilters()
+			filters.Fields = fieldFilters
+			result, total, err := suite.repo.List(ctx, filters)
+			suite.Require().NoError(err)
+			suite.Require().Equal(int64(len(result)), total)
+
+			ids := make([]uint, 0, len(result))
+			for _, c := range result {
+				ids = append(ids, c.ID)
+			}
+			return ids
+		}
+
+		// 文字列の大小比較
+		assert.Equal(suite.T(), []uint{march.ID}, list(content.FieldFilter{Name: "event_date", Operator: content.FieldOpGte, Value: "2026-02-01"}))
+
+		// 数値の大小比較では型の異なる値は対象外
+		assert.Equal(suite.T(), []uint{january.ID}, list(content.FieldFilter{Name: "capacity", Operator: content.FieldOpGt, Value: "50"}))
+
+		// 等価比較は数値として解釈できる文字列にも一致する
+		assert.Equal(suite.T(), []uint{january.ID}, list(content.FieldFilter{Name: "zip", Operator: content.FieldOpEq, Value: "01234"}))
+		assert.Equal(suite.T(), []uint{march.ID}, list(content.FieldFilter{Name: "capacity", Operator: content.FieldOpEq, Value: "30"}))
+
+		// 否定はフィールドを持たないコンテンツを含まない
+		assert.Equal(suite.T(), []uint{march.ID}, list(content.FieldFilter{Name: "event_date", Operator: content.FieldOpNe, Value: "2026-01-15"}))
+
+		// 複数条件はすべてを満たす
+		assert.Equal(suite.T(), []uint{january.ID}, list(
+			content.FieldFilter{Name: "event_date", Operator: content.FieldOpLt, Value: "2026-02-01"},
+			content.FieldFilter{Name: "capacity", Operator: content.FieldOpGte, Value: "100"},
+		))
+	})
+
 	suite.Run("ページネーションが機能する", func() {
 		ctx := context.Background()
 
@@ -234,6 +345,7 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 				fmt.Sprintf("本文%d", i),
 				"article",
 				"作成者",
+				entities.RegisterAuthor(),
 			)
 			err := suite.repo.Create(ctx, content)
 			suite.Require().NoError(err)
@@ -261,6 +373,119 @@ func (suite *ContentRepositoryTestSuite) TestList() {
 		// 異なるコンテンツが取得されることを確認
 		assert.NotEqual(suite.T(), result[0].ID, result2[0].ID)
 	})
+
+	suite.Run("複数の項目で並び替えできる", func() {
+		ctx := context.Background()
+
+		for _, tc := range []struct{ title, author string }{
+			{"B", "作成者1"}, {"A", "作成者2"}, {"C", "作成者1"},
+		} {
+			content, _ := entities.NewContent(tc.title, "本文", "article", tc.author, entities.RegisterAuthor())
+			suite.Require().NoError(suite.repo.Create(ctx, content))
+		}
+
+		filters := content.NewContentFilters()
+		filters.Sort = []content.SortField{{Column: "author", Desc: true}, {Column: "title"}}
+		result, _, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(result, 3)
+		assert.Equal(suite.T(), "A", result[0].Title)
+		assert.Equal(suite.T(), "B", result[1].Title)
+		assert.Equal(suite.T(), "C", result[2].Title)
+	})
+
+	suite.Run("作成日時・更新日時の範囲でフィルタリングできる", func() {
+		ctx := context.Background()
+
+		base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 3; i++ {
+			content, _ := entities.NewContent(fmt.Sprintf("記事%d", i+1), "本文", "article", "作成者", entities.RegisterAuthor())
+			content.CreatedAt = base.AddDate(0, i, 0)
+			content.UpdatedAt = base.AddDate(0, i, 0)
+			suite.Require().NoError(suite.repo.Create(ctx, content))
+		}
+
+		createdAfter := base.AddDate(0, 1, 0)
+		createdBefore := base.AddDate(0, 2, 0)
+		filters := content.NewContentFilters()
+		filters.CreatedAfter = &createdAfter
+		filters.CreatedBefore = &createdBefore
+		result, total, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+		assert.Equal(suite.T(), "記事2", result[0].Title)
+
+		filters = content.NewContentFilters()
+		filters.UpdatedAfter = &createdAfter
+		_, total, err = suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), total)
+	})
+
+	suite.Run("作成者の前方一致でフィルタリングできる", func() {
+		ctx := context.Background()
+
+		for _, author := range []string{"Yamada Taro", "yamada hanako", "Suzuki", "100%_author"} {
+			content, _ := entities.NewContent("記事", "本文", "article", author, entities.RegisterAuthor())
+			suite.Require().NoError(suite.repo.Create(ctx, content))
+		}
+
+		prefix := "YAMADA"
+		filters := content.NewContentFilters()
+		filters.AuthorPrefix = &prefix
+		_, total, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), total)
+
+		// ワイルドカードは文字として扱う
+		prefix = "1%_"
+		_, total, err = suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(0), total)
+	})
+
+	suite.Run("カーソルの前後のコンテンツを取得できる", func() {
+		ctx := context.Background()
+
+		// 作成日時が同じコンテンツもIDで順序が決まることを確認するため同時刻で作成する
+		createdAt := time.Now().Add(-time.Hour)
+		for i := 1; i <= 4; i++ {
+			content, _ := entities.NewContent(fmt.Sprintf("記事%d", i), "本文", "article", "作成者", entities.RegisterAuthor())
+			content.CreatedAt = createdAt
+			suite.Require().NoError(suite.repo.Create(ctx, content))
+		}
+
+		filters := content.NewContentFilters()
+		all, _, err := suite.repo.List(ctx, filters)
+		suite.Require().NoError(err)
+		suite.Require().Len(all, 4)
+
+		// 2件目より後ろ（古い側）
+		filters.SkipTotal = true
+		filters.Cursor = &content.ContentCursor{CreatedAt: all[1].CreatedAt, ID: all[1].ID}
+		after, total, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(0), total)
+		suite.Require().Len(after, 2)
+		assert.Equal(suite.T(), all[2].ID, after[0].ID)
+		assert.Equal(suite.T(), all[3].ID, after[1].ID)
+
+		// 4件目より前（新しい側）は表示順のまま返る
+		filters.Limit = 2
+		filters.Cursor = &content.ContentCursor{CreatedAt: all[3].CreatedAt, ID: all[3].ID, Backward: true}
+		before, _, err := suite.repo.List(ctx, filters)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(before, 2)
+		assert.Equal(suite.T(), all[1].ID, before[0].ID)
+		assert.Equal(suite.T(), all[2].ID, before[1].ID)
+	})
 }
 
 func (suite *ContentRepositoryTestSuite) TestUpdate() {
@@ -268,12 +493,12 @@ func (suite *ContentRepositoryTestSuite) TestUpdate() {
 		ctx := context.Background()
 
 		// テストデータ作成
-		content, _ := entities.NewContent("元のタイトル", "元の本文", "article", "元の作成者")
+		content, _ := entities.NewContent("元のタイトル", "元の本文", "article", "元の作成者", entities.RegisterAuthor())
 		err := suite.repo.Create(ctx, content)
 		suite.Require().NoError(err)
 
 		// 更新
-		err = content.Update("新しいタイトル", "新しい本文", "blog", "新しい作成者")
+		err = content.Update("新しいタイトル", "新しい本文", "blog", "新しい作成者", entities.RegisterAuthor())
 		suite.Require().NoError(err)
 
 		err = suite.repo.Update(ctx, content)
@@ -289,6 +514,70 @@ func (suite *ContentRepositoryTestSuite) TestUpdate() {
 		assert.Equal(suite.T(), "新しい作成者", updated.Author)
 		assert.True(suite.T(), updated.UpdatedAt.After(updated.CreatedAt))
 	})
+
+	suite.Run("登録を許可されていない未登録の作成者はエラー", func() {
+		ctx := context.Background()
+
+		// Given: 名前を変更された作成者の旧名
+		content, _ := entities.NewContent("タイトル", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, content))
+		suite.Require().NoError(content.Update("タイトル", "本文", "article", "旧作成者名"))
+
+		// When
+		err := suite.repo.Update(ctx, content)
+
+		// Then: 作成者は作られない
+		assert.ErrorIs(suite.T(), err, entities.ErrUnknownAuthor)
+
+		var count int64
+		suite.db.Model(&entities.Author{}).Where("name = ?", "旧作成者名").Count(&count)
+		assert.Equal(suite.T(), int64(0), count)
+	})
+}
+
+func (suite *ContentRepositoryTestSuite) TestUpdateTags() {
+	suite.Run("更新時にタグが置き換えられる", func() {
+		ctx := context.Background()
+
+		c, _ := entities.NewContent("タイトル", "本文", "article", "作成者", entities.RegisterAuthor(), entities.WithTags([]string{"Go", "API"}))
+		suite.Require().NoError(suite.repo.Create(ctx, c))
+
+		suite.Require().NoError(c.Update(c.Title, c.Body, c.ContentType, c.Author, entities.WithTags([]string{"Go", "設計"})))
+		err := suite.repo.Update(ctx, c)
+		assert.NoError(suite.T(), err)
+
+		updated, err := suite.repo.GetByID(ctx, c.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), []string{"Go", "設計"}, tagNames(updated))
+	})
+}
+
+func (suite *ContentRepositoryTestSuite) TestUpdateVersionConflict() {
+	suite.Run("古いバージョンでの更新は競合エラーになる", func() {
+		ctx := context.Background()
+
+		original, _ := entities.NewContent("元のタイトル", "元の本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, original))
+
+		// 同じバージョンを2つのリクエストが読み込んだ状態を再現
+		first, err := suite.repo.GetByID(ctx, original.ID)
+		suite.Require().NoError(err)
+		second, err := suite.repo.GetByID(ctx, original.ID)
+		suite.Require().NoError(err)
+
+		suite.Require().NoError(first.Update("先の更新", "本文", "article", "作成者"))
+		assert.NoError(suite.T(), suite.repo.Update(ctx, first))
+		assert.Equal(suite.T(), uint(2), first.Version)
+
+		suite.Require().NoError(second.Update("後の更新", "本文", "article", "作成者"))
+		err = suite.repo.Update(ctx, second)
+		assert.ErrorIs(suite.T(), err, content.ErrVersionConflict)
+		assert.Equal(suite.T(), uint(1), second.Version)
+
+		stored, err := suite.repo.GetByID(ctx, original.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "先の更新", stored.Title)
+	})
 }
 
 func (suite *ContentRepositoryTestSuite) TestDelete() {
@@ -296,18 +585,79 @@ func (suite *ContentRepositoryTestSuite) TestDelete() {
 		ctx := context.Background()
 
 		// テストデータ作成
-		content, _ := entities.NewContent("削除対象", "削除対象本文", "article", "作成者")
+		content, _ := entities.NewContent("削除対象", "削除対象本文", "article", "作成者", entities.RegisterAuthor())
 		err := suite.repo.Create(ctx, content)
 		suite.Require().NoError(err)
 
 		// 削除
-		err = suite.repo.Delete(ctx, content.ID)
+		err = suite.repo.Delete(ctx, content)
 		assert.NoError(suite.T(), err)
 
 		// 削除確認（ソフトデリートなのでエラーになる）
 		_, err = suite.repo.GetByID(ctx, content.ID)
 		assert.Error(suite.T(), err)
 	})
+
+	suite.Run("読み込んだ後に更新されたコンテンツは削除せず競合エラーになる", func() {
+		ctx := context.Background()
+
+		original, _ := entities.NewContent("元のタイトル", "元の本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, original))
+
+		// 削除するリクエストが読み込んだ後に、別のリクエストが更新した状態を再現
+		loaded, err := suite.repo.GetByID(ctx, original.ID)
+		suite.Require().NoError(err)
+		suite.Require().NoError(original.Update("先の更新", "本文", "article", "作成者"))
+		suite.Require().NoError(suite.repo.Update(ctx, original))
+
+		err = suite.repo.Delete(ctx, loaded)
+		assert.ErrorIs(suite.T(), err, content.ErrVersionConflict)
+
+		stored, err := suite.repo.GetByID(ctx, original.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "先の更新", stored.Title)
+	})
+}
+
+func (suite *ContentRepositoryTestSuite) TestTrash() {
+	suite.Run("削除済みのコンテンツを一覧・復元できる", func() {
+		ctx := context.Background()
+		content, _ := entities.NewContent("記事", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.repo.Create(ctx, content))
+		suite.Require().NoError(suite.repo.Delete(ctx, content))
+
+		deleted, total, err := suite.repo.ListDeleted(ctx, 20, 0)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), total)
+		assert.True(suite.T(), deleted[0].IsDeleted())
+
+		err = suite.repo.Restore(ctx, content.ID)
+		assert.NoError(suite.T(), err)
+
+		_, err = suite.repo.GetByID(ctx, content.ID)
+		assert.NoError(suite.T(), err)
+
+		// 復元済みのコンテンツはゴミ箱にない
+		err = suite.repo.Restore(ctx, content.ID)
+		assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	})
+
+	suite.Run("指定日時より前に削除されたコンテンツを完全削除できる", func() {
+		ctx := context.Background()
+		for i := 1; i <= 3; i++ {
+			content, _ := entities.NewContent(fmt.Sprintf("記事%d", i), "本文", "article", "作成者", entities.RegisterAuthor())
+			suite.Require().NoError(suite.repo.Create(ctx, content))
+			suite.Require().NoError(suite.repo.Delete(ctx, content))
+		}
+
+		purged, err := suite.repo.PurgeDeletedBefore(ctx, time.Now().Add(time.Minute))
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(3), purged)
+
+		_, total, err := suite.repo.ListDeleted(ctx, 20, 0)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(0), total)
+	})
 }
 
 func TestContentRepositoryTestSuite(t *testing.T) {
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize は期限切れのコンテンツを完全削除する際に1トランザクションで削除する件数
+const purgeBatchSize = 500
+
+func (r *contentRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entities.Content, int64, error) {
+	var contents []*entities.Content
+	var total int64
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&entities.Content{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Scopes(preloadAssociations).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&contents).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return contents, total, nil
+}
+
+func (r *contentRepository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.Content{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *contentRepository) Purge(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&entities.Content{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return purgeContents(tx, ids)
+	})
+	if err != nil {
+		return err
+	}
+
+	// 完全削除は復元できないため、実行したユーザーとともに記録する
+	logging.FromContext(ctx).InfoContext(ctx, "コンテンツを完全削除しました", "content_id", id)
+	return nil
+}
+
+func (r *contentRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	for {
+		// 長時間のロックを避けるため、一定件数ずつ別のトランザクションで削除する
+		var ids []uint
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&entities.Content{}).
+				Where("deleted_at < ?", before).
+				Order("id").
+				Limit(purgeBatchSize).
+				Pluck("id", &ids).Error
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+
+			return purgeContents(tx, ids)
+		})
+		if err != nil {
+			return purged, err
+		}
+		if len(ids) > 0 {
+			logging.FromContext(ctx).InfoContext(ctx, "保持期間を過ぎたコンテンツを完全削除しました", "content_ids", ids)
+		}
+
+		purged += int64(len(ids))
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purgeContents はコンテンツをタグの関連付けとリビジョンとともに物理削除する
+func purgeContents(tx *gorm.DB, ids []uint) error {
+	if err := tx.Exec("DELETE FROM content_tags WHERE content_id IN ?", ids).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("content_id IN ?", ids).Delete(&entities.ContentRevision{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Delete(&entities.Content{}, ids).Error
+}
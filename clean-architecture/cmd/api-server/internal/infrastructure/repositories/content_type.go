@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+
+	"go-api-server-sample/cmd/api-server/internal/api/contenttype"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type contentTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewContentTypeRepository(db *gorm.DB) contenttype.ContentTypeRepository {
+	return &contentTypeRepository{
+		db: db,
+	}
+}
+
+func (r *contentTypeRepository) List(ctx context.Context) ([]*entities.ContentType, error) {
+	var types []*entities.ContentType
+	err := r.db.WithContext(ctx).Order("name").Find(&types).Error
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (r *contentTypeRepository) GetByName(ctx context.Context, name string) (*entities.ContentType, error) {
+	var t entities.ContentType
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *contentTypeRepository) Create(ctx context.Context, t *entities.ContentType) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(t)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return contenttype.ErrContentTypeAlreadyExists
+	}
+	return nil
+}
+
+func (r *contentTypeRepository) Update(ctx context.Context, t *entities.ContentType) error {
+	return r.db.WithContext(ctx).Model(t).
+		Select("description", "max_body_length", "required_fields", "allowed_authors", "fields_schema").
+		Updates(t).Error
+}
+
+func (r *contentTypeRepository) Delete(ctx context.Context, t *entities.ContentType) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// ゴミ箱から復元される可能性があるため、削除済みのコンテンツも参照として扱う
+		var count int64
+		err := tx.Unscoped().Model(&entities.Content{}).Where("content_type = ?", t.Name).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return contenttype.ErrContentTypeInUse
+		}
+
+		return tx.Delete(t).Error
+	})
+}
+
+func (r *contentTypeRepository) AuthorsExist(ctx context.Context, ids []uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.Author{}).Where("id IN ?", ids).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count == int64(len(ids)), nil
+}
@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/contenttype"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentTypeRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      contenttype.ContentTypeRepository
+}
+
+func (suite *ContentTypeRepositoryTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.ContentType{}, &entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	suite.repo = NewContentTypeRepository(suite.db)
+}
+
+func (suite *ContentTypeRepositoryTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentTypeRepositoryTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM content_types")
+}
+
+func (suite *ContentTypeRepositoryTestSuite) TestCreate() {
+	suite.Run("コンテンツタイプを作成して名前で取得できる", func() {
+		// Given
+		ctx := context.Background()
+		podcast, _ := entities.NewContentType("podcast", "音声配信", 500, []string{"tags"}, []uint{1}, nil)
+
+		// When
+		err := suite.repo.Create(ctx, podcast)
+
+		// Then
+		assert.NoError(suite.T(), err)
+		assert.NotZero(suite.T(), podcast.ID)
+
+		found, err := suite.repo.GetByName(ctx, "podcast")
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), 500, found.MaxBodyLength)
+		assert.Equal(suite.T(), []string{"tags"}, found.RequiredFields)
+		assert.Equal(suite.T(), []uint{1}, found.AllowedAuthors)
+	})
+
+	suite.Run("同名のコンテンツタイプは作成できない", func() {
+		// Given
+		ctx := context.Background()
+		first, _ := entities.NewContentType("podcast", "", 0, nil, nil, nil)
+		suite.Require().NoError(suite.repo.Create(ctx, first))
+
+		// When
+		second, _ := entities.NewContentType("podcast", "", 0, nil, nil, nil)
+		err := suite.repo.Create(ctx, second)
+
+		// Then
+		assert.ErrorIs(suite.T(), err, contenttype.ErrContentTypeAlreadyExists)
+	})
+}
+
+func (suite *ContentTypeRepositoryTestSuite) TestUpdate() {
+	suite.Run("制約を更新できる", func() {
+		// Given
+		ctx := context.Background()
+		podcast, _ := entities.NewContentType("podcast", "", 0, nil, nil, nil)
+		suite.Require().NoError(suite.repo.Create(ctx, podcast))
+
+		// When
+		suite.Require().NoError(podcast.Update("音声配信", 100, nil, []uint{1}, nil))
+		err := suite.repo.Update(ctx, podcast)
+
+		// Then
+		assert.NoError(suite.T(), err)
+
+		found, err := suite.repo.GetByName(ctx, "podcast")
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "音声配信", found.Description)
+		assert.Equal(suite.T(), 100, found.MaxBodyLength)
+		assert.Equal(suite.T(), []uint{1}, found.AllowedAuthors)
+	})
+}
+
+func (suite *ContentTypeRepositoryTestSuite) TestDelete() {
+	suite.Run("使用されていないコンテンツタイプを削除できる", func() {
+		// Given
+		ctx := context.Background()
+		podcast, _ := entities.NewContentType("podcast", "", 0, nil, nil, nil)
+		suite.Require().NoError(suite.repo.Create(ctx, podcast))
+
+		// When
+		err := suite.repo.Delete(ctx, podcast)
+
+		// Then
+		assert.NoError(suite.T(), err)
+
+		types, err := suite.repo.List(ctx)
+		suite.Require().NoError(err)
+		assert.Empty(suite.T(), types)
+	})
+
+	suite.Run("削除済みのコンテンツが参照していても削除できない", func() {
+		// Given
+		ctx := context.Background()
+		article, _ := entities.NewContentType("article", "", 0, nil, nil, nil)
+		suite.Require().NoError(suite.repo.Create(ctx, article))
+
+		c, _ := entities.NewContent("タイトル", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(suite.db.Create(c).Error)
+		suite.Require().NoError(suite.db.Delete(c).Error)
+
+		// When
+		err := suite.repo.Delete(ctx, article)
+
+		// Then
+		assert.ErrorIs(suite.T(), err, contenttype.ErrContentTypeInUse)
+
+		_, err = suite.repo.GetByName(ctx, "article")
+		assert.NoError(suite.T(), err)
+	})
+}
+
+func (suite *ContentTypeRepositoryTestSuite) TestAuthorsExist() {
+	suite.Run("すべての作成者が登録されているかを返す", func() {
+		// Given
+		ctx := context.Background()
+		suite.db.Exec("DELETE FROM authors")
+		author := &entities.Author{Name: "配信者"}
+		suite.Require().NoError(suite.db.Create(author).Error)
+
+		// When
+		exists, err := suite.repo.AuthorsExist(ctx, []uint{author.ID})
+
+		// Then
+		suite.Require().NoError(err)
+		assert.True(suite.T(), exists)
+
+		exists, err = suite.repo.AuthorsExist(ctx, []uint{author.ID, author.ID + 1})
+		suite.Require().NoError(err)
+		assert.False(suite.T(), exists)
+	})
+}
+
+func TestContentTypeRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentTypeRepositoryTestSuite))
+}
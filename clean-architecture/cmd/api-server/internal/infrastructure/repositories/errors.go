@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation は一意制約違反を示すPostgreSQLのエラーコード
+const uniqueViolation = "23505"
+
+// isUniqueViolation はエラーが一意制約違反によるものかどうかを返す
+// 事前の重複確認と更新の間に他のリクエストが同じ値を登録した場合に発生する
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) middleware.IdempotencyStore {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, key *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error) {
+	db := r.db.WithContext(ctx)
+
+	// 有効期限切れのキーを削除し、同じキーを再利用できるようにする
+	if err := db.Where("expires_at <= ?", time.Now()).Delete(&entities.IdempotencyKey{}).Error; err != nil {
+		return nil, false, err
+	}
+
+	// 一意制約により、同時に送られた同じキーのリクエストのうち1つだけが保存に成功する
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client"}, {Name: "key"}},
+		DoNothing: true,
+	}).Create(key)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return key, true, nil
+	}
+
+	// 占有期限を過ぎても完了していない同じリクエストのキーを引き継ぐ
+	// 同時に引き継ごうとした場合は、先に更新したリクエストが占有期限を延ばすため1つだけが成功する
+	result = db.Model(&entities.IdempotencyKey{}).
+		Where("client = ? AND key = ? AND request_hash = ? AND status_code = 0 AND locked_until <= ?",
+			key.Client, key.Key, key.RequestHash, time.Now()).
+		Updates(map[string]interface{}{"locked_until": key.LockedUntil, "expires_at": key.ExpiresAt})
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	var existing entities.IdempotencyKey
+	err := db.Where("client = ? AND key = ?", key.Client, key.Key).First(&existing).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if result.RowsAffected > 0 {
+		key.ID = existing.ID
+		key.CreatedAt = existing.CreatedAt
+		return key, true, nil
+	}
+	return &existing, false, nil
+}
+
+// Complete と Release は占有期限が予約時のままの場合のみ変更し、引き継いだリクエストの予約を上書き・削除しない
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, key *entities.IdempotencyKey) error {
+	result := r.db.WithContext(ctx).Model(key).
+		Where("locked_until = ?", key.LockedUntil).
+		Select("status_code", "response_headers", "response_body").
+		Updates(key)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return middleware.ErrIdempotencyKeyLost
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) Release(ctx context.Context, key *entities.IdempotencyKey) error {
+	return r.db.WithContext(ctx).
+		Where("locked_until = ?", key.LockedUntil).
+		Delete(&entities.IdempotencyKey{}, key.ID).Error
+}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type IdempotencyKeyRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      middleware.IdempotencyStore
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.IdempotencyKey{})
+	suite.Require().NoError(err)
+
+	suite.repo = NewIdempotencyKeyRepository(suite.db)
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM idempotency_keys")
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) newKey(client, key string, expiresAt time.Time) *entities.IdempotencyKey {
+	return suite.newLockedKey(client, key, "hash", expiresAt, time.Now().Add(time.Minute))
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) newLockedKey(client, key, requestHash string, expiresAt, lockedUntil time.Time) *entities.IdempotencyKey {
+	k, err := entities.NewIdempotencyKey(client, key, requestHash, expiresAt, lockedUntil)
+	suite.Require().NoError(err)
+	return k
+}
+
+// reserveStale は占有期限を過ぎても完了していないキー（処理中にプロセスが停止したもの）を予約する
+func (suite *IdempotencyKeyRepositoryTestSuite) reserveStale(requestHash string) *entities.IdempotencyKey {
+	stale := suite.newLockedKey("ip:127.0.0.1", "key-1", requestHash, time.Now().Add(time.Hour), time.Now().Add(-time.Second))
+	_, reserved, err := suite.repo.Reserve(context.Background(), stale)
+	suite.Require().NoError(err)
+	suite.Require().True(reserved)
+	return stale
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) TestReserve() {
+	suite.Run("同じキーを同時に予約しても1つだけが成功する", func() {
+		ctx := context.Background()
+		expiresAt := time.Now().Add(time.Hour)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		reservedCount := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", expiresAt))
+				assert.NoError(suite.T(), err)
+				if reserved {
+					mu.Lock()
+					reservedCount++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(suite.T(), 1, reservedCount)
+	})
+
+	suite.Run("予約済みのキーは保存されたレスポンスとともに返される", func() {
+		ctx := context.Background()
+		first := suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour))
+		_, reserved, err := suite.repo.Reserve(ctx, first)
+		suite.Require().NoError(err)
+		suite.Require().True(reserved)
+		first.Complete(201, map[string]string{"ETag": `"1"`}, []byte(`{"id":1}`))
+		suite.Require().NoError(suite.repo.Complete(ctx, first))
+
+		existing, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+		assert.Equal(suite.T(), 201, existing.StatusCode)
+		assert.Equal(suite.T(), `"1"`, existing.ResponseHeaders["ETag"])
+		assert.Equal(suite.T(), []byte(`{"id":1}`), existing.ResponseBody)
+	})
+
+	suite.Run("クライアントが異なれば同じキーを予約できる", func() {
+		ctx := context.Background()
+		expiresAt := time.Now().Add(time.Hour)
+		_, _, err := suite.repo.Reserve(ctx, suite.newKey("principal:alice", "key-1", expiresAt))
+		suite.Require().NoError(err)
+
+		_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("principal:bob", "key-1", expiresAt))
+
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+	})
+
+	suite.Run("有効期限切れのキーは再予約できる", func() {
+		ctx := context.Background()
+		_, _, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(-time.Minute)))
+		suite.Require().NoError(err)
+
+		_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+	})
+
+	suite.Run("解放したキーは再予約できる", func() {
+		ctx := context.Background()
+		first := suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour))
+		_, _, err := suite.repo.Reserve(ctx, first)
+		suite.Require().NoError(err)
+		suite.Require().NoError(suite.repo.Release(ctx, first))
+
+		_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+	})
+}
+
+func (suite *IdempotencyKeyRepositoryTestSuite) TestLease() {
+	suite.Run("占有期限を過ぎた処理中のキーは同じリクエストの再送で引き継げる", func() {
+		ctx := context.Background()
+		stale := suite.reserveStale("hash")
+
+		// When
+		retry := suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour))
+		reservedKey, reserved, err := suite.repo.Reserve(ctx, retry)
+
+		// Then
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), reserved)
+		assert.Equal(suite.T(), stale.ID, reservedKey.ID)
+
+		// 引き継いだリクエストのみがレスポンスを保存できる
+		stale.Complete(201, nil, []byte(`{"id":1}`))
+		assert.ErrorIs(suite.T(), suite.repo.Complete(ctx, stale), middleware.ErrIdempotencyKeyLost)
+		retry.Complete(201, nil, []byte(`{"id":2}`))
+		assert.NoError(suite.T(), suite.repo.Complete(ctx, retry))
+
+		existing, _, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), []byte(`{"id":2}`), existing.ResponseBody)
+	})
+
+	suite.Run("占有期限内の処理中のキーは引き継げない", func() {
+		ctx := context.Background()
+		_, _, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+		suite.Require().NoError(err)
+
+		existing, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+		assert.False(suite.T(), existing.IsCompleted())
+	})
+
+	suite.Run("異なるリクエストでは占有期限を過ぎたキーも引き継げない", func() {
+		ctx := context.Background()
+		suite.reserveStale("other")
+
+		_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+	})
+
+	suite.Run("引き継がれたキーは元のリクエストから解放されない", func() {
+		ctx := context.Background()
+		stale := suite.reserveStale("hash")
+		_, reserved, err := suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+		suite.Require().NoError(err)
+		suite.Require().True(reserved)
+
+		// When
+		suite.Require().NoError(suite.repo.Release(ctx, stale))
+
+		// Then: 引き継いだリクエストが処理中のまま残る
+		_, reserved, err = suite.repo.Reserve(ctx, suite.newKey("ip:127.0.0.1", "key-1", time.Now().Add(time.Hour)))
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), reserved)
+	})
+}
+
+func TestIdempotencyKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyKeyRepositoryTestSuite))
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+
+	"go-api-server-sample/cmd/api-server/internal/api/tag"
+	"go-api-server-sample/internal/domain/entities"
+
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) tag.TagRepository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (r *tagRepository) ListWithUsage(ctx context.Context) ([]*tag.TagUsage, error) {
+	var usages []*tag.TagUsage
+	err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, COUNT(contents.id) AS usage_count").
+		Joins("LEFT JOIN content_tags ON content_tags.tag_id = tags.id").
+		Joins("LEFT JOIN contents ON contents.id = content_tags.content_id AND contents.deleted_at IS NULL").
+		Group("tags.id").
+		Order("usage_count DESC, tags.name").
+		Scan(&usages).Error
+	if err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (r *tagRepository) GetByID(ctx context.Context, id uint) (*entities.Tag, error) {
+	var t entities.Tag
+	err := r.db.WithContext(ctx).First(&t, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *tagRepository) Rename(ctx context.Context, t *entities.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&entities.Tag{}).Where("name = ? AND id <> ?", t.Name, t.ID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return tag.ErrTagNameConflict
+		}
+
+		err = tx.Model(t).Update("name", t.Name).Error
+		if isUniqueViolation(err) {
+			return tag.ErrTagNameConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		return touchTaggedContents(tx, t.ID)
+	})
+}
+
+func (r *tagRepository) Merge(ctx context.Context, source, target *entities.Tag) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedContents(tx, source.ID); err != nil {
+			return err
+		}
+
+		// 既にマージ先のタグを持つコンテンツは重複しないよう除外して付け替える
+		err := tx.Exec(`
+			INSERT INTO content_tags (content_id, tag_id)
+			SELECT content_id, ? FROM content_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`,
+			target.ID, source.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM content_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&entities.Tag{}, source.ID).Error
+	})
+}
+
+// touchTaggedContents はタグが付いたコンテンツのバージョンと更新日時を進める
+// タグ名はコンテンツのレスポンスに含まれるため、ETagを変えてキャッシュしたクライアントに古いタグ名を返さないようにする
+func touchTaggedContents(tx *gorm.DB, tagID uint) error {
+	return tx.Exec(`
+		UPDATE contents
+		SET version = version + 1, updated_at = NOW()
+		WHERE id IN (SELECT content_id FROM content_tags WHERE tag_id = ?)`,
+		tagID,
+	).Error
+}
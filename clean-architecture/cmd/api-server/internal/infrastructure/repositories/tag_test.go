@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/api/tag"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type TagRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      tag.TagRepository
+	contents  content.ContentRepository
+}
+
+func (suite *TagRepositoryTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動（カスタムwait strategyを使用）
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	// この時点でPostgreSQLは確実に接続可能
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	suite.repo = NewTagRepository(suite.db)
+	suite.contents = NewContentRepository(suite.db)
+}
+
+func (suite *TagRepositoryTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *TagRepositoryTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+// createTaggedContent はタグ付きのコンテンツを作成する
+func (suite *TagRepositoryTestSuite) createTaggedContent(title string, names ...string) *entities.Content {
+	c, err := entities.NewContent(title, "本文", "article", "作成者", entities.RegisterAuthor(), entities.WithTags(names))
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.contents.Create(context.Background(), c))
+	return c
+}
+
+// tagNames はコンテンツに付与されたタグ名の一覧を返す
+func tagNames(c *entities.Content) []string {
+	names := make([]string, 0, len(c.Tags))
+	for _, t := range c.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// findTag はタグ名からタグを取得する
+func (suite *TagRepositoryTestSuite) findTag(name string) *entities.Tag {
+	var t entities.Tag
+	suite.Require().NoError(suite.db.Where("name = ?", name).First(&t).Error)
+	return &t
+}
+
+func (suite *TagRepositoryTestSuite) TestListWithUsage() {
+	suite.Run("削除されていないコンテンツの件数とともに取得できる", func() {
+		ctx := context.Background()
+		suite.createTaggedContent("記事1", "Go", "API")
+		suite.createTaggedContent("記事2", "Go")
+		deleted := suite.createTaggedContent("記事3", "Go", "API")
+		suite.Require().NoError(suite.contents.Delete(ctx, deleted))
+
+		usages, err := suite.repo.ListWithUsage(ctx)
+
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(usages, 2)
+		assert.Equal(suite.T(), "Go", usages[0].Name)
+		assert.Equal(suite.T(), int64(2), usages[0].UsageCount)
+		assert.Equal(suite.T(), "API", usages[1].Name)
+		assert.Equal(suite.T(), int64(1), usages[1].UsageCount)
+	})
+}
+
+func (suite *TagRepositoryTestSuite) TestRename() {
+	suite.Run("タグ名を変更できる", func() {
+		ctx := context.Background()
+		suite.createTaggedContent("記事1", "golang")
+		t := suite.findTag("golang")
+
+		suite.Require().NoError(t.Rename("Go"))
+		err := suite.repo.Rename(ctx, t)
+
+		assert.NoError(suite.T(), err)
+		renamed, err := suite.repo.GetByID(ctx, t.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "Go", renamed.Name)
+	})
+
+	suite.Run("タグが付いたコンテンツのバージョンと更新日時が進む", func() {
+		ctx := context.Background()
+		tagged := suite.createTaggedContent("記事1", "golang")
+		untagged := suite.createTaggedContent("記事2", "API")
+		t := suite.findTag("golang")
+
+		suite.Require().NoError(t.Rename("Go"))
+		suite.Require().NoError(suite.repo.Rename(ctx, t))
+
+		renamed, err := suite.contents.GetByID(ctx, tagged.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), tagged.Version+1, renamed.Version)
+		assert.True(suite.T(), renamed.UpdatedAt.After(tagged.UpdatedAt))
+
+		unchanged, err := suite.contents.GetByID(ctx, untagged.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), untagged.Version, unchanged.Version)
+	})
+
+	suite.Run("既存のタグ名には変更できない", func() {
+		ctx := context.Background()
+		suite.createTaggedContent("記事1", "golang", "Go")
+		t := suite.findTag("golang")
+
+		suite.Require().NoError(t.Rename("Go"))
+		err := suite.repo.Rename(ctx, t)
+
+		assert.ErrorIs(suite.T(), err, tag.ErrTagNameConflict)
+	})
+}
+
+func (suite *TagRepositoryTestSuite) TestMerge() {
+	suite.Run("マージ元のタグが付け替えられ削除される", func() {
+		ctx := context.Background()
+		both := suite.createTaggedContent("記事1", "golang", "Go")
+		sourceOnly := suite.createTaggedContent("記事2", "golang")
+		targetOnly := suite.createTaggedContent("記事3", "Go")
+		source, target := suite.findTag("golang"), suite.findTag("Go")
+
+		err := suite.repo.Merge(ctx, source, target)
+
+		assert.NoError(suite.T(), err)
+
+		_, err = suite.repo.GetByID(ctx, source.ID)
+		assert.Error(suite.T(), err)
+
+		for _, id := range []uint{both.ID, sourceOnly.ID} {
+			merged, err := suite.contents.GetByID(ctx, id)
+			suite.Require().NoError(err)
+			assert.Equal(suite.T(), []string{"Go"}, tagNames(merged))
+		}
+
+		// マージ元のタグが付いていたコンテンツのみバージョンが進む
+		for _, c := range []*entities.Content{both, sourceOnly} {
+			merged, err := suite.contents.GetByID(ctx, c.ID)
+			suite.Require().NoError(err)
+			assert.Equal(suite.T(), c.Version+1, merged.Version)
+		}
+		unchanged, err := suite.contents.GetByID(ctx, targetOnly.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), targetOnly.Version, unchanged.Version)
+	})
+}
+
+func TestTagRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TagRepositoryTestSuite))
+}
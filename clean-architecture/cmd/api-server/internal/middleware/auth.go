@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey はgin.Contextにプリンシパルを保存するキー
+const principalKey = "principal"
+
+// authRealm はWWW-Authenticateヘッダーのrealm
+const authRealm = "api"
+
+// apiKeyHeader はBearerトークンの代わりにAPIキーを指定するヘッダー
+const apiKeyHeader = "X-API-Key"
+
+// ErrInvalidAPIKey はAPIキーが存在しない、失効している、または有効期限切れであることを表す
+var ErrInvalidAPIKey = errors.New("APIキーが不正です")
+
+// errAuthenticationRequired はBearerトークンまたはAPIキーが指定されていないことを表す
+var errAuthenticationRequired = apierror.New(apierror.KindUnauthorized, "authentication_required", "認証が必要です")
+
+// Principal は認証されたリクエストの主体
+type Principal struct {
+	Subject string
+	// Name はコンテンツの作成者・編集者として記録される表示名で、一意ではないため所有者の判定には使用しない
+	// APIキーのように表示名を持たないプリンシパルでは空になる
+	Name string
+	// Roles はプリンシパルに付与されたロールで、許可される操作を決める
+	Roles []Role
+	// Permissions はロールを介さずに直接許可された操作（APIキーのスコープなど）
+	Permissions []Permission
+}
+
+// TokenVerifier はBearerトークンを検証してプリンシパルを返す
+type TokenVerifier interface {
+	Verify(token string) (*Principal, error)
+}
+
+// APIKeyVerifier はAPIキーを検証してプリンシパルを返す
+// キーが不正な場合は ErrInvalidAPIKey を返す
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// AuthenticateOption は Authenticate の振る舞いを変更するオプション
+type AuthenticateOption func(*authenticator)
+
+// WithAPIKeys はBearerトークンの代わりに X-API-Key ヘッダーのAPIキーでの認証を受け付ける
+func WithAPIKeys(verifier APIKeyVerifier) AuthenticateOption {
+	return func(a *authenticator) {
+		a.apiKeys = verifier
+	}
+}
+
+type authenticator struct {
+	tokens  TokenVerifier
+	apiKeys APIKeyVerifier
+}
+
+// SetPrincipal はリクエストのプリンシパルを設定する
+func SetPrincipal(c *gin.Context, principal *Principal) {
+	c.Set(principalKey, principal)
+	// 以降のログ（SQLのログを含む）に認証済みのユーザーを付与する
+	c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user", principal.Subject))
+}
+
+// PrincipalFrom はリクエストのプリンシパルを返す。認証されていない場合は false を返す
+func PrincipalFrom(c *gin.Context) (*Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok && principal != nil
+}
+
+// EditorName はリビジョンなどに編集者として記録する名前を返す
+// 表示名を持たないプリンシパルは識別子を、認証されていない場合は fallback を編集者とする
+func EditorName(c *gin.Context, fallback string) string {
+	principal, ok := PrincipalFrom(c)
+	if !ok {
+		return fallback
+	}
+	if principal.Name != "" {
+		return principal.Name
+	}
+	return principal.Subject
+}
+
+// Authenticate はAuthorizationヘッダーのBearerトークンを検証し、プリンシパルをコンテキストに設定する
+// WithAPIKeys が指定され X-API-Key ヘッダーがある場合は、トークンの代わりにAPIキーを検証する
+// トークンがない、または検証に失敗した場合は WWW-Authenticate ヘッダー付きで401を返す
+func Authenticate(verifier TokenVerifier, opts ...AuthenticateOption) gin.HandlerFunc {
+	a := &authenticator{tokens: verifier}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return func(c *gin.Context) {
+		if key := c.GetHeader(apiKeyHeader); key != "" && a.apiKeys != nil {
+			a.authenticateAPIKey(c, key)
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="`+authRealm+`"`)
+			apierror.Abort(c, errAuthenticationRequired)
+			return
+		}
+
+		principal, err := a.tokens.Verify(token)
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Debug("トークンの検証に失敗しました", "error", err)
+			c.Header("WWW-Authenticate", `Bearer realm="`+authRealm+`", error="invalid_token"`)
+			apierror.Abort(c, errInvalidToken(err))
+			return
+		}
+
+		SetPrincipal(c, principal)
+		c.Next()
+	}
+}
+
+// errInvalidToken はトークンが不正であることを表すエラーを作成する
+// 有効期限切れ・署名の不正・形式の不正など翻訳できる原因のみを説明に含め、それ以外の検証の詳細は返さない
+func errInvalidToken(err error) *apierror.Error {
+	e := apierror.New(apierror.KindUnauthorized, "invalid_token", "トークンが不正です")
+	if reason := tokenErrorReason(err); reason != nil {
+		return e.WithReason(reason)
+	}
+	return e
+}
+
+// authenticateAPIKey はAPIキーを検証し、プリンシパルをコンテキストに設定する
+func (a *authenticator) authenticateAPIKey(c *gin.Context, key string) {
+	principal, err := a.apiKeys.VerifyAPIKey(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAPIKey) {
+			c.Header("WWW-Authenticate", `Bearer realm="`+authRealm+`", error="invalid_token"`)
+			apierror.Abort(c, apierror.New(apierror.KindUnauthorized, "invalid_api_key", err.Error()))
+			return
+		}
+
+		apierror.Abort(c, apierror.Internal("APIキーの検証に失敗しました", err))
+		return
+	}
+
+	SetPrincipal(c, principal)
+	c.Next()
+}
+
+// bearerToken はAuthorizationヘッダーからBearerトークンを取り出す（スキーム名は大文字小文字を区別しない）
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Role はプリンシパルに付与されるロール
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleWriter Role = "writer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// Permission はロールに許可される操作
+type Permission string
+
+const (
+	// PermissionContentRead はコンテンツ・リビジョン・タグ・作成者・コンテンツタイプを参照する権限
+	PermissionContentRead Permission = "content:read"
+	// PermissionContentWrite はコンテンツを作成し、自分が作成者のコンテンツを更新・削除する権限
+	PermissionContentWrite Permission = "content:write"
+	// PermissionContentWriteAny は他人が作成者のコンテンツも更新・削除・復元する権限
+	PermissionContentWriteAny Permission = "content:write:any"
+	// PermissionContentPublish はコンテンツを公開・アーカイブする権限
+	PermissionContentPublish Permission = "content:publish"
+	// PermissionContentPurge はゴミ箱のコンテンツを完全削除する権限
+	PermissionContentPurge Permission = "content:purge"
+	// PermissionTagManage はタグの名前変更・統合を行う権限
+	PermissionTagManage Permission = "tag:manage"
+	// PermissionAuthorManage は作成者を追加・変更・統合・削除する権限
+	PermissionAuthorManage Permission = "author:manage"
+	// PermissionContentTypeManage はコンテンツタイプを追加・変更・削除する権限
+	PermissionContentTypeManage Permission = "content_type:manage"
+	// PermissionAPIKeyManage はAPIキーを発行・参照・失効する権限
+	PermissionAPIKeyManage Permission = "api_key:manage"
+)
+
+// rolePermissions はロールごとに許可される操作
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {
+		PermissionContentRead,
+	},
+	RoleWriter: {
+		PermissionContentRead,
+		PermissionContentWrite,
+	},
+	RoleEditor: {
+		PermissionContentRead,
+		PermissionContentWrite,
+		PermissionContentWriteAny,
+		PermissionContentPublish,
+		PermissionTagManage,
+		PermissionAuthorManage,
+	},
+	RoleAdmin: {
+		PermissionContentRead,
+		PermissionContentWrite,
+		PermissionContentWriteAny,
+		PermissionContentPublish,
+		PermissionContentPurge,
+		PermissionTagManage,
+		PermissionAuthorManage,
+		PermissionContentTypeManage,
+		PermissionAPIKeyManage,
+	},
+}
+
+// HasPermission はプリンシパルに直接、またはいずれかのロールに操作が許可されているかを返す
+func (p *Principal) HasPermission(permission Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	for _, role := range p.Roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorize はプリンシパルに操作が許可されていない場合に403を返す
+// Authenticate の後に使用し、プリンシパルがない場合は401を返す
+func Authorize(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFrom(c)
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="`+authRealm+`"`)
+			apierror.Abort(c, errAuthenticationRequired)
+			return
+		}
+
+		if !principal.HasPermission(permission) {
+			AbortForbidden(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AllowAll は認証が無効な場合に Authorize の代わりに使用し、すべての操作を許可する
+func AllowAll(Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+	}
+}
+
+// ErrForbidden は操作が許可されていないことを表す
+var ErrForbidden = apierror.Forbidden("forbidden", "この操作を行う権限がありません")
+
+// AbortForbidden は操作が許可されていないことを示す403を返す
+func AbortForbidden(c *gin.Context) {
+	apierror.Abort(c, ErrForbidden)
+}
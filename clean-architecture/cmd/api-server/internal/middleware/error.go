@@ -1,38 +1,95 @@
 package middleware
 
 import (
-	"log"
-	"net/http"
-	"time"
+	"errors"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/infrastructure/logging"
 
 	"github.com/gin-gonic/gin"
 )
 
+// problemContentType はRFC 7807のエラーレスポンスのContent-Type
+const problemContentType = "application/problem+json"
+
+// problemTypeBase はエラーの種類を識別する type URI の接頭辞で、末尾にエラーコードを付ける
+const problemTypeBase = "/problems/"
+
+// Problem はRFC 7807のエラーレスポンス
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code はエラーの種類を表す安定した識別子で、type URI の末尾と同じ
+	Code string `json:"code"`
+	// Errors はリクエストの項目ごとの検証エラー
+	Errors []apierror.FieldError `json:"errors,omitempty"`
+	// Debug は内部エラーの内容で、リリースモード以外でのみ返す
+	Debug string `json:"debug,omitempty"`
+}
+
+// ErrorHandler はハンドラーが記録したエラーを application/problem+json のレスポンスに変換する
+// apierror.Error 以外のエラーは内部エラーとして扱い、原因はリリースモードではクライアントに返さない
+// メッセージは Language が決めた言語に翻訳する
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
-		if len(c.Errors) > 0 {
-			err := c.Errors.Last()
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		ctx := c.Request.Context()
+		err := c.Errors.Last()
+		if c.Writer.Written() {
+			// レスポンスの書き込み後に記録されたエラーはログにのみ出力する
+			logging.FromContext(ctx).ErrorContext(ctx, "エラーが発生しました", "error", err.Err)
+			return
+		}
+
+		apiErr := toAPIError(err)
+		if apiErr.Status() >= 500 {
+			logging.FromContext(ctx).ErrorContext(ctx, "エラーが発生しました", "code", apiErr.Code, "error", apiErr)
+		}
 
-			log.Printf("エラーが発生しました: %v", err.Err)
+		respondProblem(c, apiErr)
+	}
+}
 
-			errorResponse := gin.H{
-				"code":      http.StatusInternalServerError,
-				"message":   "内部サーバーエラーが発生しました",
-				"timestamp": time.Now().Format(time.RFC3339),
-			}
+// toAPIError は記録されたエラーを apierror.Error に変換する
+func toAPIError(err *gin.Error) *apierror.Error {
+	var apiErr *apierror.Error
+	if errors.As(err.Err, &apiErr) {
+		return apiErr
+	}
+	if err.Type == gin.ErrorTypeBind {
+		return apierror.Bind(err.Err)
+	}
+	return apierror.Internal("内部サーバーエラーが発生しました", err.Err)
+}
 
-			switch e := err.Err.(type) {
-			case *gin.Error:
-				if e.Type == gin.ErrorTypeBind {
-					errorResponse["code"] = http.StatusBadRequest
-					errorResponse["message"] = "リクエストデータが正しくありません"
-					errorResponse["details"] = e.Error()
-				}
-			}
+func respondProblem(c *gin.Context, apiErr *apierror.Error) {
+	lang := LanguageFrom(c)
+	localized := apiErr.Localize(lang)
 
-			c.AbortWithStatusJSON(errorResponse["code"].(int), errorResponse)
-		}
+	problem := Problem{
+		Type:     problemTypeBase + apiErr.Code,
+		Title:    localized.Message,
+		Status:   apiErr.Status(),
+		Detail:   localized.Detail,
+		Instance: c.Request.URL.Path,
+		Code:     apiErr.Code,
+		Errors:   localized.Fields,
 	}
+	if apiErr.Err != nil && gin.Mode() != gin.ReleaseMode {
+		// 内部エラーの概要は翻訳で共通のメッセージになるため、呼び出し元のメッセージを含める
+		problem.Debug = apiErr.Error()
+	}
+
+	// Content-Type を先に設定すると c.JSON は上書きしない
+	c.Header("Content-Type", problemContentType)
+	c.Header("Content-Language", lang)
+	c.AbortWithStatusJSON(problem.Status, problem)
 }
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idempotencyKeyHeader は再送されたリクエストを識別するためにクライアントが指定するヘッダー
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentReplayedHeader は保存済みのレスポンスを返したことを示すヘッダー
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotentResponseHeaders は保存し、再送時に返すレスポンスヘッダー
+var idempotentResponseHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// ErrIdempotencyKeyLost は処理中に占有期限を過ぎ、キーが他のリクエストに引き継がれたことを表す
+var ErrIdempotencyKeyLost = errors.New("Idempotency-Keyの占有期限を過ぎたため、レスポンスを保存できませんでした")
+
+// IdempotencyStore は冪等キーと最初のレスポンスを保持する
+type IdempotencyStore interface {
+	// Reserve は有効な同じキーが存在しなければ key を保存して true を返す
+	// 存在する場合は保存済みのキーを返す（有効期限切れのキーは置き換える）
+	// 同じリクエストの処理中のキーが占有期限を過ぎている場合は、key の占有期限で引き継いで true を返す
+	Reserve(ctx context.Context, key *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error)
+	// Complete は Reserve したキーに最初のレスポンスを保存する
+	// 他のリクエストに引き継がれていた場合は ErrIdempotencyKeyLost を返す
+	Complete(ctx context.Context, key *entities.IdempotencyKey) error
+	// Release は処理が完了しなかったキーを削除し、同じキーで再試行できるようにする
+	// 他のリクエストに引き継がれていた場合は何もしない
+	Release(ctx context.Context, key *entities.IdempotencyKey) error
+}
+
+// Idempotency は Idempotency-Key ヘッダーが指定されたリクエストの最初のレスポンスを ttl の間保存し、
+// 同じクライアントが同じキーで再送した場合はハンドラーを実行せずに保存済みのレスポンスを返す
+// 同じキーで異なるリクエストが送られた場合は422、最初のリクエストが処理中の場合は409を返す
+// エラーのレスポンス（ErrorHandler が書き込むものと5xx）は保存せず、同じキーで再試行できる
+// 処理中のキーは lease の間占有し、プロセスの停止などで完了も解放もされなかったキーは lease を過ぎると再送で引き継げる
+// lease はリクエストの処理にかかる最大の時間より長くする
+func Idempotency(store IdempotencyStore, ttl, lease time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			apierror.Abort(c, apierror.Bind(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := entities.HashIdempotentRequest(c.Request.Method, c.Request.URL.Path, body)
+		now := time.Now()
+		record, err := entities.NewIdempotencyKey(clientKey(c), key, requestHash, now.Add(ttl), now.Add(lease))
+		if err != nil {
+			apierror.Abort(c, apierror.Validation("invalid_idempotency_key", "不正なIdempotency-Keyです", err))
+			return
+		}
+
+		ctx := c.Request.Context()
+		existing, reserved, err := store.Reserve(ctx, record)
+		if err != nil {
+			apierror.Abort(c, apierror.Internal("Idempotency-Keyの確認に失敗しました", err))
+			return
+		}
+		if !reserved {
+			replay(c, existing, requestHash)
+			return
+		}
+
+		// ハンドラーがパニックした場合も含め、レスポンスを保存できなかったキーは解放する
+		completed := false
+		defer func() {
+			if !completed {
+				store.Release(context.WithoutCancel(ctx), record)
+			}
+		}()
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// ハンドラーが記録したエラーは、このミドルウェアが戻った後に ErrorHandler がレスポンスを書き込む
+		status := writer.Status()
+		if !writer.Written() || status >= http.StatusInternalServerError {
+			return
+		}
+
+		headers := make(map[string]string, len(idempotentResponseHeaders))
+		for _, name := range idempotentResponseHeaders {
+			if value := writer.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		record.Complete(status, headers, writer.body.Bytes())
+
+		if err := store.Complete(context.WithoutCancel(ctx), record); err != nil {
+			c.Error(err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay は保存済みのキーに対するリクエストに応答する
+func replay(c *gin.Context, existing *entities.IdempotencyKey, requestHash string) {
+	if !existing.Matches(requestHash) {
+		apierror.Abort(c, apierror.New(apierror.KindUnprocessable, "idempotency_key_reused", "Idempotency-Keyが異なるリクエストで使用されています"))
+		return
+	}
+
+	if !existing.IsCompleted() {
+		c.Header("Retry-After", "1")
+		apierror.Abort(c, apierror.Conflict("idempotency_key_in_progress", "同じIdempotency-Keyのリクエストを処理中です"))
+		return
+	}
+
+	for name, value := range existing.ResponseHeaders {
+		c.Header(name, value)
+	}
+	c.Header(idempotentReplayedHeader, "true")
+	c.Status(existing.StatusCode)
+	c.Writer.Write(existing.ResponseBody)
+	c.Abort()
+}
+
+// capturingWriter はクライアントに書き込んだレスポンスボディを保存する
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
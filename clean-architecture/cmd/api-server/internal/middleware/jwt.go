@@ -0,0 +1,249 @@
+package middleware
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoVerificationKey = errors.New("JWTの検証鍵が設定されていません")
+	ErrUnknownKeyID      = apierror.NewReason("unknown_key_id", "JWTの鍵IDに対応する検証鍵がありません")
+	ErrMissingSubject    = apierror.NewReason("missing_subject", "JWTにsubクレームがありません")
+)
+
+// トークンの検証に失敗した原因のうち、クライアントに返す原因
+var (
+	errTokenExpired          = apierror.NewReason("token_expired", "トークンの有効期限が切れています")
+	errTokenSignatureInvalid = apierror.NewReason("token_signature_invalid", "トークンの署名が不正です")
+	errTokenMalformed        = apierror.NewReason("token_malformed", "トークンの形式が正しくありません")
+)
+
+// tokenErrorReason はトークンの検証エラーをクライアントに返す原因に変換する
+// ライブラリのエラーメッセージは検証の詳細を含むため返さず、対応する原因がない場合は nil を返す
+func tokenErrorReason(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return errTokenExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return errTokenSignatureInvalid
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return errTokenMalformed
+	}
+
+	var reason *apierror.Reason
+	if errors.As(err, &reason) {
+		return reason
+	}
+	return nil
+}
+
+// JWTConfig はJWTの検証設定
+// HS256Secret、RS256PublicKeyFile（PEM形式）、JWKSFile のうち少なくとも1つを指定する
+type JWTConfig struct {
+	HS256Secret        string
+	RS256PublicKeyFile string
+	JWKSFile           string
+	Issuer             string
+	Audience           string
+	Leeway             time.Duration
+}
+
+// JWTVerifier はBearerトークンのJWTを検証してプリンシパルを取り出す
+type JWTVerifier struct {
+	hmacSecret []byte
+	// rsaKeys は鍵ID（kid）ごとのRS256の検証鍵で、PEMファイルの鍵は空の鍵IDで登録する
+	rsaKeys map[string]*rsa.PublicKey
+	parser  *jwt.Parser
+}
+
+// principalClaims はプリンシパルの取り出しに使用するクレーム
+type principalClaims struct {
+	jwt.RegisteredClaims
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Roles             []string `json:"roles"`
+}
+
+// NewJWTVerifier は設定された鍵を読み込んでJWTVerifierを作成する
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	verifier := &JWTVerifier{rsaKeys: map[string]*rsa.PublicKey{}}
+
+	if cfg.HS256Secret != "" {
+		verifier.hmacSecret = []byte(cfg.HS256Secret)
+	}
+
+	if cfg.RS256PublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.RS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("RS256の公開鍵の読み込みに失敗しました: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("RS256の公開鍵の解析に失敗しました: %w", err)
+		}
+		verifier.rsaKeys[""] = key
+	}
+
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWKSの読み込みに失敗しました: %w", err)
+		}
+		for kid, key := range keys {
+			verifier.rsaKeys[kid] = key
+		}
+	}
+
+	if verifier.hmacSecret == nil && len(verifier.rsaKeys) == 0 {
+		return nil, ErrNoVerificationKey
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(verifier.methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	verifier.parser = jwt.NewParser(opts...)
+
+	return verifier, nil
+}
+
+// Verify はトークンの署名とクレームを検証し、プリンシパルを返す
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	var claims principalClaims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.key); err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrMissingSubject
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+	if name == "" {
+		name = claims.Subject
+	}
+
+	return &Principal{
+		Subject: claims.Subject,
+		Name:    name,
+		Roles:   knownRoles(claims.Roles),
+	}, nil
+}
+
+// knownRoles はrolesクレームのうち定義済みのロールのみを返す
+func knownRoles(names []string) []Role {
+	roles := []Role{}
+	for _, name := range names {
+		role := Role(name)
+		if _, ok := rolePermissions[role]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// methods は設定された鍵で検証できる署名アルゴリズムを返す
+// 鍵の種類と異なるアルゴリズムを拒否し、公開鍵をHMACの秘密鍵として使う攻撃を防ぐ
+func (v *JWTVerifier) methods() []string {
+	methods := []string{}
+	if v.hmacSecret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(v.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	return methods
+}
+
+// key はトークンのヘッダーに応じた検証鍵を返す
+func (v *JWTVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		// 鍵IDのないトークンは、鍵が1つだけの場合に限りその鍵で検証する
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, ErrUnknownKeyID
+	}
+	return nil, jwt.ErrSignatureInvalid
+}
+
+// jwk はJWKSに含まれるRSA公開鍵
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// loadJWKS はJWKSファイルから署名用のRSA公開鍵を読み込む
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		// RS256の署名検証に使えない鍵は無視する
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != "RS256") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("鍵 %q のnが不正です: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("鍵 %q のeが不正です: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("RS256の署名検証に使える鍵がありません")
+	}
+	return keys, nil
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/i18n"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const jwtTestSecret = "test-secret"
+
+type JWTVerifierTestSuite struct {
+	suite.Suite
+	verifier *JWTVerifier
+}
+
+func (suite *JWTVerifierTestSuite) SetupTest() {
+	verifier, err := NewJWTVerifier(JWTConfig{HS256Secret: jwtTestSecret, Issuer: "test-issuer"})
+	suite.Require().NoError(err)
+	suite.verifier = verifier
+}
+
+func (suite *JWTVerifierTestSuite) sign(claims jwt.MapClaims, secret string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	suite.Require().NoError(err)
+	return token
+}
+
+func (suite *JWTVerifierTestSuite) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "test-issuer",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (suite *JWTVerifierTestSuite) TestInvalidToken() {
+	expired := suite.claims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherIssuer := suite.claims()
+	otherIssuer["iss"] = "other-issuer"
+	noSubject := suite.claims()
+	delete(noSubject, "sub")
+
+	tests := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{"有効期限切れ", suite.sign(expired, jwtTestSecret), "The token has expired"},
+		{"署名が不正", suite.sign(suite.claims(), "wrong-secret"), "The token signature is invalid"},
+		{"形式が不正", "not-a-jwt", "The token is malformed"},
+		{"subクレームがない", suite.sign(noSubject, jwtTestSecret), "The JWT has no sub claim"},
+		{"翻訳できない原因は返さない", suite.sign(otherIssuer, jwtTestSecret), ""},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// When
+			_, err := suite.verifier.Verify(tt.token)
+			suite.Require().Error(err)
+
+			// Then
+			localized := errInvalidToken(err).Localize(i18n.English)
+			assert.Equal(suite.T(), "invalid_token", localized.Code)
+			assert.Equal(suite.T(), tt.expected, localized.Detail)
+		})
+	}
+}
+
+func TestJWTVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(JWTVerifierTestSuite))
+}
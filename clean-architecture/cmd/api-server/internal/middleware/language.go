@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"go-api-server-sample/cmd/api-server/internal/api/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// languageKey はgin.Contextにレスポンスの言語を保存するキー
+const languageKey = "language"
+
+// Language は Accept-Language ヘッダーからレスポンスの言語を決め、コンテキストに設定する
+// エラーレスポンスのメッセージはこの言語で返す
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(languageKey, i18n.Negotiate(c.GetHeader("Accept-Language")))
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// LanguageFrom はリクエストの言語を返す。Language が設定していない場合は既定の言語を返す
+func LanguageFrom(c *gin.Context) string {
+	if lang, ok := c.Get(languageKey); ok {
+		if lang, ok := lang.(string); ok {
+			return lang
+		}
+	}
+	return i18n.Default
+}
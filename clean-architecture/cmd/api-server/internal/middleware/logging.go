@@ -1,51 +1,43 @@
 package middleware
 
 import (
-	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
+	"go-api-server-sample/internal/infrastructure/logging"
+
 	"github.com/gin-gonic/gin"
 )
 
-func RequestLogger() gin.HandlerFunc {
+// Logger はリクエストごとにアクセスログを出力する
+// ログはリクエストのコンテキストのロガーに出力するため、RequestID の後に適用する
+// ステータスが5xxの場合はエラー、4xxの場合は警告として出力する
+func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
 
 		c.Next()
 
-		latency := time.Since(start)
-		clientIP := c.ClientIP()
-		method := c.Request.Method
-		statusCode := c.Writer.Status()
-
-		if raw != "" {
-			path = path + "?" + raw
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
 		}
 
-		log.Printf("[%s] %d %s %s %v",
-			method,
-			statusCode,
-			clientIP,
-			path,
-			latency,
+		// 認証で付与したユーザーを含めるため、処理後のリクエストのコンテキストを使う
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "リクエストを処理しました",
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("query", c.Request.URL.RawQuery),
+			slog.Int("status", status),
+			slog.Int("size", c.Writer.Size()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
 		)
 	}
 }
-
-func StructuredLogger() gin.HandlerFunc {
-	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf(`{"time":"%s","method":"%s","path":"%s","protocol":"%s","status_code":%d,"latency":"%s","client_ip":"%s","user_agent":"%s"}`+"\n",
-			param.TimeStamp.Format(time.RFC3339),
-			param.Method,
-			param.Path,
-			param.Request.Proto,
-			param.StatusCode,
-			param.Latency,
-			param.ClientIP,
-			param.Request.UserAgent(),
-		)
-	})
-}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute はどのルートにも一致しなかったリクエストのルート名
+// 存在しないパスごとに系列が増えないよう、まとめて集計する
+const unmatchedRoute = "unmatched"
+
+// RequestMetrics はHTTPリクエストのメトリクスを記録する
+type RequestMetrics interface {
+	// RequestStarted は処理中のリクエスト数を増やし、処理の終了時に呼ぶ関数を返す
+	RequestStarted() (done func())
+	// ObserveRequest はリクエストの件数と処理時間を記録する
+	ObserveRequest(method, route, status string, duration time.Duration)
+}
+
+// Metrics はリクエストの件数・処理時間・処理中のリクエスト数を記録する
+// ルートはパスではなくルートのテンプレート（/api/v1/contents/:id など）で集計する
+func Metrics(m RequestMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		done := m.RequestStarted()
+		defer done()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apierror"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errRateLimited はクライアントのリクエスト数が制限を超えたことを表す
+var errRateLimited = apierror.New(apierror.KindTooManyRequests, "rate_limited", "リクエストが多すぎます。しばらくしてから再度お試しください")
+
+// RateLimit はトークンバケットの設定
+// バケットの容量は Requests で、Period ごとに Requests 個のトークンが補充される
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimits は読み取り（GET / HEAD / OPTIONS）と書き込み（それ以外のメソッド）のリクエストの制限
+// Requests または Period が0以下の制限は適用しない
+type RateLimits struct {
+	Read  RateLimit
+	Write RateLimit
+}
+
+// RateLimitResult はトークンを1つ消費しようとした結果
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining は消費後に残っているトークン数
+	Remaining int
+	// Reset はバケットが満杯に戻るまでの時間
+	Reset time.Duration
+	// RetryAfter は拒否された場合に次のトークンが補充されるまでの時間
+	RetryAfter time.Duration
+}
+
+// RateLimitStore はクライアントごとのトークンバケットを保持する
+// 複数のサーバーで制限を共有する場合は、共有ストレージを使う実装に差し替える
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimiter はクライアントごとにリクエスト数を制限し、RateLimit-* ヘッダーで残りの割り当てを返す
+// クライアントはAPIキー・ユーザー（プリンシパル）ごと、認証されていない場合はクライアントIPごとに識別するため、
+// Authenticate の後に適用する
+// 制限を超えた場合は Retry-After ヘッダー付きで429を返す
+func RateLimiter(store RateLimitStore, limits RateLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class, limit := "write", limits.Write
+		if isReadMethod(c.Request.Method) {
+			class, limit = "read", limits.Read
+		}
+		if limit.Requests <= 0 || limit.Period <= 0 {
+			c.Next()
+			return
+		}
+
+		if !take(c, store, class+":"+clientKey(c), limit) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// IPRateLimiter はメソッドによらずクライアントIPごとにリクエスト数を制限する
+// 不正なトークンやAPIキーを試すリクエストも制限できるよう、Authenticate の前に適用する
+// クライアントIPは信頼するプロキシ（gin.Engine.SetTrustedProxies）の X-Forwarded-For を考慮して判定する
+// Requests または Period が0以下の場合は制限しない
+func IPRateLimiter(store RateLimitStore, limit RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit.Requests <= 0 || limit.Period <= 0 {
+			c.Next()
+			return
+		}
+
+		if !take(c, store, "ip:"+c.ClientIP(), limit) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// take はトークンを1つ消費して RateLimit-* ヘッダーを設定する
+// 制限を超えた場合は429を返して false を返す
+func take(c *gin.Context, store RateLimitStore, key string, limit RateLimit) bool {
+	result, err := store.Take(c.Request.Context(), key, limit)
+	if err != nil {
+		// ストアの障害でAPI全体を止めないよう、制限せずに処理を続ける
+		logging.FromContext(c.Request.Context()).Error("レート制限の確認に失敗しました", "error", err)
+		return true
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+
+	if !result.Allowed {
+		c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+		apierror.Abort(c, errRateLimited)
+		return false
+	}
+	return true
+}
+
+// clientKey はリクエストのクライアントを識別するキーを返す
+// APIキーのプリンシパルの Subject は "api_key:<id>" のため、同じユーザーのAPIキーとトークンは別々のクライアントになる
+func clientKey(c *gin.Context) string {
+	if principal, ok := PrincipalFrom(c); ok {
+		return "principal:" + principal.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// ceilSeconds は時間を切り上げた秒数の文字列で返す
+func ceilSeconds(d time.Duration) string {
+	if d <= 0 {
+		return "0"
+	}
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// memoryStoreSweepInterval は満杯に戻ったバケットを破棄する間隔
+const memoryStoreSweepInterval = time.Minute
+
+// MemoryRateLimitStore はプロセス内のメモリにトークンバケットを保持する RateLimitStore
+// 制限はサーバーのプロセスごとに適用される
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	// updatedAt は tokens を最後に計算した時刻
+	updatedAt time.Time
+	// fullAt はバケットが満杯に戻る時刻
+	fullAt time.Time
+}
+
+// NewMemoryRateLimitStore はMemoryRateLimitStoreの新しいインスタンスを作成する
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	perToken := limit.Period / time.Duration(limit.Requests)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		s.buckets[key] = bucket
+	}
+
+	// 前回からの経過時間分のトークンを補充する
+	elapsed := now.Sub(bucket.updatedAt)
+	bucket.tokens = math.Min(capacity, bucket.tokens+elapsed.Seconds()/perToken.Seconds())
+	bucket.updatedAt = now
+
+	result := RateLimitResult{Allowed: bucket.tokens >= 1}
+	if result.Allowed {
+		bucket.tokens--
+	} else {
+		result.RetryAfter = time.Duration((1 - bucket.tokens) * float64(perToken))
+	}
+
+	bucket.fullAt = now.Add(time.Duration((capacity - bucket.tokens) * float64(perToken)))
+	result.Remaining = int(bucket.tokens)
+	result.Reset = bucket.fullAt.Sub(now)
+
+	return result, nil
+}
+
+// sweep は満杯に戻ったバケットを破棄する（次のリクエストで満杯のバケットとして作り直されるため結果は変わらない）
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memoryStoreSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
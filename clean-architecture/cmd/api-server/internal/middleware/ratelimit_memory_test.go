@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeClock はテストで進める時刻
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type MemoryRateLimitStoreTestSuite struct {
+	suite.Suite
+	clock *fakeClock
+	store *MemoryRateLimitStore
+}
+
+func (suite *MemoryRateLimitStoreTestSuite) SetupSubTest() {
+	suite.clock = &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	suite.store = NewMemoryRateLimitStore()
+	suite.store.now = suite.clock.Now
+}
+
+func (suite *MemoryRateLimitStoreTestSuite) take(key string, limit RateLimit) RateLimitResult {
+	result, err := suite.store.Take(context.Background(), key, limit)
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *MemoryRateLimitStoreTestSuite) TestTake() {
+	// 1秒ごとに1つ補充され、3秒で満杯に戻る
+	limit := RateLimit{Requests: 3, Period: 3 * time.Second}
+
+	type step struct {
+		// advance は前のリクエストからの経過時間
+		advance  time.Duration
+		expected RateLimitResult
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"容量までは許可され、残りと満杯に戻るまでの時間が返される", []step{
+			{0, RateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+		}},
+		{"空になると拒否され、次のトークンが補充されるまでの時間が返される", []step{
+			{0, RateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+			{0, RateLimitResult{Allowed: false, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}},
+		}},
+		{"経過時間に応じてトークンが補充される", []step{
+			{0, RateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+			{0, RateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+			{500 * time.Millisecond, RateLimitResult{Allowed: false, Remaining: 0, Reset: 2500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+			{500 * time.Millisecond, RateLimitResult{Allowed: true, Remaining: 0, Reset: 3 * time.Second}},
+			{2 * time.Second, RateLimitResult{Allowed: true, Remaining: 1, Reset: 2 * time.Second}},
+		}},
+		{"補充は容量を超えない", []step{
+			{0, RateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+			{time.Hour, RateLimitResult{Allowed: true, Remaining: 2, Reset: time.Second}},
+		}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			for i, s := range tt.steps {
+				suite.clock.advance(s.advance)
+				assert.Equal(suite.T(), s.expected, suite.take("client", limit), "%d回目", i+1)
+			}
+		})
+	}
+}
+
+func (suite *MemoryRateLimitStoreTestSuite) TestKeys() {
+	suite.Run("キーごとに別々のバケットで制限される", func() {
+		limit := RateLimit{Requests: 1, Period: time.Minute}
+		suite.Require().True(suite.take("alice", limit).Allowed)
+
+		assert.False(suite.T(), suite.take("alice", limit).Allowed)
+		assert.True(suite.T(), suite.take("bob", limit).Allowed)
+	})
+}
+
+func (suite *MemoryRateLimitStoreTestSuite) TestSweep() {
+	short := RateLimit{Requests: 2, Period: 2 * time.Second}
+	long := RateLimit{Requests: 1, Period: time.Hour}
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		expected []string
+	}{
+		{"間隔が経過するまでは破棄しない", memoryStoreSweepInterval - time.Second, []string{"long", "short", "trigger"}},
+		{"満杯に戻ったバケットのみ破棄する", memoryStoreSweepInterval, []string{"long", "trigger"}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			// Given: 1秒で満杯に戻るバケットと、1時間で満杯に戻るバケット
+			suite.take("short", short)
+			suite.take("long", long)
+
+			// When: 別のキーのリクエストで破棄が実行される
+			suite.clock.advance(tt.advance)
+			suite.take("trigger", short)
+
+			// Then
+			keys := make([]string, 0, len(suite.store.buckets))
+			for key := range suite.store.buckets {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(suite.T(), tt.expected, keys)
+		})
+	}
+
+	suite.Run("破棄されたバケットは満杯として作り直される", func() {
+		// Given
+		suite.take("short", short)
+		suite.clock.advance(memoryStoreSweepInterval)
+
+		// When
+		result := suite.take("short", short)
+
+		// Then
+		assert.Equal(suite.T(), RateLimitResult{Allowed: true, Remaining: 1, Reset: time.Second}, result)
+	})
+}
+
+func TestMemoryRateLimitStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRateLimitStoreTestSuite))
+}
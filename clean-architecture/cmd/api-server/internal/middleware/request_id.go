@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDHeader はリクエストを識別するIDを受け取り、レスポンスで返すヘッダー
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey はgin.ContextにリクエストIDを保存するキー
+const requestIDKey = "request_id"
+
+// maxRequestIDLength はクライアントが指定できるリクエストIDの最大長
+const maxRequestIDLength = 128
+
+// RequestID はリクエストIDを決め、レスポンスヘッダーとリクエストのコンテキストのロガーに設定する
+// X-Request-ID ヘッダーが指定された場合はその値を使い、ない・不正な場合は新しく生成する
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+
+		ctx := logging.WithContext(c.Request.Context(), logger.With("request_id", id))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// RequestIDFrom はリクエストIDを返す。RequestID が設定していない場合は空文字を返す
+func RequestIDFrom(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// isValidRequestID はログやヘッダーにそのまま出力できる値かどうかを返す（空白を含まない表示可能なASCII文字）
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Config はHTTPサーバーの設定
+type Config struct {
+	Addr string
+	// ReadTimeout と WriteTimeout はリクエストの読み込み・レスポンスの書き込みを含む処理全体の制限時間
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// IdleTimeout はKeep-Aliveの接続で次のリクエストを待つ時間
+	IdleTimeout time.Duration
+	// ShutdownDelay は停止を開始してから新しい接続の受け付けを止めるまでの待ち時間
+	// ロードバランサーがreadinessの失敗を検知し、振り分けを止めるまでの時間を指定する
+	ShutdownDelay time.Duration
+	// ShutdownTimeout は処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration
+}
+
+// Server は停止時に処理中のリクエストの完了を待つHTTPサーバー
+type Server struct {
+	http            *http.Server
+	shutdownDelay   time.Duration
+	shutdownTimeout time.Duration
+	beforeShutdown  []func()
+}
+
+// New はServerの新しいインスタンスを作成する
+func New(cfg Config, handler http.Handler) *Server {
+	return &Server{
+		http: &http.Server{
+			Addr:         cfg.Addr,
+			Handler:      handler,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			IdleTimeout:  cfg.IdleTimeout,
+		},
+		shutdownDelay:   cfg.ShutdownDelay,
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+}
+
+// BeforeShutdown は停止を開始したときに呼ぶ関数を登録する（readinessを失敗させるなど）
+// 登録した関数は、新しい接続の受け付けを止める前に登録した順に呼ばれる
+func (s *Server) BeforeShutdown(fn func()) {
+	s.beforeShutdown = append(s.beforeShutdown, fn)
+}
+
+// ListenAndServe は Addr で接続を受け付け、ctx がキャンセルされると停止する
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return fmt.Errorf("ポート %s で待ち受けできません: %w", s.http.Addr, err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve は ln で接続を受け付け、ctx がキャンセルされると次の順に停止する
+//  1. BeforeShutdown で登録した関数を呼ぶ
+//  2. ShutdownDelay の間、リクエストの処理を続ける
+//  3. 新しい接続の受け付けを止め、処理中のリクエストの完了を ShutdownTimeout まで待つ
+//
+// 処理中のリクエストが時間内に完了しなかった場合は接続を閉じてエラーを返す
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.http.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("サーバーを停止しています", "shutdown_delay", s.shutdownDelay, "shutdown_timeout", s.shutdownTimeout)
+	for _, fn := range s.beforeShutdown {
+		fn()
+	}
+	time.Sleep(s.shutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		s.http.Close()
+		return fmt.Errorf("処理中のリクエストが時間内に完了しませんでした: %w", err)
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	listener net.Listener
+	url      string
+	client   *http.Client
+}
+
+func (suite *ServerTestSuite) SetupSubTest() {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.listener = ln
+	suite.url = "http://" + ln.Addr().String()
+	// 停止後の接続が拒否されることを確認するため、接続を使い回さない
+	suite.client = &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{DisableKeepAlives: true},
+	}
+}
+
+// serve はサーバーを起動し、停止した結果を返すチャネルを返す
+func (suite *ServerTestSuite) serve(ctx context.Context, s *Server) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Serve(ctx, suite.listener)
+	}()
+	return errCh
+}
+
+func (suite *ServerTestSuite) TestServe() {
+	suite.Run("停止時に処理中のリクエストの完了を待つ", func() {
+		// Given
+		started := make(chan struct{})
+		s := New(Config{ShutdownTimeout: 5 * time.Second}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		var notReady atomic.Bool
+		s.BeforeShutdown(func() { notReady.Store(true) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errCh := suite.serve(ctx, s)
+
+		statusCh := make(chan int, 1)
+		go func() {
+			resp, err := suite.client.Get(suite.url)
+			if err != nil {
+				statusCh <- 0
+				return
+			}
+			resp.Body.Close()
+			statusCh <- resp.StatusCode
+		}()
+		<-started
+
+		// When
+		cancel()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, <-statusCh)
+		assert.NoError(suite.T(), <-errCh)
+		assert.True(suite.T(), notReady.Load())
+
+		_, err := suite.client.Get(suite.url)
+		assert.Error(suite.T(), err)
+	})
+
+	suite.Run("ShutdownDelay の間は新しいリクエストも処理する", func() {
+		// Given
+		s := New(Config{ShutdownDelay: 500 * time.Millisecond, ShutdownTimeout: 5 * time.Second}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		notReady := make(chan struct{})
+		s.BeforeShutdown(func() { close(notReady) })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := suite.serve(ctx, s)
+
+		// When
+		cancel()
+		<-notReady
+
+		// Then
+		resp, err := suite.client.Get(suite.url)
+		suite.Require().NoError(err)
+		resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.NoError(suite.T(), <-errCh)
+	})
+
+	suite.Run("処理中のリクエストが時間内に完了しない場合はエラー", func() {
+		// Given
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		s := New(Config{ShutdownTimeout: 50 * time.Millisecond}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := suite.serve(ctx, s)
+
+		go func() {
+			if resp, err := suite.client.Get(suite.url); err == nil {
+				resp.Body.Close()
+			}
+		}()
+		<-started
+
+		// When
+		cancel()
+
+		// Then
+		assert.ErrorIs(suite.T(), <-errCh, context.DeadlineExceeded)
+	})
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
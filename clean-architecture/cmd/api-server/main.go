@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/cmd/api-server/internal/server"
+	"go-api-server-sample/config"
 	"go-api-server-sample/internal/infrastructure/database"
+	"go-api-server-sample/internal/infrastructure/logging"
+	"go-api-server-sample/internal/infrastructure/tracing"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,9 +23,53 @@ import (
 func main() {
 	migrate := flag.Bool("migrate", false, "Run database migration")
 	migrateReset := flag.Bool("migrate-reset", false, "Reset database (development only)")
+	configFile := flag.String("config", "", "Path to a YAML or TOML config file (defaults to $CONFIG_FILE)")
+	printConfig := flag.Bool("print-config", false, "Print the effective configuration with secrets redacted and exit")
 	flag.Parse()
 
-	db, err := database.Connect()
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal("設定が不正です:\n", err)
+	}
+
+	if *printConfig {
+		if err := cfg.Dump(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// 標準の log パッケージの出力も含め、ログを設定された形式で出力する
+	logger, err := logging.New(os.Stdout, cfg.Logger.Level, cfg.Logger.Format)
+	if err != nil {
+		log.Fatal("ロガーの初期化に失敗しました:", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+		ServiceName:  cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		log.Fatal("トレースの初期化に失敗しました:", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	db, err := database.Connect(database.DatabaseConfig{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		User:            cfg.Database.User,
+		Password:        cfg.Database.Password.Reveal(),
+		Database:        cfg.Database.Database,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		LogLevel:        cfg.Logger.Level,
+	})
 	if err != nil {
 		log.Fatal("データベース接続に失敗しました:", err)
 	}
@@ -43,46 +96,165 @@ func main() {
 		log.Fatal("マイグレーション実行に失敗しました:", err)
 	}
 
-	dependencyContainer := NewContainer(db)
+	dependencyContainer, err := NewContainer(db, cfg)
+	if err != nil {
+		log.Fatal("依存関係の初期化に失敗しました:", err)
+	}
+
+	// コンテンツの検証で参照するコンテンツタイプをデータベースから読み込む
+	if err := dependencyContainer.ContentTypeAPI.Reload(context.Background()); err != nil {
+		log.Fatal("コンテンツタイプの読み込みに失敗しました:", err)
+	}
+
+	// SIGINT・SIGTERM を受け取ると停止を開始する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// 停止中に再度シグナルを受け取った場合は、処理中のリクエストを待たずに終了する
+		<-ctx.Done()
+		stop()
+	}()
 
-	router := setupRouter(dependencyContainer)
+	// 保持期間を過ぎたゴミ箱のコンテンツをバックグラウンドで完全削除する
+	var workers sync.WaitGroup
+	workers.Go(func() {
+		dependencyContainer.TrashPurger.Run(ctx)
+	})
+	// 他のインスタンスで変更されたコンテンツタイプを定期的に読み込み直す
+	workers.Go(func() {
+		dependencyContainer.ContentTypeRefresher.Run(ctx)
+	})
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	srv := server.New(server.Config{
+		Addr:            ":" + cfg.Server.Port,
+		ReadTimeout:     cfg.Server.Timeout,
+		WriteTimeout:    cfg.Server.Timeout,
+		IdleTimeout:     cfg.Server.IdleTimeout,
+		ShutdownDelay:   cfg.Server.ShutdownDelay,
+		ShutdownTimeout: cfg.Server.Shutdown,
+	}, setupRouter(dependencyContainer))
+	// 新しい接続の受け付けを止める前にヘルスチェックを失敗させ、ロードバランサーに振り分けを止めさせる
+	srv.BeforeShutdown(dependencyContainer.HealthAPI.SetShuttingDown)
+
+	log.Printf("サーバーをポート %s で起動します", cfg.Server.Port)
+	if err := srv.ListenAndServe(ctx); err != nil {
+		if ctx.Err() == nil {
+			log.Fatal("サーバー起動に失敗しました:", err)
+		}
+		log.Println("サーバーの停止に失敗しました:", err)
 	}
 
-	log.Printf("サーバーをポート %s で起動します", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("サーバー起動に失敗しました:", err)
+	// バックグラウンド処理の終了を待ってから、データベースの接続を閉じる
+	workers.Wait()
+	if err := database.Close(db); err != nil {
+		log.Println("データベース接続の切断に失敗しました:", err)
 	}
+	log.Println("サーバーを停止しました")
 }
 
 func setupRouter(deps *Container) *gin.Engine {
-	ginMode := os.Getenv("GIN_MODE")
-	if ginMode == "" {
-		ginMode = gin.ReleaseMode
-	}
-	gin.SetMode(ginMode)
+	gin.SetMode(deps.Config.Server.GinMode)
 
 	r := gin.New()
+	// X-Forwarded-For は信頼するプロキシからのもののみ使用する（設定の検証で不正な値は起動前に拒否している）
+	if err := r.SetTrustedProxies(deps.Config.Server.TrustedProxies); err != nil {
+		log.Fatal("信頼するプロキシの設定に失敗しました:", err)
+	}
 
-	r.Use(gin.Logger())
+	r.Use(middleware.RequestID(slog.Default()))
+	r.Use(middleware.Tracing())
+	r.Use(middleware.Logger())
+	if deps.Metrics != nil {
+		r.Use(middleware.Metrics(deps.Metrics))
+	}
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
 	r.GET("/health", deps.HealthAPI.Check)
+	r.GET("/livez", deps.HealthAPI.Livez)
+	r.GET("/readyz", deps.HealthAPI.Readyz)
+	if deps.Metrics != nil {
+		r.GET("/metrics", gin.WrapH(deps.Metrics.Handler()))
+	}
 
 	v1 := r.Group("/api/v1")
+	v1.Use(middleware.Language())
 	v1.Use(middleware.ErrorHandler())
+	// 認証の前に適用し、不正なトークンやAPIキーを試すリクエストもクライアントIPごとに制限する
+	if deps.IPRateLimiter != nil {
+		v1.Use(deps.IPRateLimiter)
+	}
+	if deps.Authenticator != nil {
+		v1.Use(deps.Authenticator)
+	}
+	// 認証後に適用し、APIキー・ユーザーごとに制限する
+	if deps.RateLimiter != nil {
+		v1.Use(deps.RateLimiter)
+	}
+
+	// 各ルートに必要な操作の権限（所有者やステータスによる制限はハンドラーで確認する）
+	authorize := deps.Authorize
+	read := authorize(middleware.PermissionContentRead)
+	write := authorize(middleware.PermissionContentWrite)
 
 	contents := v1.Group("/contents")
 	{
-		contents.POST("", deps.ContentAPI.Create)
-		contents.GET("", deps.ContentAPI.List)
-		contents.GET("/:id", deps.ContentAPI.GetByID)
-		contents.PUT("/:id", deps.ContentAPI.Update)
-		contents.DELETE("/:id", deps.ContentAPI.Delete)
+		contents.POST("", write, deps.Idempotency, deps.ContentAPI.Create)
+		contents.GET("", read, deps.ContentAPI.List)
+		contents.GET("/search", read, deps.ContentAPI.Search)
+		contents.GET("/:id", read, deps.ContentAPI.GetByID)
+		contents.PUT("/:id", write, deps.ContentAPI.Update)
+		contents.DELETE("/:id", write, deps.ContentAPI.Delete)
+		contents.POST("/:id/restore", authorize(middleware.PermissionContentWriteAny), deps.ContentAPI.Restore)
+
+		contents.POST("/:id/submit", write, deps.ContentAPI.Submit)
+		contents.POST("/:id/publish", authorize(middleware.PermissionContentPublish), deps.ContentAPI.Publish)
+		contents.POST("/:id/archive", authorize(middleware.PermissionContentPublish), deps.ContentAPI.Archive)
+		contents.POST("/:id/return-to-draft", write, deps.ContentAPI.ReturnToDraft)
+
+		contents.GET("/:id/revisions", read, deps.ContentAPI.ListRevisions)
+		contents.GET("/:id/revisions/diff", read, deps.ContentAPI.DiffRevisions)
+		contents.GET("/:id/revisions/:revision", read, deps.ContentAPI.GetRevision)
+		contents.POST("/:id/revisions/:revision/restore", write, deps.ContentAPI.RestoreRevision)
+	}
+
+	trash := v1.Group("/trash")
+	{
+		trash.GET("/contents", authorize(middleware.PermissionContentWriteAny), deps.ContentAPI.ListTrash)
+		trash.DELETE("/contents/:id", authorize(middleware.PermissionContentPurge), deps.ContentAPI.Purge)
+	}
+
+	contentTypes := v1.Group("/content-types")
+	{
+		contentTypes.GET("", read, deps.ContentTypeAPI.List)
+		contentTypes.POST("", authorize(middleware.PermissionContentTypeManage), deps.ContentTypeAPI.Create)
+		contentTypes.GET("/:name", read, deps.ContentTypeAPI.GetByName)
+		contentTypes.PUT("/:name", authorize(middleware.PermissionContentTypeManage), deps.ContentTypeAPI.Update)
+		contentTypes.DELETE("/:name", authorize(middleware.PermissionContentTypeManage), deps.ContentTypeAPI.Delete)
+	}
+
+	tags := v1.Group("/tags")
+	{
+		tags.GET("", read, deps.TagAPI.List)
+		tags.PUT("/:id", authorize(middleware.PermissionTagManage), deps.TagAPI.Rename)
+		tags.POST("/:id/merge", authorize(middleware.PermissionTagManage), deps.TagAPI.Merge)
+	}
+
+	authors := v1.Group("/authors")
+	{
+		authors.GET("", read, deps.AuthorAPI.List)
+		authors.POST("", authorize(middleware.PermissionAuthorManage), deps.AuthorAPI.Create)
+		authors.GET("/:id", read, deps.AuthorAPI.GetByID)
+		authors.PUT("/:id", authorize(middleware.PermissionAuthorManage), deps.AuthorAPI.Update)
+		authors.DELETE("/:id", authorize(middleware.PermissionAuthorManage), deps.AuthorAPI.Delete)
+		authors.POST("/:id/merge", authorize(middleware.PermissionAuthorManage), deps.AuthorAPI.Merge)
+	}
+
+	apiKeys := v1.Group("/api-keys", authorize(middleware.PermissionAPIKeyManage))
+	{
+		apiKeys.GET("", deps.APIKeyAPI.List)
+		apiKeys.POST("", deps.APIKeyAPI.Create)
+		apiKeys.DELETE("/:id", deps.APIKeyAPI.Revoke)
 	}
 
 	return r
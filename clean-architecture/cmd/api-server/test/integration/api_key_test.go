@@ -0,0 +1,349 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/apikey"
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type APIKeyIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *APIKeyIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *APIKeyIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *APIKeyIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM api_keys")
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM authors")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "山田太郎"}}).Error)
+}
+
+func (suite *APIKeyIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	verifier, err := middleware.NewJWTVerifier(middleware.JWTConfig{HS256Secret: authTestSecret})
+	suite.Require().NoError(err)
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+	apiKeyAPI := apikey.NewAPIKeyAPI(repositories.NewAPIKeyRepository(suite.db))
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+	v1.Use(middleware.Authenticate(verifier, middleware.WithAPIKeys(apiKeyAPI)))
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", middleware.Authorize(middleware.PermissionContentWrite), contentAPI.Create)
+		contents.GET("/:id", middleware.Authorize(middleware.PermissionContentRead), contentAPI.GetByID)
+		contents.PUT("/:id", middleware.Authorize(middleware.PermissionContentWrite), contentAPI.Update)
+	}
+
+	apiKeys := v1.Group("/api-keys", middleware.Authorize(middleware.PermissionAPIKeyManage))
+	{
+		apiKeys.GET("", apiKeyAPI.List)
+		apiKeys.POST("", apiKeyAPI.Create)
+		apiKeys.DELETE("/:id", apiKeyAPI.Revoke)
+	}
+
+	return r
+}
+
+func (suite *APIKeyIntegrationTestSuite) adminToken() string {
+	claims := jwt.MapClaims{
+		"sub":   "admin",
+		"name":  "管理者",
+		"roles": []string{"admin"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(authTestSecret))
+	suite.Require().NoError(err)
+	return token
+}
+
+// do はリクエストを送信する。headers に Authorization または X-API-Key を指定する
+func (suite *APIKeyIntegrationTestSuite) do(method, path string, headers map[string]string, body interface{}) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, err := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *APIKeyIntegrationTestSuite) asAdmin() map[string]string {
+	return map[string]string{"Authorization": "Bearer " + suite.adminToken()}
+}
+
+func (suite *APIKeyIntegrationTestSuite) withAPIKey(key string) map[string]string {
+	return map[string]string{"X-API-Key": key}
+}
+
+// createKey は管理者としてAPIキーを発行する
+func (suite *APIKeyIntegrationTestSuite) createKey(name string, scopes ...string) apikey.CreateAPIKeyResponse {
+	resp := suite.do(http.MethodPost, "/api/v1/api-keys", suite.asAdmin(), map[string]interface{}{
+		"name":   name,
+		"scopes": scopes,
+	})
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var response apikey.CreateAPIKeyResponse
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func (suite *APIKeyIntegrationTestSuite) contentRequest() map[string]string {
+	return map[string]string{
+		"title":        "テストタイトル",
+		"body":         "テスト本文",
+		"content_type": "article",
+		"author":       "山田太郎",
+	}
+}
+
+func (suite *APIKeyIntegrationTestSuite) TestManage() {
+	suite.Run("発行時のみ平文のキーを返し、一覧には含まない", func() {
+		// When
+		created := suite.createKey("取り込みバッチ", "contents:read", "contents:write")
+
+		// Then
+		assert.NotEmpty(suite.T(), created.Key)
+		assert.Equal(suite.T(), "取り込みバッチ", created.Name)
+		// 表示名ではなく識別子を記録する
+		assert.Equal(suite.T(), "admin", created.CreatedBy)
+		assert.Equal(suite.T(), []string{"contents:read", "contents:write"}, created.Scopes)
+
+		resp := suite.do(http.MethodGet, "/api/v1/api-keys", suite.asAdmin(), nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var raw map[string][]map[string]interface{}
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&raw))
+		suite.Require().Len(raw["api_keys"], 1)
+		assert.NotContains(suite.T(), raw["api_keys"][0], "key")
+		assert.NotContains(suite.T(), raw["api_keys"][0], "key_hash")
+		assert.Equal(suite.T(), created.Prefix, raw["api_keys"][0]["prefix"])
+	})
+
+	suite.Run("未定義のスコープは400エラー", func() {
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/api-keys", suite.asAdmin(), map[string]interface{}{
+			"name":   "取り込みバッチ",
+			"scopes": []string{"contents:admin"},
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("APIキーではAPIキーを管理できず403エラー", func() {
+		// Given
+		created := suite.createKey("取り込みバッチ", "contents:write")
+
+		// When
+		resp := suite.do(http.MethodGet, "/api/v1/api-keys", suite.withAPIKey(created.Key), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("失効済みのキーを再度失効すると409エラー", func() {
+		// Given
+		created := suite.createKey("取り込みバッチ", "contents:read")
+		path := fmt.Sprintf("/api/v1/api-keys/%d", created.ID)
+		first := suite.do(http.MethodDelete, path, suite.asAdmin(), nil)
+		defer first.Body.Close()
+
+		// When
+		second := suite.do(http.MethodDelete, path, suite.asAdmin(), nil)
+		defer second.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNoContent, first.StatusCode)
+		assert.Equal(suite.T(), http.StatusConflict, second.StatusCode)
+	})
+}
+
+func (suite *APIKeyIntegrationTestSuite) TestAuthenticate() {
+	suite.Run("書き込みスコープのキーでコンテンツを作成でき、キーの識別子が所有者になる", func() {
+		// Given
+		created := suite.createKey("取り込みバッチ", "contents:write")
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.withAPIKey(created.Key), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "山田太郎", response.Author)
+
+		var stored entities.Content
+		suite.Require().NoError(suite.db.First(&stored, response.ID).Error)
+		assert.Equal(suite.T(), fmt.Sprintf("api_key:%d", created.ID), stored.OwnerSubject)
+
+		var key entities.APIKey
+		suite.Require().NoError(suite.db.First(&key, created.ID).Error)
+		assert.NotNil(suite.T(), key.LastUsedAt)
+	})
+
+	suite.Run("作成者と同じ名前のキーでもその作成者のコンテンツは変更できず403エラー", func() {
+		// Given
+		c, _ := entities.NewContent("テストタイトル", "テスト本文", "article", "山田太郎", entities.WithOwner("user-1"), entities.RegisterAuthor())
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+		created := suite.createKey("山田太郎", "contents:write")
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.withAPIKey(created.Key), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("読み取りスコープのキーでは作成できず403エラー", func() {
+		// Given
+		created := suite.createKey("CI", "contents:read")
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.withAPIKey(created.Key), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("失効したキーは401エラー", func() {
+		// Given
+		created := suite.createKey("取り込みバッチ", "contents:write")
+		revokeResp := suite.do(http.MethodDelete, fmt.Sprintf("/api/v1/api-keys/%d", created.ID), suite.asAdmin(), nil)
+		defer revokeResp.Body.Close()
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.withAPIKey(created.Key), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), `Bearer realm="api", error="invalid_token"`, resp.Header.Get("WWW-Authenticate"))
+	})
+
+	suite.Run("有効期限切れのキーは401エラー", func() {
+		// Given
+		created := suite.createKey("取り込みバッチ", "contents:write")
+		suite.db.Model(&entities.APIKey{}).Where("id = ?", created.ID).Update("expires_at", time.Now().Add(-time.Minute))
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.withAPIKey(created.Key), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	suite.Run("存在しないキーは401エラー", func() {
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.withAPIKey("gas_unknown"), suite.contentRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestAPIKeyIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyIntegrationTestSuite))
+}
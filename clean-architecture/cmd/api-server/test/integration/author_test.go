@@ -0,0 +1,397 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/author"
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type AuthorIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *AuthorIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *AuthorIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *AuthorIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+}
+
+func (suite *AuthorIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+	authorAPI := author.NewAuthorAPI(repositories.NewAuthorRepository(suite.db))
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.GET("/:id", contentAPI.GetByID)
+		contents.DELETE("/:id", contentAPI.Delete)
+	}
+
+	authors := v1.Group("/authors")
+	{
+		authors.GET("", authorAPI.List)
+		authors.POST("", authorAPI.Create)
+		authors.GET("/:id", authorAPI.GetByID)
+		authors.PUT("/:id", authorAPI.Update)
+		authors.DELETE("/:id", authorAPI.Delete)
+		authors.POST("/:id/merge", authorAPI.Merge)
+	}
+
+	return r
+}
+
+func (suite *AuthorIntegrationTestSuite) send(method, path string, body interface{}) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *AuthorIntegrationTestSuite) createContent(title, authorName string) *entities.Content {
+	c, _ := entities.NewContent(title, "本文", "article", authorName, entities.RegisterAuthor())
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+	return c
+}
+
+func (suite *AuthorIntegrationTestSuite) findAuthor(name string) *entities.Author {
+	var a entities.Author
+	suite.Require().NoError(suite.db.Where("name = ?", name).First(&a).Error)
+	return &a
+}
+
+// getContent はコンテンツを取得する。認証なしでは公開中のコンテンツのみ取得できるため、先に公開中にする
+func (suite *AuthorIntegrationTestSuite) getContent(id uint) *entities.Content {
+	suite.Require().NoError(suite.db.Model(&entities.Content{}).Where("id = ?", id).Update("status", entities.StatusPublished).Error)
+
+	resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", id), nil)
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var c entities.Content
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&c))
+	return &c
+}
+
+func (suite *AuthorIntegrationTestSuite) TestContentAuthor() {
+	suite.Run("コンテンツのレスポンスに作成者の概要が含まれる", func() {
+		// Given
+		registered, _ := entities.NewAuthor("運営チーム", "")
+		suite.Require().NoError(suite.db.Create(registered).Error)
+
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]string{
+			"title":        "タイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author":       "運営チーム",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var created entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+
+		found := suite.getContent(created.ID)
+		suite.Require().NotNil(found.AuthorSummary)
+		assert.Equal(suite.T(), suite.findAuthor("運営チーム").ID, found.AuthorSummary.ID)
+		assert.Equal(suite.T(), "運営チーム", found.AuthorSummary.Name)
+	})
+
+	suite.Run("作成者IDで作成者を指定できる", func() {
+		// Given
+		registered, _ := entities.NewAuthor("運営チーム", "")
+		suite.Require().NoError(suite.db.Create(registered).Error)
+
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]interface{}{
+			"title":        "タイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author_id":    registered.ID,
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var created entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+		assert.Equal(suite.T(), "運営チーム", created.Author)
+		suite.Require().NotNil(created.AuthorSummary)
+		assert.Equal(suite.T(), registered.ID, created.AuthorSummary.ID)
+	})
+
+	suite.Run("登録されていない作成者名は400エラーで作成者も作成されない", func() {
+		// Given
+		registered, _ := entities.NewAuthor("運営チーム", "")
+		suite.Require().NoError(suite.db.Create(registered).Error)
+
+		// When: 作成者名の入力を誤る
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]string{
+			"title":        "タイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author":       "運営チ-ム",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+
+		var count int64
+		suite.db.Model(&entities.Author{}).Count(&count)
+		assert.Equal(suite.T(), int64(1), count)
+	})
+
+	suite.Run("存在しない作成者IDは400エラー", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]interface{}{
+			"title":        "タイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author_id":    999999,
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *AuthorIntegrationTestSuite) TestAuthors() {
+	suite.Run("作成者を作成できる", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/authors", map[string]string{
+			"name":  "運営チーム",
+			"email": "ops@example.com",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Author
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.NotZero(suite.T(), response.ID)
+		assert.Equal(suite.T(), "ops@example.com", response.Email)
+	})
+
+	suite.Run("同名の作成者の作成は409エラー", func() {
+		// Given
+		suite.createContent("記事1", "運営チーム")
+
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/authors", map[string]string{"name": "運営チーム"})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("不正なメールアドレスは400エラー", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/authors", map[string]string{
+			"name":  "運営チーム",
+			"email": "ops",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("コンテンツ件数付きで作成者一覧を取得できる", func() {
+		// Given
+		suite.createContent("記事1", "運営チーム")
+		suite.createContent("記事2", "運営チーム")
+
+		// When
+		resp := suite.send(http.MethodGet, "/api/v1/authors", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response author.ListAuthorsResponse
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		suite.Require().Len(response.Authors, 1)
+		assert.Equal(suite.T(), "運営チーム", response.Authors[0].Name)
+		assert.Equal(suite.T(), int64(2), response.Authors[0].ContentCount)
+	})
+
+	suite.Run("作成者名を変更するとコンテンツの作成者名も変わる", func() {
+		// Given
+		created := suite.createContent("記事1", "運営チーム")
+		target := suite.findAuthor("運営チーム")
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/authors/%d", target.ID), map[string]string{"name": "運営部"})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		found := suite.getContent(created.ID)
+		assert.Equal(suite.T(), "運営部", found.Author)
+		assert.Equal(suite.T(), "運営部", found.AuthorSummary.Name)
+	})
+
+	suite.Run("存在しない作成者の取得は404エラー", func() {
+		// When
+		resp := suite.send(http.MethodGet, "/api/v1/authors/999999", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("コンテンツから参照されている作成者の削除は409エラー", func() {
+		// Given
+		created := suite.createContent("記事1", "運営チーム")
+		suite.send(http.MethodDelete, fmt.Sprintf("/api/v1/contents/%d", created.ID), nil).Body.Close()
+		target := suite.findAuthor("運営チーム")
+
+		// When
+		resp := suite.send(http.MethodDelete, fmt.Sprintf("/api/v1/authors/%d", target.ID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("参照されていない作成者は削除できる", func() {
+		// Given
+		unused, _ := entities.NewAuthor("運営チーム", "")
+		suite.Require().NoError(suite.db.Create(unused).Error)
+
+		// When
+		resp := suite.send(http.MethodDelete, fmt.Sprintf("/api/v1/authors/%d", unused.ID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+	})
+
+	suite.Run("作成者をマージできる", func() {
+		// Given
+		created := suite.createContent("記事1", "運営チ-ム")
+		suite.createContent("記事2", "運営チーム")
+		source, target := suite.findAuthor("運営チ-ム"), suite.findAuthor("運営チーム")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/authors/%d/merge", source.ID), map[string]uint{"target_id": target.ID})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		found := suite.getContent(created.ID)
+		assert.Equal(suite.T(), "運営チーム", found.Author)
+		assert.Equal(suite.T(), target.ID, found.AuthorSummary.ID)
+	})
+
+	suite.Run("同じ作成者同士のマージは400エラー", func() {
+		// Given
+		suite.createContent("記事1", "運営チーム")
+		target := suite.findAuthor("運営チーム")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/authors/%d/merge", target.ID), map[string]uint{"target_id": target.ID})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAuthorIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorIntegrationTestSuite))
+}
@@ -0,0 +1,372 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const authTestSecret = "test-secret"
+
+type ContentAuthIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+	rsaKey     *rsa.PrivateKey
+}
+
+func (suite *ContentAuthIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	// RS256の署名鍵を生成
+	suite.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentAuthIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentAuthIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentAuthIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	verifier, err := middleware.NewJWTVerifier(middleware.JWTConfig{
+		HS256Secret: authTestSecret,
+		JWKSFile:    suite.writeJWKS(),
+		Issuer:      "test-issuer",
+	})
+	suite.Require().NoError(err)
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+	v1.Use(middleware.Authenticate(verifier))
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.PUT("/:id", contentAPI.Update)
+		contents.GET("/:id/revisions", contentAPI.ListRevisions)
+	}
+
+	return r
+}
+
+// writeJWKS はRS256の公開鍵を鍵ID "test-key" のJWKSとして一時ファイルに書き出す
+func (suite *ContentAuthIntegrationTestSuite) writeJWKS() string {
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(suite.rsaKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(suite.rsaKey.E)).Bytes()),
+		}},
+	}
+	data, err := json.Marshal(jwks)
+	suite.Require().NoError(err)
+
+	path := filepath.Join(suite.T().TempDir(), "jwks.json")
+	suite.Require().NoError(os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func (suite *ContentAuthIntegrationTestSuite) claims(subject, name string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":  subject,
+		"name": name,
+		"iss":  "test-issuer",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (suite *ContentAuthIntegrationTestSuite) signHS256(claims jwt.MapClaims, secret string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	suite.Require().NoError(err)
+	return token
+}
+
+func (suite *ContentAuthIntegrationTestSuite) signRS256(claims jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(suite.rsaKey)
+	suite.Require().NoError(err)
+	return signed
+}
+
+func (suite *ContentAuthIntegrationTestSuite) do(method, path, token string, body map[string]string) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, err := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+// detail はエラーレスポンスの detail を返す
+func (suite *ContentAuthIntegrationTestSuite) detail(resp *http.Response) interface{} {
+	var response map[string]interface{}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	return response["detail"]
+}
+
+func (suite *ContentAuthIntegrationTestSuite) createRequest() map[string]string {
+	return map[string]string{
+		"title":        "テストタイトル",
+		"body":         "テスト本文",
+		"content_type": "article",
+		"author":       "なりすまし",
+	}
+}
+
+func (suite *ContentAuthIntegrationTestSuite) TestUnauthorized() {
+	suite.Run("トークンがない場合は401エラー", func() {
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", "", suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), `Bearer realm="api"`, resp.Header.Get("WWW-Authenticate"))
+
+		var count int64
+		suite.db.Model(&entities.Content{}).Count(&count)
+		assert.Equal(suite.T(), int64(0), count)
+	})
+
+	suite.Run("署名が不正な場合は401エラー", func() {
+		// Given
+		token := suite.signHS256(suite.claims("user-1", "山田太郎"), "wrong-secret")
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), `Bearer realm="api", error="invalid_token"`, resp.Header.Get("WWW-Authenticate"))
+		assert.Equal(suite.T(), "トークンの署名が不正です", suite.detail(resp))
+	})
+
+	suite.Run("有効期限切れの場合は401エラー", func() {
+		// Given
+		claims := suite.claims("user-1", "山田太郎")
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		token := suite.signHS256(claims, authTestSecret)
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), "トークンの有効期限が切れています", suite.detail(resp))
+	})
+
+	suite.Run("発行者が異なる場合は401エラー", func() {
+		// Given
+		claims := suite.claims("user-1", "山田太郎")
+		claims["iss"] = "other-issuer"
+		token := suite.signHS256(claims, authTestSecret)
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then: 検証の詳細は返さない
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Nil(suite.T(), suite.detail(resp))
+	})
+
+	suite.Run("形式が不正な場合は401エラー", func() {
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", "not-a-jwt", suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), "トークンの形式が正しくありません", suite.detail(resp))
+	})
+
+	suite.Run("JWKSにない鍵IDの場合は401エラー", func() {
+		// Given
+		token := suite.signRS256(suite.claims("user-1", "山田太郎"), "unknown-key")
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(suite.T(), "JWTの鍵IDに対応する検証鍵がありません", suite.detail(resp))
+	})
+}
+
+func (suite *ContentAuthIntegrationTestSuite) TestAuthor() {
+	suite.Run("HS256のトークンのプリンシパルが作成者になる", func() {
+		// Given
+		token := suite.signHS256(suite.claims("user-1", "山田太郎"), authTestSecret)
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "山田太郎", response.Author)
+	})
+
+	suite.Run("RS256のトークンのプリンシパルが作成者になる", func() {
+		// Given
+		token := suite.signRS256(suite.claims("user-2", "佐藤花子"), "test-key")
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "佐藤花子", response.Author)
+	})
+
+	suite.Run("nameクレームがない場合はsubが作成者になる", func() {
+		// Given
+		token := suite.signHS256(suite.claims("user-3", ""), authTestSecret)
+
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", token, suite.createRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "user-3", response.Author)
+	})
+
+	suite.Run("更新時は作成者を維持し、編集者としてプリンシパルを記録する", func() {
+		// Given
+		c, _ := entities.NewContent("元のタイトル", "本文", "article", "山田太郎", entities.RegisterAuthor())
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+		claims := suite.claims("user-2", "佐藤花子")
+		claims["roles"] = []string{"editor"}
+		token := suite.signHS256(claims, authTestSecret)
+		reqBody := suite.createRequest()
+		reqBody["title"] = "更新後のタイトル"
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), token, reqBody)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "山田太郎", response.Author)
+
+		var revision entities.ContentRevision
+		suite.Require().NoError(suite.db.Where("content_id = ?", c.ID).Order("revision_number DESC").First(&revision).Error)
+		assert.Equal(suite.T(), "佐藤花子", revision.Editor)
+	})
+}
+
+func TestContentAuthIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentAuthIntegrationTestSuite))
+}
@@ -0,0 +1,453 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentAuthorizationIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	verifier, err := middleware.NewJWTVerifier(middleware.JWTConfig{HS256Secret: authTestSecret})
+	suite.Require().NoError(err)
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+	v1.Use(middleware.Authenticate(verifier))
+
+	read := middleware.Authorize(middleware.PermissionContentRead)
+	write := middleware.Authorize(middleware.PermissionContentWrite)
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", write, contentAPI.Create)
+		contents.GET("", read, contentAPI.List)
+		contents.GET("/:id", read, contentAPI.GetByID)
+		contents.PUT("/:id", write, contentAPI.Update)
+		contents.DELETE("/:id", write, contentAPI.Delete)
+		contents.POST("/:id/submit", write, contentAPI.Submit)
+		contents.POST("/:id/publish", middleware.Authorize(middleware.PermissionContentPublish), contentAPI.Publish)
+		contents.POST("/:id/return-to-draft", write, contentAPI.ReturnToDraft)
+		contents.GET("/:id/revisions", read, contentAPI.ListRevisions)
+	}
+
+	trash := v1.Group("/trash")
+	{
+		trash.DELETE("/contents/:id", middleware.Authorize(middleware.PermissionContentPurge), contentAPI.Purge)
+	}
+
+	return r
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) token(name string, roles ...string) string {
+	return suite.tokenFor(name, name, roles...)
+}
+
+// tokenFor は識別子と表示名が異なるプリンシパルのトークンを作成する
+func (suite *ContentAuthorizationIntegrationTestSuite) tokenFor(subject, name string, roles ...string) string {
+	claims := jwt.MapClaims{
+		"sub":   subject,
+		"name":  name,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(authTestSecret))
+	suite.Require().NoError(err)
+	return token
+}
+
+// createContent は作成者名と同じ識別子のプリンシパルが所有するコンテンツを作成する
+func (suite *ContentAuthorizationIntegrationTestSuite) createContent(author string) *entities.Content {
+	c, _ := entities.NewContent("テストタイトル", "テスト本文", "article", author, entities.WithOwner(author), entities.RegisterAuthor())
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+	return c
+}
+
+// createContentWithStatus は指定したステータスのコンテンツを作成する
+func (suite *ContentAuthorizationIntegrationTestSuite) createContentWithStatus(author string, status entities.ContentStatus) *entities.Content {
+	c, _ := entities.NewContent("テストタイトル", "テスト本文", "article", author, entities.WithOwner(author), entities.RegisterAuthor())
+	c.Status = status
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+	return c
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) do(method, path, token string, body map[string]string) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, err := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) updateRequest() map[string]string {
+	return map[string]string{
+		"title":        "更新後のタイトル",
+		"body":         "更新後の本文",
+		"content_type": "article",
+	}
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) TestRoutePolicy() {
+	suite.Run("viewerはコンテンツを参照できる", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.token("閲覧者", "viewer"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("viewerはコンテンツを作成できず403エラー", func() {
+		// When
+		resp := suite.do(http.MethodPost, "/api/v1/contents", suite.token("閲覧者", "viewer"), map[string]string{
+			"title":        "テストタイトル",
+			"body":         "テスト本文",
+			"content_type": "article",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+
+		var response map[string]interface{}
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), float64(http.StatusForbidden), response["status"])
+		assert.Equal(suite.T(), "forbidden", response["code"])
+		assert.Equal(suite.T(), "この操作を行う権限がありません", response["title"])
+	})
+
+	suite.Run("ロールがない場合は参照もできず403エラー", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.token("山田太郎", "unknown"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("writerは公開できず403エラー", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/publish", c.ID), suite.token("山田太郎", "writer"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("editorはゴミ箱のコンテンツを完全削除できず、adminは完全削除できる", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Delete(context.Background(), c))
+		path := fmt.Sprintf("/api/v1/trash/contents/%d", c.ID)
+
+		// When
+		editorResp := suite.do(http.MethodDelete, path, suite.token("編集者", "editor"), nil)
+		defer editorResp.Body.Close()
+		adminResp := suite.do(http.MethodDelete, path, suite.token("管理者", "admin"), nil)
+		defer adminResp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, editorResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusNoContent, adminResp.StatusCode)
+	})
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) TestOwnership() {
+	suite.Run("writerは自分のコンテンツを更新できる", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.token("山田太郎", "writer"), suite.updateRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("writerは他人のコンテンツを更新・削除・レビュー依頼できず403エラー", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+		token := suite.token("佐藤花子", "writer")
+
+		// When
+		updateResp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), token, suite.updateRequest())
+		defer updateResp.Body.Close()
+		deleteResp := suite.do(http.MethodDelete, fmt.Sprintf("/api/v1/contents/%d", c.ID), token, nil)
+		defer deleteResp.Body.Close()
+		submitResp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/submit", c.ID), token, nil)
+		defer submitResp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, updateResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusForbidden, deleteResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusForbidden, submitResp.StatusCode)
+
+		var stored entities.Content
+		suite.Require().NoError(suite.db.First(&stored, c.ID).Error)
+		assert.Equal(suite.T(), "テストタイトル", stored.Title)
+		assert.Equal(suite.T(), entities.StatusDraft, stored.Status)
+	})
+
+	suite.Run("作成者と同じ名前でも識別子が異なるwriterは更新できず403エラー", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.tokenFor("other-user", "山田太郎", "writer"), suite.updateRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode)
+	})
+
+	suite.Run("作成したコンテンツは表示名を変えても識別子で所有者と判定される", func() {
+		// Given
+		createResp := suite.do(http.MethodPost, "/api/v1/contents", suite.tokenFor("user-1", "山田太郎", "writer"), map[string]string{
+			"title":        "テストタイトル",
+			"body":         "テスト本文",
+			"content_type": "article",
+		})
+		defer createResp.Body.Close()
+		suite.Require().Equal(http.StatusCreated, createResp.StatusCode)
+		var created entities.Content
+		suite.Require().NoError(json.NewDecoder(createResp.Body).Decode(&created))
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", created.ID), suite.tokenFor("user-1", "山田花子", "writer"), suite.updateRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var stored entities.Content
+		suite.Require().NoError(suite.db.First(&stored, created.ID).Error)
+		assert.Equal(suite.T(), "user-1", stored.OwnerSubject)
+	})
+
+	suite.Run("editorは他人のコンテンツを更新できる", func() {
+		// Given
+		c := suite.createContent("山田太郎")
+
+		// When
+		resp := suite.do(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.token("編集者", "editor"), suite.updateRequest())
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), "山田太郎", response.Author)
+	})
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) TestReturnToDraft() {
+	suite.Run("writerはレビュー待ちの自分のコンテンツを下書きに戻せる", func() {
+		// Given
+		c := suite.createContentWithStatus("山田太郎", entities.StatusInReview)
+
+		// When
+		resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/return-to-draft", c.ID), suite.token("山田太郎", "writer"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("writerは公開中・アーカイブ済みの自分のコンテンツを下書きに戻せず403エラー", func() {
+		for _, status := range []entities.ContentStatus{entities.StatusPublished, entities.StatusArchived} {
+			// Given
+			c := suite.createContentWithStatus("山田太郎", status)
+
+			// When
+			resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/return-to-draft", c.ID), suite.token("山田太郎", "writer"), nil)
+			defer resp.Body.Close()
+
+			// Then
+			assert.Equal(suite.T(), http.StatusForbidden, resp.StatusCode, status)
+
+			var stored entities.Content
+			suite.Require().NoError(suite.db.First(&stored, c.ID).Error)
+			assert.Equal(suite.T(), status, stored.Status)
+		}
+	})
+
+	suite.Run("editorは公開中のコンテンツを下書きに戻せる", func() {
+		// Given
+		c := suite.createContentWithStatus("山田太郎", entities.StatusPublished)
+
+		// When
+		resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/return-to-draft", c.ID), suite.token("編集者", "editor"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+}
+
+func (suite *ContentAuthorizationIntegrationTestSuite) TestReadPolicy() {
+	suite.Run("公開中でないコンテンツは所有者とeditorのみ参照でき、他のプリンシパルには404を返す", func() {
+		// Given
+		c := suite.createContentWithStatus("山田太郎", entities.StatusDraft)
+		path := fmt.Sprintf("/api/v1/contents/%d", c.ID)
+
+		// When
+		ownerResp := suite.do(http.MethodGet, path, suite.token("山田太郎", "writer"), nil)
+		defer ownerResp.Body.Close()
+		editorResp := suite.do(http.MethodGet, path, suite.token("編集者", "editor"), nil)
+		defer editorResp.Body.Close()
+		viewerResp := suite.do(http.MethodGet, path, suite.token("閲覧者", "viewer"), nil)
+		defer viewerResp.Body.Close()
+		revisionsResp := suite.do(http.MethodGet, path+"/revisions", suite.token("佐藤花子", "writer"), nil)
+		defer revisionsResp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, ownerResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusOK, editorResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusNotFound, viewerResp.StatusCode)
+		assert.Equal(suite.T(), http.StatusNotFound, revisionsResp.StatusCode)
+	})
+
+	suite.Run("公開中のコンテンツは誰でも参照できる", func() {
+		// Given
+		c := suite.createContentWithStatus("山田太郎", entities.StatusPublished)
+
+		// When
+		resp := suite.do(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", c.ID), suite.token("閲覧者", "viewer"), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+	})
+
+	suite.Run("下書きを指定した一覧は自分が所有するものだけを返す", func() {
+		// Given
+		own := suite.createContentWithStatus("山田太郎", entities.StatusDraft)
+		suite.createContentWithStatus("佐藤花子", entities.StatusDraft)
+
+		// When
+		writerResp := suite.do(http.MethodGet, "/api/v1/contents?status=draft", suite.token("山田太郎", "writer"), nil)
+		defer writerResp.Body.Close()
+		editorResp := suite.do(http.MethodGet, "/api/v1/contents?status=draft", suite.token("編集者", "editor"), nil)
+		defer editorResp.Body.Close()
+
+		// Then
+		var writerList, editorList content.ListContentsResponse
+		suite.Require().NoError(json.NewDecoder(writerResp.Body).Decode(&writerList))
+		suite.Require().NoError(json.NewDecoder(editorResp.Body).Decode(&editorList))
+		suite.Require().Len(writerList.Contents, 1)
+		assert.Equal(suite.T(), own.ID, writerList.Contents[0].ID)
+		assert.Len(suite.T(), editorList.Contents, 2)
+	})
+}
+
+func TestContentAuthorizationIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentAuthorizationIntegrationTestSuite))
+}
@@ -14,6 +14,7 @@ import (
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
 	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func (suite *ContentCreateIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -84,7 +85,14 @@ func (suite *ContentCreateIntegrationTestSuite) TearDownSuite() {
 
 func (suite *ContentCreateIntegrationTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM tags")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "テスト作成者"}}).Error)
 }
 
 func (suite *ContentCreateIntegrationTestSuite) setupRouter() *gin.Engine {
@@ -94,7 +102,8 @@ func (suite *ContentCreateIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
 	r.GET("/health", healthAPI.Check)
 
 	// リポジトリとAPIを直接初期化
@@ -102,6 +111,7 @@ func (suite *ContentCreateIntegrationTestSuite) setupRouter() *gin.Engine {
 	contentAPI := content.NewContentAPI(contentRepo)
 
 	v1 := r.Group("/api/v1")
+	v1.Use(middleware.Language())
 	v1.Use(middleware.ErrorHandler())
 
 	contents := v1.Group("/contents")
@@ -166,14 +176,89 @@ func (suite *ContentCreateIntegrationTestSuite) TestCreate() {
 		suite.Require().NoError(err)
 		defer resp.Body.Close()
 
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(suite.T(), "application/problem+json", resp.Header.Get("Content-Type"))
+
+		var response map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
+		assert.Equal(suite.T(), "invalid_request", response["code"])
+		assert.Equal(suite.T(), "/problems/invalid_request", response["type"])
+		assert.Contains(suite.T(), response["title"], "不正なリクエストです")
+
+		fieldErrors, ok := response["errors"].([]interface{})
+		suite.Require().True(ok)
+		suite.Require().Len(fieldErrors, 1)
+		fieldError := fieldErrors[0].(map[string]interface{})
+		assert.Equal(suite.T(), "title", fieldError["field"])
+		assert.Equal(suite.T(), "required", fieldError["rule"])
+	})
+
+	suite.Run("Accept-Languageで指定した言語のメッセージが返される", func() {
+		// Given
+		reqBody := map[string]interface{}{
+			"title":        "",
+			"body":         "テスト本文",
+			"content_type": "article",
+			"tags":         []string{"go", ""},
+		}
+		jsonBytes, _ := json.Marshal(reqBody)
+
+		req, _ := http.NewRequest(http.MethodPost, suite.server.URL+"/api/v1/contents", bytes.NewBuffer(jsonBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9,ja;q=0.8")
+
+		// When
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(suite.T(), "en", resp.Header.Get("Content-Language"))
+
+		var response map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "invalid_request", response["code"])
+		assert.Equal(suite.T(), "Invalid request", response["title"])
+
+		fieldErrors, ok := response["errors"].([]interface{})
+		suite.Require().True(ok)
+		suite.Require().Len(fieldErrors, 2)
+		assert.Equal(suite.T(), "This field is required", fieldErrors[0].(map[string]interface{})["message"])
+		assert.Equal(suite.T(), "tags[1]", fieldErrors[1].(map[string]interface{})["field"])
+		assert.Equal(suite.T(), "Must be at least 1 character(s)", fieldErrors[1].(map[string]interface{})["message"])
+	})
+
+	suite.Run("ドメインの検証エラーも指定した言語で返される", func() {
+		// Given
+		reqBody := map[string]string{
+			"title":        "テストタイトル",
+			"body":         "テスト本文",
+			"content_type": "invalid_type",
+		}
+		jsonBytes, _ := json.Marshal(reqBody)
+
+		req, _ := http.NewRequest(http.MethodPost, suite.server.URL+"/api/v1/contents", bytes.NewBuffer(jsonBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "en")
+
+		// When
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
 		// Then
 		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
 
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
-		assert.Contains(suite.T(), response["message"], "不正なリクエストです")
+		assert.Equal(suite.T(), "invalid_content", response["code"])
+		assert.Equal(suite.T(), "The specified content type is not registered", response["detail"])
 	})
 
 	suite.Run("bodyが空の場合はバリデーションエラー", func() {
@@ -201,7 +286,7 @@ func (suite *ContentCreateIntegrationTestSuite) TestCreate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
 	})
 
 	suite.Run("content_typeが不正な値の場合はバリデーションエラー", func() {
@@ -229,7 +314,7 @@ func (suite *ContentCreateIntegrationTestSuite) TestCreate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
 	})
 
 	suite.Run("authorが空の場合はバリデーションエラー", func() {
@@ -257,7 +342,7 @@ func (suite *ContentCreateIntegrationTestSuite) TestCreate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
 	})
 
 	suite.Run("JSONが不正な場合はバリデーションエラー", func() {
@@ -14,6 +14,7 @@ import (
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
 	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func (suite *ContentDeleteIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -84,7 +85,10 @@ func (suite *ContentDeleteIntegrationTestSuite) TearDownSuite() {
 
 func (suite *ContentDeleteIntegrationTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
 }
 
 func (suite *ContentDeleteIntegrationTestSuite) setupRouter() *gin.Engine {
@@ -94,7 +98,8 @@ func (suite *ContentDeleteIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
 	r.GET("/health", healthAPI.Check)
 
 	// リポジトリとAPIを直接初期化
@@ -121,6 +126,7 @@ func (suite *ContentDeleteIntegrationTestSuite) createContent(title, body, conte
 		Body:        body,
 		ContentType: contentType,
 		Author:      author,
+		Status:      entities.StatusPublished,
 	}
 	err := suite.db.Create(content).Error
 	suite.Require().NoError(err)
@@ -155,6 +161,29 @@ func (suite *ContentDeleteIntegrationTestSuite) TestDelete() {
 		assert.Equal(suite.T(), http.StatusNotFound, getResp.StatusCode)
 	})
 
+	suite.Run("If-Matchが一致しない場合は412エラーで削除されない", func() {
+		// Given
+		contentID := suite.createContent("削除対象", "削除対象本文", "article", "作成者")
+
+		// When
+		req, _ := http.NewRequest(
+			http.MethodDelete,
+			fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, contentID),
+			nil,
+		)
+		req.Header.Set("If-Match", fmt.Sprintf(`"%d-99"`, contentID))
+
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusPreconditionFailed, resp.StatusCode)
+
+		var stored entities.Content
+		assert.NoError(suite.T(), suite.db.First(&stored, contentID).Error)
+	})
+
 	suite.Run("存在しないIDでは404エラー", func() {
 		// When
 		req, _ := http.NewRequest(
@@ -173,8 +202,9 @@ func (suite *ContentDeleteIntegrationTestSuite) TestDelete() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusNotFound), response["code"])
-		assert.Contains(suite.T(), response["message"], "指定されたコンテンツが見つかりません")
+		assert.Equal(suite.T(), float64(http.StatusNotFound), response["status"])
+		assert.Equal(suite.T(), "content_not_found", response["code"])
+		assert.Contains(suite.T(), response["title"], "指定されたコンテンツが見つかりません")
 	})
 
 	suite.Run("不正なID形式では400エラー", func() {
@@ -195,8 +225,9 @@ func (suite *ContentDeleteIntegrationTestSuite) TestDelete() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
-		assert.Contains(suite.T(), response["message"], "不正なIDです")
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
+		assert.Equal(suite.T(), "invalid_id", response["code"])
+		assert.Contains(suite.T(), response["title"], "不正なIDです")
 	})
 
 	suite.Run("負のIDでは400エラー", func() {
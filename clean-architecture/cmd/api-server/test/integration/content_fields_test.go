@@ -0,0 +1,264 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/api/contenttype"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentFieldsIntegrationTestSuite struct {
+	suite.Suite
+	container      *postgres.PostgresContainer
+	db             *gorm.DB
+	server         *httptest.Server
+	httpClient     *http.Client
+	contentTypeAPI *contenttype.ContentTypeAPI
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.ContentType{}, &entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップとイベント用コンテンツタイプの登録
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM content_types")
+	suite.Require().NoError(suite.db.Create(entities.DefaultContentTypes()).Error)
+	suite.Require().NoError(suite.contentTypeAPI.Reload(context.Background()))
+
+	resp := suite.send(http.MethodPost, "/api/v1/content-types", map[string]interface{}{
+		"name": "event",
+		"fields_schema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"event_date": map[string]interface{}{"type": "string", "format": "date"},
+				"capacity":   map[string]interface{}{"type": "integer", "minimum": 1},
+			},
+			"required": []string{"event_date"},
+		},
+	})
+	resp.Body.Close()
+	suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "作成者"}}).Error)
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	registry := entities.NewDefaultContentTypeRegistry()
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db), content.WithContentTypes(registry))
+	suite.contentTypeAPI = contenttype.NewContentTypeAPI(repositories.NewContentTypeRepository(suite.db), registry)
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.GET("", contentAPI.List)
+		contents.PUT("/:id", contentAPI.Update)
+	}
+
+	v1.POST("/content-types", suite.contentTypeAPI.Create)
+
+	return r
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) send(method, path string, body interface{}) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+// createEvent はカスタムフィールド付きの公開中イベントを作成する
+func (suite *ContentFieldsIntegrationTestSuite) createEvent(title string, fields map[string]interface{}) *entities.Content {
+	c, err := entities.NewContent(title, "本文", "event", "作成者", entities.WithFields(fields), entities.RegisterAuthor())
+	suite.Require().NoError(err)
+	suite.Require().NoError(c.SubmitForReview())
+	suite.Require().NoError(c.Publish())
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+	return c
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) list(rawQuery string) (*http.Response, []string) {
+	resp := suite.send(http.MethodGet, "/api/v1/contents?"+rawQuery, nil)
+	defer resp.Body.Close()
+
+	var response content.ListContentsResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	titles := make([]string, 0, len(response.Contents))
+	for _, c := range response.Contents {
+		titles = append(titles, c.Title)
+	}
+	return resp, titles
+}
+
+func (suite *ContentFieldsIntegrationTestSuite) TestCustomFields() {
+	suite.Run("スキーマに一致するカスタムフィールドで作成できる", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]interface{}{
+			"title":        "勉強会",
+			"body":         "本文",
+			"content_type": "event",
+			"author":       "作成者",
+			"fields":       map[string]interface{}{"event_date": "2026-01-15", "capacity": 30},
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "2026-01-15", response.Fields["event_date"])
+		assert.Equal(suite.T(), float64(30), response.Fields["capacity"])
+	})
+
+	suite.Run("スキーマに一致しないカスタムフィールドは400エラー", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]interface{}{
+			"title":        "勉強会",
+			"body":         "本文",
+			"content_type": "event",
+			"author":       "作成者",
+			"fields":       map[string]interface{}{"event_date": "2026-01-15", "capacity": 0},
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("更新時にカスタムフィールドを省略すると既存の値が維持される", func() {
+		// Given
+		created := suite.createEvent("勉強会", map[string]interface{}{"event_date": "2026-01-15"})
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", created.ID), map[string]interface{}{
+			"title":        "新しいタイトル",
+			"body":         "本文",
+			"content_type": "event",
+			"author":       "作成者",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "2026-01-15", response.Fields["event_date"])
+	})
+
+	suite.Run("カスタムフィールドの値で一覧を絞り込める", func() {
+		// Given
+		suite.createEvent("1月の勉強会", map[string]interface{}{"event_date": "2026-01-15", "capacity": 30})
+		suite.createEvent("3月の勉強会", map[string]interface{}{"event_date": "2026-03-01", "capacity": 100})
+
+		// When
+		resp, titles := suite.list("fields.event_date>=2026-02-01")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), []string{"3月の勉強会"}, titles)
+
+		// エンコードされた演算子と複数条件
+		_, titles = suite.list("fields.capacity" + url.QueryEscape("<") + "=50&fields.event_date=2026-01-15")
+		assert.Equal(suite.T(), []string{"1月の勉強会"}, titles)
+	})
+
+	suite.Run("不正なフィールド名での絞り込みは400エラー", func() {
+		// When
+		resp, _ := suite.list("fields.event-date=2026-01-01")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestContentFieldsIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentFieldsIntegrationTestSuite))
+}
@@ -14,6 +14,7 @@ import (
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
 	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -58,7 +59,7 @@ func (suite *ContentGetIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -84,7 +85,10 @@ func (suite *ContentGetIntegrationTestSuite) TearDownSuite() {
 
 func (suite *ContentGetIntegrationTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
 }
 
 func (suite *ContentGetIntegrationTestSuite) setupRouter() *gin.Engine {
@@ -94,7 +98,8 @@ func (suite *ContentGetIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
 	r.GET("/health", healthAPI.Check)
 
 	// リポジトリとAPIを直接初期化
@@ -120,6 +125,7 @@ func (suite *ContentGetIntegrationTestSuite) TestGetByID() {
 			Body:        "テスト本文",
 			ContentType: "article",
 			Author:      "テスト作成者",
+			Status:      entities.StatusPublished,
 		}
 		err := suite.db.Create(testContent).Error
 		suite.Require().NoError(err)
@@ -144,6 +150,62 @@ func (suite *ContentGetIntegrationTestSuite) TestGetByID() {
 		assert.Equal(suite.T(), "テスト作成者", response.Author)
 	})
 
+	suite.Run("ETagが返りIf-None-Matchが一致すれば304を返す", func() {
+		// Given
+		testContent := &entities.Content{
+			Title:       "テストタイトル",
+			Body:        "テスト本文",
+			ContentType: "article",
+			Author:      "テスト作成者",
+			Status:      entities.StatusPublished,
+		}
+		err := suite.db.Create(testContent).Error
+		suite.Require().NoError(err)
+
+		url := fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, testContent.ID)
+		resp, err := suite.httpClient.Get(url)
+		suite.Require().NoError(err)
+		resp.Body.Close()
+
+		etag := resp.Header.Get("ETag")
+		assert.Equal(suite.T(), fmt.Sprintf(`"%d-1"`, testContent.ID), etag)
+
+		// When
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("If-None-Match", etag)
+		resp, err = suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotModified, resp.StatusCode)
+		assert.Equal(suite.T(), etag, resp.Header.Get("ETag"))
+	})
+
+	suite.Run("認証されていない場合は公開中でないコンテンツは404エラー", func() {
+		for _, status := range []entities.ContentStatus{entities.StatusDraft, entities.StatusInReview, entities.StatusArchived} {
+			// Given
+			testContent := &entities.Content{
+				Title:       "テストタイトル",
+				Body:        "テスト本文",
+				ContentType: "article",
+				Author:      "テスト作成者",
+				Status:      status,
+			}
+			suite.Require().NoError(suite.db.Create(testContent).Error)
+
+			// When
+			resp, err := suite.httpClient.Get(
+				fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, testContent.ID),
+			)
+			suite.Require().NoError(err)
+			resp.Body.Close()
+
+			// Then
+			assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode, status)
+		}
+	})
+
 	suite.Run("存在しないIDでは404エラー", func() {
 		// When
 		resp, err := suite.httpClient.Get(
@@ -158,8 +220,9 @@ func (suite *ContentGetIntegrationTestSuite) TestGetByID() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusNotFound), response["code"])
-		assert.Contains(suite.T(), response["message"], "指定されたコンテンツが見つかりません")
+		assert.Equal(suite.T(), float64(http.StatusNotFound), response["status"])
+		assert.Equal(suite.T(), "content_not_found", response["code"])
+		assert.Contains(suite.T(), response["title"], "指定されたコンテンツが見つかりません")
 	})
 
 	suite.Run("不正なID形式では400エラー", func() {
@@ -176,8 +239,9 @@ func (suite *ContentGetIntegrationTestSuite) TestGetByID() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
-		assert.Contains(suite.T(), response["message"], "不正なIDです")
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
+		assert.Equal(suite.T(), "invalid_id", response["code"])
+		assert.Contains(suite.T(), response["title"], "不正なIDです")
 	})
 
 	suite.Run("負のIDでは400エラー", func() {
@@ -0,0 +1,225 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentIdempotencyIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{}, &entities.IdempotencyKey{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM idempotency_keys")
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "取り込みバッチ"}}).Error)
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+	idempotency := middleware.Idempotency(repositories.NewIdempotencyKeyRepository(suite.db), time.Hour, time.Minute)
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", idempotency, contentAPI.Create)
+	}
+
+	return r
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) create(key, title string) *http.Response {
+	jsonBytes, _ := json.Marshal(map[string]string{
+		"title":        title,
+		"body":         "本文",
+		"content_type": "article",
+		"author":       "取り込みバッチ",
+	})
+	req, _ := http.NewRequest(http.MethodPost, suite.server.URL+"/api/v1/contents", bytes.NewBuffer(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) countContents() int64 {
+	var count int64
+	suite.db.Model(&entities.Content{}).Count(&count)
+	return count
+}
+
+func (suite *ContentIdempotencyIntegrationTestSuite) TestIdempotency() {
+	suite.Run("同じキーで再送すると最初のレスポンスが返され、コンテンツは1件だけ作成される", func() {
+		// Given
+		first := suite.create("import-1", "タイトル")
+		defer first.Body.Close()
+		suite.Require().Equal(http.StatusCreated, first.StatusCode)
+
+		var created entities.Content
+		suite.Require().NoError(json.NewDecoder(first.Body).Decode(&created))
+
+		// When
+		resp := suite.create("import-1", "タイトル")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+		assert.Equal(suite.T(), "true", resp.Header.Get("Idempotent-Replayed"))
+		assert.Equal(suite.T(), first.Header.Get("ETag"), resp.Header.Get("ETag"))
+
+		var replayed entities.Content
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&replayed))
+		assert.Equal(suite.T(), created.ID, replayed.ID)
+		assert.Equal(suite.T(), int64(1), suite.countContents())
+	})
+
+	suite.Run("同じキーで異なるリクエストを送ると422エラー", func() {
+		// Given
+		suite.create("import-1", "タイトル").Body.Close()
+
+		// When
+		resp := suite.create("import-1", "別のタイトル")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Equal(suite.T(), int64(1), suite.countContents())
+	})
+
+	suite.Run("キーを指定しない場合は送るたびに作成される", func() {
+		// When
+		suite.create("", "タイトル").Body.Close()
+		suite.create("", "タイトル").Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), int64(2), suite.countContents())
+	})
+
+	suite.Run("同じキーのリクエストを同時に送ってもコンテンツは1件だけ作成される", func() {
+		// When
+		var wg sync.WaitGroup
+		statuses := make([]int, 5)
+		for i := range statuses {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				resp := suite.create("import-1", "タイトル")
+				resp.Body.Close()
+				statuses[i] = resp.StatusCode
+			}(i)
+		}
+		wg.Wait()
+
+		// Then: 処理中の重複は409、処理後の重複は保存済みのレスポンスが返される
+		for _, status := range statuses {
+			assert.Contains(suite.T(), []int{http.StatusCreated, http.StatusConflict}, status)
+		}
+		assert.Contains(suite.T(), statuses, http.StatusCreated)
+		assert.Equal(suite.T(), int64(1), suite.countContents())
+	})
+
+	suite.Run("不正なキーは400エラー", func() {
+		// When
+		resp := suite.create("invalid key", "タイトル")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(suite.T(), int64(0), suite.countContents())
+	})
+}
+
+func TestContentIdempotencyIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentIdempotencyIntegrationTestSuite))
+}
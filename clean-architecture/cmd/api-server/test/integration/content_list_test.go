@@ -3,8 +3,10 @@ package integration
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
 	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -57,7 +60,7 @@ func (suite *ContentListIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -83,7 +86,10 @@ func (suite *ContentListIntegrationTestSuite) TearDownSuite() {
 
 func (suite *ContentListIntegrationTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
 }
 
 func (suite *ContentListIntegrationTestSuite) setupRouter() *gin.Engine {
@@ -93,7 +99,8 @@ func (suite *ContentListIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
 	r.GET("/health", healthAPI.Check)
 
 	// リポジトリとAPIを直接初期化
@@ -101,6 +108,13 @@ func (suite *ContentListIntegrationTestSuite) setupRouter() *gin.Engine {
 	contentAPI := content.NewContentAPI(contentRepo)
 	v1 := r.Group("/api/v1")
 	v1.Use(middleware.ErrorHandler())
+	// 下書きは認証されていないリクエストには返さないため、ヘッダーで指定された場合は他人のコンテンツも参照できる編集者として扱う
+	v1.Use(func(c *gin.Context) {
+		if user := c.GetHeader(testUserHeader); user != "" {
+			middleware.SetPrincipal(c, &middleware.Principal{Subject: user, Name: user, Roles: []middleware.Role{middleware.RoleEditor}})
+		}
+		c.Next()
+	})
 
 	contents := v1.Group("/contents")
 	{
@@ -112,11 +126,16 @@ func (suite *ContentListIntegrationTestSuite) setupRouter() *gin.Engine {
 }
 
 func (suite *ContentListIntegrationTestSuite) createContent(title, body, contentType, author string) {
+	suite.createContentWithStatus(title, body, contentType, author, entities.StatusPublished)
+}
+
+func (suite *ContentListIntegrationTestSuite) createContentWithStatus(title, body, contentType, author string, status entities.ContentStatus) {
 	content := &entities.Content{
 		Title:       title,
 		Body:        body,
 		ContentType: contentType,
 		Author:      author,
+		Status:      status,
 	}
 	err := suite.db.Create(content).Error
 	suite.Require().NoError(err)
@@ -196,6 +215,88 @@ func (suite *ContentListIntegrationTestSuite) TestList() {
 		assert.Len(suite.T(), contentsList, 2)
 	})
 
+	suite.Run("デフォルトでは公開中のコンテンツのみ取得する", func() {
+		// Given
+		suite.createContent("公開記事", "本文1", "article", "作成者A")
+		suite.createContentWithStatus("下書き記事", "本文2", "article", "作成者A", entities.StatusDraft)
+		suite.createContentWithStatus("アーカイブ記事", "本文3", "article", "作成者A", entities.StatusArchived)
+
+		// When
+		resp, err := suite.httpClient.Get(suite.server.URL + "/api/v1/contents")
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.ListContentsResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		suite.Require().NotNil(response.Total)
+		assert.Equal(suite.T(), int64(1), *response.Total)
+		assert.Equal(suite.T(), "公開記事", response.Contents[0].Title)
+	})
+
+	suite.Run("statusでフィルタリングできる", func() {
+		// Given
+		suite.createContent("公開記事", "本文1", "article", "作成者A")
+		suite.createContentWithStatus("下書き記事1", "本文2", "article", "作成者A", entities.StatusDraft)
+		suite.createContentWithStatus("下書き記事2", "本文3", "blog", "作成者B", entities.StatusDraft)
+
+		// When
+		req, _ := http.NewRequest(http.MethodGet, suite.server.URL+"/api/v1/contents?status=draft", nil)
+		req.Header.Set(testUserHeader, "編集者")
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.ListContentsResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		suite.Require().NotNil(response.Total)
+		assert.Equal(suite.T(), int64(2), *response.Total)
+		for _, c := range response.Contents {
+			assert.Equal(suite.T(), entities.StatusDraft, c.Status)
+		}
+	})
+
+	suite.Run("認証されていない場合はstatusを指定しても公開中のコンテンツのみ取得する", func() {
+		// Given
+		suite.createContent("公開記事", "本文1", "article", "作成者A")
+		suite.createContentWithStatus("下書き記事", "本文2", "article", "作成者A", entities.StatusDraft)
+		suite.createContentWithStatus("アーカイブ記事", "本文3", "article", "作成者A", entities.StatusArchived)
+
+		for _, status := range []string{"draft", "archived"} {
+			// When
+			resp, err := suite.httpClient.Get(suite.server.URL + "/api/v1/contents?status=" + status)
+			suite.Require().NoError(err)
+
+			// Then
+			var response content.ListContentsResponse
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			resp.Body.Close()
+			suite.Require().NoError(err)
+			assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+			suite.Require().Len(response.Contents, 1, status)
+			assert.Equal(suite.T(), "公開記事", response.Contents[0].Title)
+		}
+	})
+
+	suite.Run("不正なstatusでは400エラー", func() {
+		// When
+		resp, err := suite.httpClient.Get(
+			suite.server.URL + "/api/v1/contents?status=invalid",
+		)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
 	suite.Run("ページネーションが機能する", func() {
 		// Given
 		for i := 1; i <= 5; i++ {
@@ -237,7 +338,7 @@ func (suite *ContentListIntegrationTestSuite) TestList() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
 	})
 
 	suite.Run("負のlimitでは400エラー", func() {
@@ -265,6 +366,208 @@ func (suite *ContentListIntegrationTestSuite) TestList() {
 	})
 }
 
+// createContentsAt は作成日時を1分ずつずらしたコンテンツを作成し、新しい順のタイトルを返す
+func (suite *ContentListIntegrationTestSuite) createContentsAt(count int) []string {
+	base := time.Now().Add(-time.Hour)
+	titles := make([]string, count)
+	for i := 0; i < count; i++ {
+		title := fmt.Sprintf("記事%d", i+1)
+		content := &entities.Content{
+			Title:       title,
+			Body:        "本文",
+			ContentType: "article",
+			Author:      "作成者",
+			Status:      entities.StatusPublished,
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+		}
+		suite.Require().NoError(suite.db.Create(content).Error)
+		titles[count-1-i] = title
+	}
+	return titles
+}
+
+func (suite *ContentListIntegrationTestSuite) list(query string) (*http.Response, *content.ListContentsResponse) {
+	resp, err := suite.httpClient.Get(suite.server.URL + "/api/v1/contents?" + query)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var response content.ListContentsResponse
+	if resp.StatusCode == http.StatusOK {
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	}
+	return resp, &response
+}
+
+func titlesOf(contents []*entities.Content) []string {
+	titles := make([]string, len(contents))
+	for i, c := range contents {
+		titles[i] = c.Title
+	}
+	return titles
+}
+
+func (suite *ContentListIntegrationTestSuite) TestListWithCursor() {
+	suite.Run("next_cursorで次のページを取得できる", func() {
+		// Given
+		titles := suite.createContentsAt(5)
+
+		// When
+		_, first := suite.list("limit=2")
+		_, second := suite.list("limit=2&cursor=" + url.QueryEscape(first.NextCursor))
+		_, third := suite.list("limit=2&cursor=" + url.QueryEscape(second.NextCursor))
+
+		// Then
+		assert.Equal(suite.T(), titles[0:2], titlesOf(first.Contents))
+		assert.Equal(suite.T(), titles[2:4], titlesOf(second.Contents))
+		assert.Equal(suite.T(), titles[4:5], titlesOf(third.Contents))
+		assert.Empty(suite.T(), first.PrevCursor)
+		assert.NotEmpty(suite.T(), second.PrevCursor)
+		assert.Empty(suite.T(), third.NextCursor)
+	})
+
+	suite.Run("prev_cursorで前のページに戻れる", func() {
+		// Given
+		titles := suite.createContentsAt(5)
+		_, first := suite.list("limit=2")
+		_, second := suite.list("limit=2&cursor=" + url.QueryEscape(first.NextCursor))
+
+		// When
+		_, back := suite.list("limit=2&cursor=" + url.QueryEscape(second.PrevCursor))
+
+		// Then
+		assert.Equal(suite.T(), titles[0:2], titlesOf(back.Contents))
+		assert.Empty(suite.T(), back.PrevCursor)
+		assert.NotEmpty(suite.T(), back.NextCursor)
+	})
+
+	suite.Run("ページ取得の間に追加されたコンテンツで重複しない", func() {
+		// Given
+		titles := suite.createContentsAt(4)
+		_, first := suite.list("limit=2")
+		suite.createContent("新しい記事", "本文", "article", "作成者")
+
+		// When
+		_, second := suite.list("limit=2&cursor=" + url.QueryEscape(first.NextCursor))
+
+		// Then
+		assert.Equal(suite.T(), titles[2:4], titlesOf(second.Contents))
+	})
+
+	suite.Run("カーソル指定時は既定で件数を集計しない", func() {
+		// Given
+		suite.createContentsAt(3)
+		_, first := suite.list("limit=1")
+
+		// When
+		_, withoutTotal := suite.list("limit=1&cursor=" + url.QueryEscape(first.NextCursor))
+		_, withTotal := suite.list("limit=1&include_total=true&cursor=" + url.QueryEscape(first.NextCursor))
+
+		// Then
+		suite.Require().NotNil(first.Total)
+		assert.Equal(suite.T(), int64(3), *first.Total)
+		assert.Nil(suite.T(), withoutTotal.Total)
+		suite.Require().NotNil(withTotal.Total)
+		assert.Equal(suite.T(), int64(3), *withTotal.Total)
+	})
+
+	suite.Run("改ざんされたカーソルでは400エラー", func() {
+		// Given
+		suite.createContentsAt(3)
+		_, first := suite.list("limit=1")
+		tampered := "x" + first.NextCursor[1:]
+
+		// When
+		resp, _ := suite.list("limit=1&cursor=" + url.QueryEscape(tampered))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("cursorとoffsetの併用では400エラー", func() {
+		// Given
+		suite.createContentsAt(3)
+		_, first := suite.list("limit=1")
+
+		// When
+		resp, _ := suite.list("limit=1&offset=1&cursor=" + url.QueryEscape(first.NextCursor))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (suite *ContentListIntegrationTestSuite) TestListWithSortAndRange() {
+	suite.Run("sortで複数の項目を指定して並び替えできる", func() {
+		// Given
+		suite.createContent("B", "本文", "article", "作成者1")
+		suite.createContent("A", "本文", "article", "作成者2")
+		suite.createContent("C", "本文", "article", "作成者1")
+
+		// When
+		resp, response := suite.list("sort=-author,title")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), []string{"A", "B", "C"}, titlesOf(response.Contents))
+		assert.Empty(suite.T(), response.NextCursor)
+	})
+
+	suite.Run("created_afterとcreated_beforeで範囲を指定できる", func() {
+		// Given
+		titles := suite.createContentsAt(3)
+		_, all := suite.list("")
+		after := all.Contents[2].CreatedAt.Format(time.RFC3339Nano)
+		before := all.Contents[0].CreatedAt.Format(time.RFC3339Nano)
+
+		// When
+		resp, response := suite.list("created_after=" + url.QueryEscape(after) + "&created_before=" + url.QueryEscape(before))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), titles[1:3], titlesOf(response.Contents))
+	})
+
+	suite.Run("author_prefixで作成者を前方一致で絞り込める", func() {
+		// Given
+		suite.createContent("記事1", "本文", "article", "Yamada Taro")
+		suite.createContent("記事2", "本文", "article", "Suzuki Jiro")
+
+		// When
+		resp, response := suite.list("author_prefix=yama")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), []string{"記事1"}, titlesOf(response.Contents))
+	})
+
+	suite.Run("許可されていない項目での並び替えは400エラー", func() {
+		resp, _ := suite.list("sort=body")
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("範囲の前後が逆の場合は400エラー", func() {
+		resp, _ := suite.list("updated_after=2026-02-01T00:00:00Z&updated_before=2026-01-01T00:00:00Z")
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("RFC3339形式でない日時は400エラー", func() {
+		resp, _ := suite.list("created_after=2026-01-01")
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("既定以外の並び順でのcursor指定は400エラー", func() {
+		// Given
+		suite.createContentsAt(3)
+		_, first := suite.list("limit=1")
+
+		// When
+		resp, _ := suite.list("sort=title&cursor=" + url.QueryEscape(first.NextCursor))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 func TestContentListIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(ContentListIntegrationTestSuite))
 }
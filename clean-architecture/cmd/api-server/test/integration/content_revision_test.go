@@ -0,0 +1,331 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentRevisionIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM tags")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "作成者"}, {Name: "別の作成者"}}).Error)
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentRepo := repositories.NewContentRepository(suite.db)
+	contentAPI := content.NewContentAPI(contentRepo)
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.PUT("/:id", contentAPI.Update)
+		contents.GET("/:id/revisions", contentAPI.ListRevisions)
+		contents.GET("/:id/revisions/diff", contentAPI.DiffRevisions)
+		contents.GET("/:id/revisions/:revision", contentAPI.GetRevision)
+		contents.POST("/:id/revisions/:revision/restore", contentAPI.RestoreRevision)
+	}
+
+	return r
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) send(method, path string, body interface{}) *http.Response {
+	var reader *bytes.Buffer
+	if body != nil {
+		jsonBytes, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(jsonBytes)
+	} else {
+		reader = bytes.NewBuffer(nil)
+	}
+
+	req, _ := http.NewRequest(method, suite.server.URL+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+// createWithUpdates はコンテンツを作成し、指定されたタイトルで順に更新する
+func (suite *ContentRevisionIntegrationTestSuite) createWithUpdates(titles ...string) uint {
+	resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]string{
+		"title":        "初版タイトル",
+		"body":         "本文",
+		"content_type": "article",
+		"author":       "作成者",
+	})
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var created entities.Content
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&created))
+
+	for _, title := range titles {
+		updateResp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", created.ID), map[string]string{
+			"title":        title,
+			"body":         "本文",
+			"content_type": "article",
+			"author":       "作成者",
+		})
+		updateResp.Body.Close()
+		suite.Require().Equal(http.StatusOK, updateResp.StatusCode)
+	}
+
+	// 認証なしでリビジョンを参照できるよう公開中にする
+	suite.Require().NoError(suite.db.Model(&entities.Content{}).Where("id = ?", created.ID).Update("status", entities.StatusPublished).Error)
+
+	return created.ID
+}
+
+// updateAuthor はコンテンツの作成者を変更する
+func (suite *ContentRevisionIntegrationTestSuite) updateAuthor(contentID uint, author string) {
+	resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", contentID), map[string]string{
+		"title":        "初版タイトル",
+		"body":         "本文",
+		"content_type": "article",
+		"author":       author,
+	})
+	resp.Body.Close()
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+}
+
+func (suite *ContentRevisionIntegrationTestSuite) TestRevisions() {
+	suite.Run("更新ごとにリビジョンが記録される", func() {
+		// Given
+		contentID := suite.createWithUpdates("第2版タイトル", "第3版タイトル")
+
+		// When
+		resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d/revisions", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.ListRevisionsResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(response.Revisions, 3)
+		assert.Equal(suite.T(), 3, response.Revisions[0].RevisionNumber)
+		assert.Equal(suite.T(), "第3版タイトル", response.Revisions[0].Title)
+		assert.Equal(suite.T(), []string{entities.FieldTitle}, response.Revisions[0].ChangedFields)
+	})
+
+	suite.Run("指定したリビジョンを取得できる", func() {
+		// Given
+		contentID := suite.createWithUpdates("第2版タイトル")
+
+		// When
+		resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d/revisions/1", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.ContentRevision
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 1, response.RevisionNumber)
+		assert.Equal(suite.T(), "初版タイトル", response.Title)
+	})
+
+	suite.Run("2つのリビジョンの差分を取得できる", func() {
+		// Given
+		contentID := suite.createWithUpdates("第2版タイトル")
+
+		// When
+		resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d/revisions/diff?from=1&to=2", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.DiffRevisionsResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []entities.FieldDiff{
+			{Field: entities.FieldTitle, From: "初版タイトル", To: "第2版タイトル"},
+		}, response.Changes)
+	})
+
+	suite.Run("古いリビジョンを新しいリビジョンとして復元できる", func() {
+		// Given
+		contentID := suite.createWithUpdates("第2版タイトル")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/revisions/1/restore", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "初版タイトル", response.Title)
+
+		var latest entities.ContentRevision
+		suite.Require().NoError(suite.db.Where("content_id = ?", contentID).Order("revision_number DESC").First(&latest).Error)
+		assert.Equal(suite.T(), 3, latest.RevisionNumber)
+		suite.Require().NotNil(latest.RestoredFrom)
+		assert.Equal(suite.T(), 1, *latest.RestoredFrom)
+	})
+
+	suite.Run("復元するとリビジョンの作成者に戻る", func() {
+		// Given
+		contentID := suite.createWithUpdates()
+		suite.updateAuthor(contentID, "別の作成者")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/revisions/1/restore", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var restored entities.Content
+		suite.Require().NoError(suite.db.Preload("AuthorSummary").First(&restored, contentID).Error)
+		assert.Equal(suite.T(), "作成者", restored.Author)
+		suite.Require().NotNil(restored.AuthorSummary)
+		assert.Equal(suite.T(), "作成者", restored.AuthorSummary.Name)
+	})
+
+	suite.Run("リビジョンの作成者が登録されていない場合は422エラー", func() {
+		// Given: 作成者を変更した後、元の作成者を削除する
+		contentID := suite.createWithUpdates()
+		suite.updateAuthor(contentID, "別の作成者")
+		suite.Require().NoError(suite.db.Where("name = ?", "作成者").Delete(&entities.Author{}).Error)
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/revisions/1/restore", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then: 削除された作成者は作り直されない
+		assert.Equal(suite.T(), http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var count int64
+		suite.db.Model(&entities.Author{}).Where("name = ?", "作成者").Count(&count)
+		assert.Equal(suite.T(), int64(0), count)
+
+		var latest entities.ContentRevision
+		suite.Require().NoError(suite.db.Where("content_id = ?", contentID).Order("revision_number DESC").First(&latest).Error)
+		assert.Equal(suite.T(), 2, latest.RevisionNumber)
+	})
+
+	suite.Run("存在しないリビジョンでは404エラー", func() {
+		// Given
+		contentID := suite.createWithUpdates()
+
+		// When
+		resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d/revisions/99", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("差分の比較対象が未指定では400エラー", func() {
+		// Given
+		contentID := suite.createWithUpdates()
+
+		// When
+		resp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d/revisions/diff?from=1", contentID), nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestContentRevisionIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentRevisionIntegrationTestSuite))
+}
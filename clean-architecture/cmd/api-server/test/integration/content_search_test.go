@@ -0,0 +1,179 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentSearchIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentSearchIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行（全文検索用の生成列を含む）
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentSearchIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentSearchIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentSearchIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.GET("/search", contentAPI.Search)
+		contents.GET("/:id", contentAPI.GetByID)
+	}
+
+	return r
+}
+
+func (suite *ContentSearchIntegrationTestSuite) createContent(title, body string, status entities.ContentStatus) {
+	c, _ := entities.NewContent(title, body, "article", "作成者", entities.RegisterAuthor())
+	c.Status = status
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+}
+
+func (suite *ContentSearchIntegrationTestSuite) search(q string) *http.Response {
+	resp, err := suite.httpClient.Get(suite.server.URL + "/api/v1/contents/search?q=" + url.QueryEscape(q))
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentSearchIntegrationTestSuite) TestSearch() {
+	suite.Run("公開中のコンテンツから関連度順に検索できる", func() {
+		// Given
+		suite.createContent("重要なお知らせ", "システムメンテナンスに関する重要なお知らせです。", entities.StatusPublished)
+		suite.createContent("サンプル記事", "重要な変更はありません。", entities.StatusPublished)
+		suite.createContent("下書きのお知らせ", "重要な下書きです。", entities.StatusDraft)
+
+		// When
+		resp := suite.search("重要")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.SearchContentsResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(2), response.Total)
+		suite.Require().Len(response.Results, 2)
+		assert.Equal(suite.T(), "重要なお知らせ", response.Results[0].Title)
+		assert.Contains(suite.T(), response.Results[0].Headline, "<mark>重要</mark>")
+	})
+
+	suite.Run("キーワード未指定は400エラー", func() {
+		// When
+		resp := suite.search("")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("除外条件のみのキーワードは400エラー", func() {
+		// When
+		resp := suite.search("-draft")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("検索エンドポイントを追加してもID指定の取得は引き続き機能する", func() {
+		// When
+		resp, err := suite.httpClient.Get(suite.server.URL + "/api/v1/contents/123")
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestContentSearchIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentSearchIntegrationTestSuite))
+}
@@ -0,0 +1,230 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentTransitionIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentRepo := repositories.NewContentRepository(suite.db)
+	contentAPI := content.NewContentAPI(contentRepo)
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("/:id/submit", contentAPI.Submit)
+		contents.POST("/:id/publish", contentAPI.Publish)
+		contents.POST("/:id/archive", contentAPI.Archive)
+		contents.POST("/:id/return-to-draft", contentAPI.ReturnToDraft)
+	}
+
+	return r
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) createContent(status entities.ContentStatus) uint {
+	content := &entities.Content{
+		Title:       "テストタイトル",
+		Body:        "テスト本文",
+		ContentType: "article",
+		Author:      "テスト作成者",
+		Status:      status,
+	}
+	err := suite.db.Create(content).Error
+	suite.Require().NoError(err)
+	return content.ID
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) post(contentID uint, action string) *http.Response {
+	resp, err := suite.httpClient.Post(
+		fmt.Sprintf("%s/api/v1/contents/%d/%s", suite.server.URL, contentID, action),
+		"application/json",
+		nil,
+	)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentTransitionIntegrationTestSuite) TestTransition() {
+	suite.Run("下書きをレビュー経由で公開できる", func() {
+		// Given
+		contentID := suite.createContent(entities.StatusDraft)
+
+		// When: レビュー依頼
+		resp := suite.post(contentID, "submit")
+		resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		// When: 公開
+		resp = suite.post(contentID, "publish")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), entities.StatusPublished, response.Status)
+		assert.NotNil(suite.T(), response.PublishedAt)
+
+		var stored entities.Content
+		suite.Require().NoError(suite.db.First(&stored, contentID).Error)
+		assert.Equal(suite.T(), entities.StatusPublished, stored.Status)
+		assert.NotNil(suite.T(), stored.PublishedAt)
+	})
+
+	suite.Run("公開中のコンテンツをアーカイブできる", func() {
+		// Given
+		contentID := suite.createContent(entities.StatusPublished)
+
+		// When
+		resp := suite.post(contentID, "archive")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), entities.StatusArchived, response.Status)
+	})
+
+	suite.Run("レビュー待ちを下書きに差し戻せる", func() {
+		// Given
+		contentID := suite.createContent(entities.StatusInReview)
+
+		// When
+		resp := suite.post(contentID, "return-to-draft")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), entities.StatusDraft, response.Status)
+	})
+
+	suite.Run("許可されていない遷移では409エラー", func() {
+		// Given
+		contentID := suite.createContent(entities.StatusDraft)
+
+		// When
+		resp := suite.post(contentID, "publish")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+
+		var response map[string]interface{}
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), float64(http.StatusConflict), response["status"])
+	})
+
+	suite.Run("存在しないIDでは404エラー", func() {
+		// When
+		resp := suite.post(99999, "submit")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestContentTransitionIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentTransitionIntegrationTestSuite))
+}
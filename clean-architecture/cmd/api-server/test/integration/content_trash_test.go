@@ -0,0 +1,263 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentTrashIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *ContentTrashIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = database.Migrate(suite.db)
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentTrashIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentTrashIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+}
+
+func (suite *ContentTrashIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(
+		repositories.NewContentRepository(suite.db),
+		content.WithTrashRetention(30*24*time.Hour),
+	)
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.GET("/:id", contentAPI.GetByID)
+		contents.DELETE("/:id", contentAPI.Delete)
+		contents.POST("/:id/restore", contentAPI.Restore)
+	}
+
+	trash := v1.Group("/trash")
+	{
+		trash.GET("/contents", contentAPI.ListTrash)
+		trash.DELETE("/contents/:id", contentAPI.Purge)
+	}
+
+	return r
+}
+
+func (suite *ContentTrashIntegrationTestSuite) createDeletedContent(title string) *entities.Content {
+	repo := repositories.NewContentRepository(suite.db)
+	c, _ := entities.NewContent(title, "本文", "article", "作成者", entities.WithTags([]string{"go"}), entities.RegisterAuthor())
+	// 復元後に認証なしで取得できるよう公開中にする
+	suite.Require().NoError(c.SubmitForReview())
+	suite.Require().NoError(c.Publish())
+	suite.Require().NoError(repo.Create(context.Background(), c))
+	suite.Require().NoError(repo.Delete(context.Background(), c))
+	return c
+}
+
+func (suite *ContentTrashIntegrationTestSuite) do(method, path string) *http.Response {
+	req, err := http.NewRequest(method, suite.server.URL+path, nil)
+	suite.Require().NoError(err)
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentTrashIntegrationTestSuite) TestListTrash() {
+	suite.Run("削除済みのコンテンツのみを削除日時付きで取得できる", func() {
+		// Given
+		c, _ := entities.NewContent("公開中", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+		deleted := suite.createDeletedContent("削除済み")
+
+		// When
+		resp := suite.do(http.MethodGet, "/api/v1/trash/contents")
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response content.ListTrashResponse
+		suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(suite.T(), int64(1), response.Total)
+		suite.Require().Len(response.Contents, 1)
+		assert.Equal(suite.T(), deleted.ID, response.Contents[0].ID)
+		assert.False(suite.T(), response.Contents[0].DeletedAt.IsZero())
+		suite.Require().NotNil(response.Contents[0].PurgeAt)
+		assert.WithinDuration(suite.T(), response.Contents[0].DeletedAt.Add(30*24*time.Hour), *response.Contents[0].PurgeAt, time.Second)
+	})
+}
+
+func (suite *ContentTrashIntegrationTestSuite) TestRestore() {
+	suite.Run("削除済みのコンテンツを復元できる", func() {
+		// Given
+		deleted := suite.createDeletedContent("削除済み")
+
+		// When
+		resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/restore", deleted.ID))
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		getResp := suite.do(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", deleted.ID))
+		defer getResp.Body.Close()
+		assert.Equal(suite.T(), http.StatusOK, getResp.StatusCode)
+
+		var restored entities.Content
+		suite.Require().NoError(json.NewDecoder(getResp.Body).Decode(&restored))
+		suite.Require().Len(restored.Tags, 1)
+		assert.Equal(suite.T(), "go", restored.Tags[0].Name)
+	})
+
+	suite.Run("削除されていないコンテンツの復元は404エラー", func() {
+		// Given
+		c, _ := entities.NewContent("公開中", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+
+		// When
+		resp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/restore", c.ID))
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func (suite *ContentTrashIntegrationTestSuite) TestPurge() {
+	suite.Run("ゴミ箱のコンテンツをリビジョンとともに完全削除できる", func() {
+		// Given
+		deleted := suite.createDeletedContent("削除済み")
+
+		// When
+		resp := suite.do(http.MethodDelete, fmt.Sprintf("/api/v1/trash/contents/%d", deleted.ID))
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNoContent, resp.StatusCode)
+
+		var count int64
+		suite.db.Unscoped().Model(&entities.Content{}).Where("id = ?", deleted.ID).Count(&count)
+		assert.Equal(suite.T(), int64(0), count)
+		suite.db.Model(&entities.ContentRevision{}).Where("content_id = ?", deleted.ID).Count(&count)
+		assert.Equal(suite.T(), int64(0), count)
+
+		restoreResp := suite.do(http.MethodPost, fmt.Sprintf("/api/v1/contents/%d/restore", deleted.ID))
+		defer restoreResp.Body.Close()
+		assert.Equal(suite.T(), http.StatusNotFound, restoreResp.StatusCode)
+	})
+
+	suite.Run("削除されていないコンテンツは完全削除できない", func() {
+		// Given
+		c, _ := entities.NewContent("公開中", "本文", "article", "作成者", entities.RegisterAuthor())
+		suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+
+		// When
+		resp := suite.do(http.MethodDelete, fmt.Sprintf("/api/v1/trash/contents/%d", c.ID))
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+
+	suite.Run("保持期間を過ぎたコンテンツのみ自動で完全削除される", func() {
+		// Given
+		expired := suite.createDeletedContent("期限切れ")
+		recent := suite.createDeletedContent("最近削除")
+		suite.db.Unscoped().Model(&entities.Content{}).Where("id = ?", expired.ID).
+			Update("deleted_at", time.Now().Add(-31*24*time.Hour))
+
+		purger := content.NewTrashPurger(repositories.NewContentRepository(suite.db), 30*24*time.Hour, time.Hour)
+
+		// When
+		purged, err := purger.PurgeExpired(context.Background())
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), int64(1), purged)
+
+		var ids []uint
+		suite.db.Unscoped().Model(&entities.Content{}).Pluck("id", &ids)
+		assert.Equal(suite.T(), []uint{recent.ID}, ids)
+	})
+}
+
+func TestContentTrashIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentTrashIntegrationTestSuite))
+}
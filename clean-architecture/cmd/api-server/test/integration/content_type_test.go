@@ -0,0 +1,274 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/api/contenttype"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ContentTypeIntegrationTestSuite struct {
+	suite.Suite
+	container      *postgres.PostgresContainer
+	db             *gorm.DB
+	server         *httptest.Server
+	httpClient     *http.Client
+	contentTypeAPI *contenttype.ContentTypeAPI
+}
+
+func (suite *ContentTypeIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.ContentType{}, &entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *ContentTypeIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *ContentTypeIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップと初期コンテンツタイプの再登録
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM tags")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM content_types")
+	suite.Require().NoError(suite.db.Create(entities.DefaultContentTypes()).Error)
+	suite.Require().NoError(suite.contentTypeAPI.Reload(context.Background()))
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "作成者"}, {Name: "運営チーム"}}).Error)
+}
+
+func (suite *ContentTypeIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	registry := entities.NewDefaultContentTypeRegistry()
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db), content.WithContentTypes(registry))
+	suite.contentTypeAPI = contenttype.NewContentTypeAPI(repositories.NewContentTypeRepository(suite.db), registry)
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.GET("", contentAPI.List)
+	}
+
+	contentTypes := v1.Group("/content-types")
+	{
+		contentTypes.GET("", suite.contentTypeAPI.List)
+		contentTypes.POST("", suite.contentTypeAPI.Create)
+		contentTypes.GET("/:name", suite.contentTypeAPI.GetByName)
+		contentTypes.PUT("/:name", suite.contentTypeAPI.Update)
+		contentTypes.DELETE("/:name", suite.contentTypeAPI.Delete)
+	}
+
+	return r
+}
+
+func (suite *ContentTypeIntegrationTestSuite) send(method, path string, body interface{}) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *ContentTypeIntegrationTestSuite) createContent(contentType, author string) *http.Response {
+	return suite.send(http.MethodPost, "/api/v1/contents", map[string]string{
+		"title":        "タイトル",
+		"body":         "本文",
+		"content_type": contentType,
+		"author":       author,
+	})
+}
+
+func (suite *ContentTypeIntegrationTestSuite) TestContentTypes() {
+	suite.Run("初期コンテンツタイプが一覧で取得できる", func() {
+		// When
+		resp := suite.send(http.MethodGet, "/api/v1/content-types", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response contenttype.ListContentTypesResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+
+		names := make([]string, 0, len(response.ContentTypes))
+		for _, t := range response.ContentTypes {
+			names = append(names, t.Name)
+		}
+		assert.Equal(suite.T(), []string{"article", "blog", "news", "page"}, names)
+	})
+
+	suite.Run("追加したコンテンツタイプですぐにコンテンツを作成できる", func() {
+		// Given: 未登録のタイプでは作成できない
+		resp := suite.createContent("podcast", "作成者")
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+
+		// When
+		resp = suite.send(http.MethodPost, "/api/v1/content-types", map[string]interface{}{
+			"name":        "podcast",
+			"description": "音声配信",
+		})
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+		// Then
+		resp = suite.createContent("podcast", "作成者")
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("同名のコンテンツタイプは409エラー", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/content-types", map[string]interface{}{
+			"name": "article",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("更新した制約がコンテンツの作成に適用される", func() {
+		// Given
+		var team entities.Author
+		suite.Require().NoError(suite.db.Where("name = ?", "運営チーム").First(&team).Error)
+
+		// When
+		resp := suite.send(http.MethodPut, "/api/v1/content-types/news", map[string]interface{}{
+			"allowed_authors": []uint{team.ID},
+		})
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		// Then
+		resp = suite.createContent("news", "作成者")
+		resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+
+		resp = suite.createContent("news", "運営チーム")
+		resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+	})
+
+	suite.Run("登録されていない作成者は許可する作成者に指定できず400エラー", func() {
+		// When
+		resp := suite.send(http.MethodPut, "/api/v1/content-types/news", map[string]interface{}{
+			"allowed_authors": []uint{999999},
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("使用中のコンテンツタイプは削除できず409エラー", func() {
+		// Given
+		resp := suite.createContent("blog", "作成者")
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+		// When
+		resp = suite.send(http.MethodDelete, "/api/v1/content-types/blog", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("削除したコンテンツタイプでは一覧を絞り込めない", func() {
+		// When
+		resp := suite.send(http.MethodDelete, "/api/v1/content-types/page", nil)
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusNoContent, resp.StatusCode)
+
+		// Then
+		resp = suite.send(http.MethodGet, "/api/v1/contents?content_type=page", nil)
+		defer resp.Body.Close()
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+
+	suite.Run("存在しないコンテンツタイプは404エラー", func() {
+		// When
+		resp := suite.send(http.MethodGet, "/api/v1/content-types/unknown", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestContentTypeIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentTypeIntegrationTestSuite))
+}
@@ -15,6 +15,7 @@ import (
 	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
 	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -59,7 +60,7 @@ func (suite *ContentUpdateIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -85,7 +86,14 @@ func (suite *ContentUpdateIntegrationTestSuite) TearDownSuite() {
 
 func (suite *ContentUpdateIntegrationTestSuite) SetupSubTest() {
 	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
 	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM tags")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "新しい作成者"}}).Error)
 }
 
 func (suite *ContentUpdateIntegrationTestSuite) setupRouter() *gin.Engine {
@@ -95,7 +103,8 @@ func (suite *ContentUpdateIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
 	r.GET("/health", healthAPI.Check)
 
 	// リポジトリとAPIを直接初期化
@@ -163,6 +172,74 @@ func (suite *ContentUpdateIntegrationTestSuite) TestUpdate() {
 		assert.Equal(suite.T(), "新しい作成者", response.Author)
 	})
 
+	suite.Run("If-Matchが一致すれば更新されETagが進む", func() {
+		// Given
+		contentID := suite.createContent("元のタイトル", "元の本文", "article", "元の作成者")
+
+		updateBody := map[string]string{
+			"title":        "新しいタイトル",
+			"body":         "新しい本文",
+			"content_type": "blog",
+			"author":       "新しい作成者",
+		}
+		jsonBytes, _ := json.Marshal(updateBody)
+
+		// When
+		req, _ := http.NewRequest(
+			http.MethodPut,
+			fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, contentID),
+			bytes.NewBuffer(jsonBytes),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", fmt.Sprintf(`"%d-1"`, contentID))
+
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), fmt.Sprintf(`"%d-2"`, contentID), resp.Header.Get("ETag"))
+
+		var response entities.Content
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), uint(2), response.Version)
+	})
+
+	suite.Run("If-Matchが一致しない場合は412エラー", func() {
+		// Given
+		contentID := suite.createContent("元のタイトル", "元の本文", "article", "元の作成者")
+
+		updateBody := map[string]string{
+			"title":        "新しいタイトル",
+			"body":         "新しい本文",
+			"content_type": "blog",
+			"author":       "新しい作成者",
+		}
+		jsonBytes, _ := json.Marshal(updateBody)
+
+		// When
+		req, _ := http.NewRequest(
+			http.MethodPut,
+			fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, contentID),
+			bytes.NewBuffer(jsonBytes),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", fmt.Sprintf(`"%d-99"`, contentID))
+
+		resp, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusPreconditionFailed, resp.StatusCode)
+
+		var stored entities.Content
+		suite.Require().NoError(suite.db.First(&stored, contentID).Error)
+		assert.Equal(suite.T(), "元のタイトル", stored.Title)
+	})
+
 	suite.Run("存在しないIDでは404エラー", func() {
 		// Given
 		updateBody := map[string]string{
@@ -191,8 +268,9 @@ func (suite *ContentUpdateIntegrationTestSuite) TestUpdate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusNotFound), response["code"])
-		assert.Contains(suite.T(), response["message"], "指定されたコンテンツが見つかりません")
+		assert.Equal(suite.T(), float64(http.StatusNotFound), response["status"])
+		assert.Equal(suite.T(), "content_not_found", response["code"])
+		assert.Contains(suite.T(), response["title"], "指定されたコンテンツが見つかりません")
 	})
 
 	suite.Run("不正なID形式では400エラー", func() {
@@ -223,8 +301,9 @@ func (suite *ContentUpdateIntegrationTestSuite) TestUpdate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
-		assert.Contains(suite.T(), response["message"], "不正なIDです")
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
+		assert.Equal(suite.T(), "invalid_id", response["code"])
+		assert.Contains(suite.T(), response["title"], "不正なIDです")
 	})
 
 	suite.Run("titleが空の場合はバリデーションエラー", func() {
@@ -257,7 +336,7 @@ func (suite *ContentUpdateIntegrationTestSuite) TestUpdate() {
 		var response map[string]interface{}
 		err = json.NewDecoder(resp.Body).Decode(&response)
 		assert.NoError(suite.T(), err)
-		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["code"])
+		assert.Equal(suite.T(), float64(http.StatusBadRequest), response["status"])
 	})
 
 	suite.Run("content_typeが不正な値の場合はバリデーションエラー", func() {
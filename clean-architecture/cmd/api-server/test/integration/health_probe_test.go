@@ -0,0 +1,234 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/health"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeCheck は結果を切り替えられ、実行回数を数えるチェック
+type fakeCheck struct {
+	calls atomic.Int32
+	mu    sync.Mutex
+	err   error
+}
+
+func (f *fakeCheck) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeCheck) check(ctx context.Context) error {
+	f.calls.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+type HealthProbeIntegrationTestSuite struct {
+	suite.Suite
+	healthAPI *health.HealthAPI
+	router    *gin.Engine
+	database  *fakeCheck
+	disk      *fakeCheck
+}
+
+func (suite *HealthProbeIntegrationTestSuite) SetupSubTest() {
+	gin.SetMode(gin.TestMode)
+	suite.database = &fakeCheck{}
+	suite.disk = &fakeCheck{}
+	suite.setupRouter(0)
+}
+
+func (suite *HealthProbeIntegrationTestSuite) setupRouter(cacheTTL time.Duration) {
+	suite.healthAPI = health.NewHealthAPI(
+		health.WithCacheTTL(cacheTTL),
+		health.WithCheckTimeout(100*time.Millisecond),
+	)
+	suite.healthAPI.Register(health.DatabaseCheck, suite.database.check)
+	suite.healthAPI.Register("disk", suite.disk.check, health.NonCritical())
+
+	suite.router = gin.New()
+	suite.router.GET("/health", suite.healthAPI.Check)
+	suite.router.GET("/livez", suite.healthAPI.Livez)
+	suite.router.GET("/readyz", suite.healthAPI.Readyz)
+}
+
+func (suite *HealthProbeIntegrationTestSuite) get(path string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var response map[string]interface{}
+	suite.Require().NoError(json.NewDecoder(w.Body).Decode(&response))
+	return w.Code, response
+}
+
+func (suite *HealthProbeIntegrationTestSuite) TestReadyz() {
+	suite.Run("必須のチェックがすべて成功した場合は200を返す", func() {
+		// When
+		status, response := suite.get("/readyz")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Equal(suite.T(), "ready", response["status"])
+		assert.NotContains(suite.T(), response, "checks")
+	})
+
+	suite.Run("必須のチェックが失敗した場合は503を返す", func() {
+		// Given
+		suite.database.setErr(errors.New("connection refused"))
+
+		// When
+		status, response := suite.get("/readyz")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, status)
+		assert.Equal(suite.T(), "not_ready", response["status"])
+		assert.Contains(suite.T(), response["message"], health.DatabaseCheck)
+	})
+
+	suite.Run("必須でないチェックの失敗は警告として報告し200を返す", func() {
+		// Given
+		suite.disk.setErr(errors.New("空き容量が不足しています"))
+
+		// When
+		status, response := suite.get("/readyz?verbose")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, status)
+		checks := response["checks"].([]interface{})
+		suite.Require().Len(checks, 2)
+		disk := checks[1].(map[string]interface{})
+		assert.Equal(suite.T(), "disk", disk["name"])
+		assert.Equal(suite.T(), health.CheckStatusWarn, disk["status"])
+		assert.Equal(suite.T(), "空き容量が不足しています", disk["error"])
+	})
+
+	suite.Run("verboseを指定した場合はチェックごとの所要時間と直近のエラーを返す", func() {
+		// Given: 一度失敗してから回復した
+		suite.database.setErr(errors.New("connection refused"))
+		suite.get("/readyz")
+		suite.database.setErr(nil)
+
+		// When
+		status, response := suite.get("/readyz?verbose=true")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, status)
+		checks := response["checks"].([]interface{})
+		database := checks[0].(map[string]interface{})
+		assert.Equal(suite.T(), health.DatabaseCheck, database["name"])
+		assert.Equal(suite.T(), health.CheckStatusOK, database["status"])
+		assert.Contains(suite.T(), database, "latency_ms")
+		assert.NotContains(suite.T(), database, "error")
+		assert.Equal(suite.T(), "connection refused", database["last_error"])
+		assert.NotEmpty(suite.T(), database["last_error_at"])
+	})
+
+	suite.Run("制限時間内に完了しないチェックは失敗する", func() {
+		// Given
+		suite.healthAPI.Register("slow", func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+		// When
+		start := time.Now()
+		status, response := suite.get("/readyz?verbose")
+
+		// Then
+		assert.Less(suite.T(), time.Since(start), 500*time.Millisecond)
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, status)
+		slow := response["checks"].([]interface{})[2].(map[string]interface{})
+		assert.Equal(suite.T(), health.CheckStatusFail, slow["status"])
+	})
+
+	suite.Run("チェックの結果はキャッシュされる", func() {
+		// Given
+		suite.setupRouter(time.Minute)
+
+		// When
+		for range 5 {
+			status, _ := suite.get("/readyz")
+			assert.Equal(suite.T(), http.StatusOK, status)
+		}
+		suite.database.setErr(errors.New("connection refused"))
+		status, _ := suite.get("/readyz")
+
+		// Then: キャッシュの期間中は依存先を再度確認しない
+		assert.Equal(suite.T(), int32(1), suite.database.calls.Load())
+		assert.Equal(suite.T(), http.StatusOK, status)
+	})
+
+	suite.Run("停止を開始した後は503を返す", func() {
+		// Given
+		suite.healthAPI.SetShuttingDown()
+
+		// When
+		status, response := suite.get("/readyz")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, status)
+		assert.Equal(suite.T(), "shutting_down", response["status"])
+	})
+}
+
+func (suite *HealthProbeIntegrationTestSuite) TestLivez() {
+	suite.Run("依存先の障害や停止処理中でも200を返す", func() {
+		// Given
+		suite.database.setErr(errors.New("connection refused"))
+		suite.healthAPI.SetShuttingDown()
+
+		// When
+		status, response := suite.get("/livez")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, status)
+		assert.Equal(suite.T(), "ok", response["status"])
+		assert.Zero(suite.T(), suite.database.calls.Load())
+	})
+}
+
+func (suite *HealthProbeIntegrationTestSuite) TestHealth() {
+	suite.Run("データベースに接続できない場合は503を返す", func() {
+		// Given
+		suite.database.setErr(errors.New("connection refused"))
+
+		// When
+		status, response := suite.get("/health")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, status)
+		assert.Equal(suite.T(), "unhealthy", response["status"])
+		assert.Equal(suite.T(), "disconnected", response["database"])
+	})
+}
+
+func (suite *HealthProbeIntegrationTestSuite) TestDiskSpaceCheck() {
+	suite.Run("空き容量が必要な容量未満の場合は失敗する", func() {
+		// Given
+		dir := suite.T().TempDir()
+
+		// When / Then
+		assert.NoError(suite.T(), health.DiskSpaceCheck(dir, 0)(context.Background()))
+		assert.ErrorContains(suite.T(), health.DiskSpaceCheck(dir, 1<<62)(context.Background()), "空き容量が不足しています")
+		assert.Error(suite.T(), health.DiskSpaceCheck(dir+"/missing", 0)(context.Background()))
+	})
+}
+
+func TestHealthProbeIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthProbeIntegrationTestSuite))
+}
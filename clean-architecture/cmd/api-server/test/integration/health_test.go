@@ -10,7 +10,7 @@ import (
 
 	"go-api-server-sample/cmd/api-server/internal/api/health"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
-	"go-api-server-sample/internal/domain/entities"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -55,7 +55,7 @@ func (suite *HealthIntegrationTestSuite) SetupSuite() {
 	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
 	suite.Require().NoError(err)
 
-	err = suite.db.AutoMigrate(&entities.Content{})
+	err = database.Migrate(suite.db)
 	suite.Require().NoError(err)
 
 	// ルーター設定
@@ -86,8 +86,12 @@ func (suite *HealthIntegrationTestSuite) setupRouter() *gin.Engine {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(suite.db)
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
+	healthAPI.Register("migrations", database.SchemaVersionCheck(suite.db))
+	healthAPI.Register("connection_pool", database.PoolSaturationCheck(suite.db, 0.9), health.NonCritical())
 	r.GET("/health", healthAPI.Check)
+	r.GET("/readyz", healthAPI.Readyz)
 
 	return r
 }
@@ -109,6 +113,89 @@ func (suite *HealthIntegrationTestSuite) TestHealthCheck() {
 		assert.Equal(suite.T(), "connected", response["database"])
 		assert.NotNil(suite.T(), response["timestamp"])
 	})
+
+	suite.Run("依存先が正常な場合はreadyを返す", func() {
+		// When
+		resp, err := suite.httpClient.Get(suite.server.URL + "/readyz?verbose")
+		suite.Require().NoError(err)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response health.ProbeResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "ready", response.Status)
+		suite.Require().Len(response.Checks, 3)
+		for _, check := range response.Checks {
+			assert.Equal(suite.T(), health.CheckStatusOK, check.Status, check.Name)
+		}
+	})
+
+	suite.Run("古いバージョンのマイグレーションでは記録されたバージョンを下げない", func() {
+		// Given: 新しいバージョンのインスタンスがマイグレーションを適用済み
+		ctx := context.Background()
+		newer := database.SchemaVersion + 1
+		suite.Require().NoError(suite.db.Exec("UPDATE schema_version SET version = ?", newer).Error)
+
+		// When
+		err := database.Migrate(suite.db)
+
+		// Then
+		suite.Require().NoError(err)
+		applied, err := database.AppliedSchemaVersion(ctx, suite.db)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), newer, applied)
+	})
+
+	suite.Run("マイグレーションが適用されていない場合は503を返す", func() {
+		// Given: スキーマのバージョンを記録していないデータベース
+		healthAPI := health.NewHealthAPI()
+		healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
+		healthAPI.Register("migrations", database.SchemaVersionCheck(suite.db))
+		r := gin.New()
+		r.GET("/readyz", healthAPI.Readyz)
+
+		suite.Require().NoError(suite.db.Exec("DROP TABLE IF EXISTS schema_version").Error)
+
+		// When
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz?verbose", nil))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+		var response health.ProbeResponse
+		err := json.NewDecoder(w.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "not_ready", response.Status)
+		suite.Require().Len(response.Checks, 2)
+		assert.Equal(suite.T(), health.CheckStatusOK, response.Checks[0].Status)
+		assert.Equal(suite.T(), health.CheckStatusFail, response.Checks[1].Status)
+		assert.NotEmpty(suite.T(), response.Checks[1].Error)
+	})
+
+	suite.Run("停止を開始した後は503を返す", func() {
+		// Given
+		healthAPI := health.NewHealthAPI()
+		healthAPI.Register(health.DatabaseCheck, database.PingCheck(suite.db))
+		healthAPI.SetShuttingDown()
+		r := gin.New()
+		r.GET("/health", healthAPI.Check)
+
+		// When
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		// Then
+		assert.Equal(suite.T(), http.StatusServiceUnavailable, w.Code)
+
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "shutting_down", response["status"])
+	})
 }
 
 func TestHealthIntegrationTestSuite(t *testing.T) {
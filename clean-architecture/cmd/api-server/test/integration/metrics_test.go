@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/metrics"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsIntegrationTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *MetricsIntegrationTestSuite) SetupSubTest() {
+	gin.SetMode(gin.TestMode)
+	suite.server = httptest.NewServer(suite.setupRouter())
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *MetricsIntegrationTestSuite) TearDownSubTest() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+}
+
+func (suite *MetricsIntegrationTestSuite) setupRouter() *gin.Engine {
+	m := metrics.New(nil, "")
+
+	r := gin.New()
+	r.Use(middleware.Metrics(m))
+	r.Use(gin.Recovery())
+	r.GET("/metrics", gin.WrapH(m.Handler()))
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/contents/:id", func(c *gin.Context) {
+		if c.Param("id") == "0" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	return r
+}
+
+func (suite *MetricsIntegrationTestSuite) get(path string) *http.Response {
+	resp, err := suite.httpClient.Get(suite.server.URL + path)
+	suite.Require().NoError(err)
+	return resp
+}
+
+func (suite *MetricsIntegrationTestSuite) scrape() string {
+	resp := suite.get("/metrics")
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	suite.Require().NoError(err)
+	return string(body)
+}
+
+func (suite *MetricsIntegrationTestSuite) TestMetrics() {
+	suite.Run("リクエストをルートのテンプレートとステータスごとに集計する", func() {
+		// Given
+		suite.get("/api/v1/contents/1").Body.Close()
+		suite.get("/api/v1/contents/2").Body.Close()
+		suite.get("/api/v1/contents/0").Body.Close()
+
+		// When
+		body := suite.scrape()
+
+		// Then
+		assert.Contains(suite.T(), body, `api_http_requests_total{method="GET",route="/api/v1/contents/:id",status="200"} 2`)
+		assert.Contains(suite.T(), body, `api_http_requests_total{method="GET",route="/api/v1/contents/:id",status="404"} 1`)
+		assert.Contains(suite.T(), body, `api_http_request_duration_seconds_count{method="GET",route="/api/v1/contents/:id",status="200"} 2`)
+		assert.Contains(suite.T(), body, `api_http_requests_in_flight 1`) // 取得中の /metrics 自身
+		assert.NotContains(suite.T(), body, `route="/api/v1/contents/1"`)
+	})
+
+	suite.Run("ルートに一致しないリクエストはまとめて集計する", func() {
+		// Given
+		suite.get("/unknown/a").Body.Close()
+		suite.get("/unknown/b").Body.Close()
+
+		// When
+		body := suite.scrape()
+
+		// Then
+		assert.Contains(suite.T(), body, `api_http_requests_total{method="GET",route="unmatched",status="404"} 2`)
+		assert.NotContains(suite.T(), body, "/unknown/")
+	})
+}
+
+func TestMetricsIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsIntegrationTestSuite))
+}
@@ -0,0 +1,168 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// testUserHeader はテスト用にプリンシパルを指定するヘッダー
+const testUserHeader = "X-Test-User"
+
+type RateLimitIntegrationTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *RateLimitIntegrationTestSuite) SetupSubTest() {
+	// サブテストごとに新しいストアでサーバーを起動する
+	gin.SetMode(gin.TestMode)
+	suite.server = httptest.NewServer(suite.setupRouter())
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *RateLimitIntegrationTestSuite) TearDownSubTest() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+}
+
+func (suite *RateLimitIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+	suite.Require().NoError(r.SetTrustedProxies([]string{"127.0.0.1"}))
+
+	r.Use(gin.Recovery())
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+	store := middleware.NewMemoryRateLimitStore()
+	v1.Use(middleware.IPRateLimiter(store, middleware.RateLimit{Requests: 5, Period: time.Hour}))
+	v1.Use(func(c *gin.Context) {
+		if user := c.GetHeader(testUserHeader); user != "" {
+			middleware.SetPrincipal(c, &middleware.Principal{Subject: user, Name: user})
+		}
+		c.Next()
+	})
+	v1.Use(middleware.RateLimiter(store, middleware.RateLimits{
+		Read:  middleware.RateLimit{Requests: 3, Period: time.Hour},
+		Write: middleware.RateLimit{Requests: 1, Period: time.Hour},
+	}))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	v1.GET("/contents", ok)
+	v1.POST("/contents", ok)
+
+	return r
+}
+
+func (suite *RateLimitIntegrationTestSuite) send(method, user string) *http.Response {
+	return suite.sendFrom(method, user, "")
+}
+
+// sendFrom は信頼するプロキシが X-Forwarded-For に forwardedFor を付けて転送したリクエストを送る
+func (suite *RateLimitIntegrationTestSuite) sendFrom(method, user, forwardedFor string) *http.Response {
+	req, _ := http.NewRequest(method, suite.server.URL+"/api/v1/contents", nil)
+	if user != "" {
+		req.Header.Set(testUserHeader, user)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	return resp
+}
+
+func (suite *RateLimitIntegrationTestSuite) TestRateLimit() {
+	suite.Run("残りのリクエスト数がヘッダーで返される", func() {
+		// When
+		resp := suite.send(http.MethodGet, "")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "3", resp.Header.Get("RateLimit-Limit"))
+		assert.Equal(suite.T(), "2", resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(suite.T(), "1200", resp.Header.Get("RateLimit-Reset"))
+	})
+
+	suite.Run("制限を超えると429エラーとRetry-Afterが返される", func() {
+		// Given
+		for i := 0; i < 3; i++ {
+			suite.Require().Equal(http.StatusOK, suite.send(http.MethodGet, "").StatusCode)
+		}
+
+		// When
+		resp := suite.send(http.MethodGet, "")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(suite.T(), "application/problem+json", resp.Header.Get("Content-Type"))
+		assert.Equal(suite.T(), "0", resp.Header.Get("RateLimit-Remaining"))
+
+		retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		assert.NoError(suite.T(), err)
+		assert.InDelta(suite.T(), 1200, retryAfter, 1)
+	})
+
+	suite.Run("読み取りと書き込みは別々に制限される", func() {
+		// Given
+		suite.Require().Equal(http.StatusOK, suite.send(http.MethodPost, "").StatusCode)
+
+		// Then
+		assert.Equal(suite.T(), http.StatusTooManyRequests, suite.send(http.MethodPost, "").StatusCode)
+		assert.Equal(suite.T(), http.StatusOK, suite.send(http.MethodGet, "").StatusCode)
+	})
+
+	suite.Run("プリンシパルごとに制限される", func() {
+		// Given
+		suite.Require().Equal(http.StatusOK, suite.send(http.MethodPost, "alice").StatusCode)
+
+		// Then
+		assert.Equal(suite.T(), http.StatusTooManyRequests, suite.send(http.MethodPost, "alice").StatusCode)
+		assert.Equal(suite.T(), http.StatusOK, suite.send(http.MethodPost, "bob").StatusCode)
+		assert.Equal(suite.T(), http.StatusOK, suite.send(http.MethodPost, "").StatusCode)
+	})
+
+	suite.Run("プリンシパルによらずクライアントIPごとに制限される", func() {
+		// Given: 異なるプリンシパルを名乗って同じIPから送る
+		for _, user := range []string{"alice", "bob", "carol", "dave", "eve"} {
+			suite.Require().Equal(http.StatusOK, suite.send(http.MethodGet, user).StatusCode)
+		}
+
+		// When
+		resp := suite.send(http.MethodGet, "frank")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(suite.T(), "5", resp.Header.Get("RateLimit-Limit"))
+		assert.NotEmpty(suite.T(), resp.Header.Get("Retry-After"))
+	})
+
+	suite.Run("信頼するプロキシの X-Forwarded-For でクライアントIPを判定する", func() {
+		// Given
+		for _, user := range []string{"alice", "bob", "carol", "dave", "eve"} {
+			suite.Require().Equal(http.StatusOK, suite.sendFrom(http.MethodGet, user, "203.0.113.1").StatusCode)
+		}
+
+		// Then
+		assert.Equal(suite.T(), http.StatusTooManyRequests, suite.sendFrom(http.MethodGet, "frank", "203.0.113.1").StatusCode)
+		assert.Equal(suite.T(), http.StatusOK, suite.sendFrom(http.MethodGet, "frank", "203.0.113.2").StatusCode)
+	})
+}
+
+func TestRateLimitIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitIntegrationTestSuite))
+}
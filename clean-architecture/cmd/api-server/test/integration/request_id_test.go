@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// syncBuffer はサーバーのゴルーチンから書き込まれるログを保持する
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines() []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err == nil {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+type RequestIDIntegrationTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	httpClient *http.Client
+	logs       *syncBuffer
+}
+
+func (suite *RequestIDIntegrationTestSuite) SetupSubTest() {
+	gin.SetMode(gin.TestMode)
+	suite.logs = &syncBuffer{}
+	suite.server = httptest.NewServer(suite.setupRouter())
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *RequestIDIntegrationTestSuite) TearDownSubTest() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+}
+
+func (suite *RequestIDIntegrationTestSuite) setupRouter() *gin.Engine {
+	logger, err := logging.New(suite.logs, "debug", "json")
+	suite.Require().NoError(err)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(logger))
+	r.Use(middleware.Logger())
+	r.Use(gin.Recovery())
+
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+	v1.Use(func(c *gin.Context) {
+		if user := c.GetHeader(testUserHeader); user != "" {
+			middleware.SetPrincipal(c, &middleware.Principal{Subject: user, Name: user})
+		}
+		c.Next()
+	})
+
+	v1.GET("/contents", func(c *gin.Context) {
+		// リポジトリなどコンテキストを受け取る処理からのログ
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).InfoContext(ctx, "handler")
+		c.Status(http.StatusOK)
+	})
+
+	return r
+}
+
+func (suite *RequestIDIntegrationTestSuite) get(requestID, user string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, suite.server.URL+"/api/v1/contents", nil)
+	if requestID != "" {
+		req.Header.Set("X-Request-ID", requestID)
+	}
+	if user != "" {
+		req.Header.Set(testUserHeader, user)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	return resp
+}
+
+func (suite *RequestIDIntegrationTestSuite) TestRequestID() {
+	suite.Run("指定したリクエストIDがレスポンスとログに含まれる", func() {
+		// When
+		resp := suite.get("req-123", "alice")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "req-123", resp.Header.Get("X-Request-ID"))
+
+		lines := suite.logs.lines()
+		suite.Require().Len(lines, 2)
+		for _, line := range lines {
+			assert.Equal(suite.T(), "req-123", line["request_id"])
+			assert.Equal(suite.T(), "alice", line["user"])
+		}
+
+		access := lines[1]
+		assert.Equal(suite.T(), "GET", access["method"])
+		assert.Equal(suite.T(), "/api/v1/contents", access["path"])
+		assert.Equal(suite.T(), float64(http.StatusOK), access["status"])
+	})
+
+	suite.Run("リクエストIDを指定しない場合は生成される", func() {
+		// When
+		resp := suite.get("", "")
+
+		// Then
+		requestID := resp.Header.Get("X-Request-ID")
+		assert.Len(suite.T(), requestID, 32)
+
+		lines := suite.logs.lines()
+		suite.Require().NotEmpty(lines)
+		assert.Equal(suite.T(), requestID, lines[0]["request_id"])
+		assert.NotContains(suite.T(), lines[0], "user")
+	})
+
+	suite.Run("不正なリクエストIDは置き換えられる", func() {
+		// When
+		resp := suite.get("invalid id", "")
+
+		// Then
+		requestID := resp.Header.Get("X-Request-ID")
+		assert.NotEqual(suite.T(), "invalid id", requestID)
+		assert.False(suite.T(), strings.Contains(requestID, " "))
+	})
+}
+
+func TestRequestIDIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDIntegrationTestSuite))
+}
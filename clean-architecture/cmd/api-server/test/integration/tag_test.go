@@ -0,0 +1,348 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/api/content"
+	"go-api-server-sample/cmd/api-server/internal/api/tag"
+	"go-api-server-sample/cmd/api-server/internal/infrastructure/repositories"
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgresDriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type TagIntegrationTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	server     *httptest.Server
+	httpClient *http.Client
+}
+
+func (suite *TagIntegrationTestSuite) SetupSuite() {
+	ctx := context.Background()
+
+	// PostgreSQLコンテナ起動
+	container, err := postgres.Run(ctx,
+		"postgres:15",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	// DB接続とマイグレーション実行
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	suite.db, err = gorm.Open(postgresDriver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = suite.db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{})
+	suite.Require().NoError(err)
+
+	// ルーター設定
+	gin.SetMode(gin.TestMode)
+	router := suite.setupRouter()
+
+	// テストサーバー起動
+	suite.server = httptest.NewServer(router)
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *TagIntegrationTestSuite) TearDownSuite() {
+	ctx := context.Background()
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	if suite.container != nil {
+		suite.container.Terminate(ctx)
+	}
+}
+
+func (suite *TagIntegrationTestSuite) SetupSubTest() {
+	// テストデータクリーンアップ
+	suite.db.Exec("DELETE FROM content_revisions")
+	suite.db.Exec("DELETE FROM content_tags")
+	suite.db.Exec("DELETE FROM contents")
+	suite.db.Exec("DELETE FROM authors")
+	suite.db.Exec("DELETE FROM tags")
+
+	// リクエストで指定する作成者を登録する
+	suite.Require().NoError(suite.db.Create([]*entities.Author{{Name: "作成者"}}).Error)
+}
+
+func (suite *TagIntegrationTestSuite) setupRouter() *gin.Engine {
+	r := gin.New()
+
+	r.Use(gin.Recovery())
+	r.Use(middleware.CORS())
+
+	// リポジトリとAPIを直接初期化
+	contentAPI := content.NewContentAPI(repositories.NewContentRepository(suite.db))
+	tagAPI := tag.NewTagAPI(repositories.NewTagRepository(suite.db))
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ErrorHandler())
+
+	contents := v1.Group("/contents")
+	{
+		contents.POST("", contentAPI.Create)
+		contents.GET("", contentAPI.List)
+		contents.GET("/:id", contentAPI.GetByID)
+		contents.PUT("/:id", contentAPI.Update)
+	}
+
+	tags := v1.Group("/tags")
+	{
+		tags.GET("", tagAPI.List)
+		tags.PUT("/:id", tagAPI.Rename)
+		tags.POST("/:id/merge", tagAPI.Merge)
+	}
+
+	return r
+}
+
+func (suite *TagIntegrationTestSuite) send(method, path string, body interface{}) *http.Response {
+	jsonBytes, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, suite.server.URL+path, bytes.NewBuffer(jsonBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	return resp
+}
+
+// tagNames はコンテンツに付与されたタグ名の一覧を返す
+func tagNames(c *entities.Content) []string {
+	names := make([]string, 0, len(c.Tags))
+	for _, t := range c.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// createPublished はタグ付きの公開中コンテンツを作成する
+func (suite *TagIntegrationTestSuite) createPublished(title string, tags ...string) *entities.Content {
+	c, err := entities.NewContent(title, "本文", "article", "作成者", entities.RegisterAuthor(), entities.WithTags(tags))
+	suite.Require().NoError(err)
+	suite.Require().NoError(c.SubmitForReview())
+	suite.Require().NoError(c.Publish())
+	suite.Require().NoError(repositories.NewContentRepository(suite.db).Create(context.Background(), c))
+	return c
+}
+
+func (suite *TagIntegrationTestSuite) findTag(name string) *entities.Tag {
+	var t entities.Tag
+	suite.Require().NoError(suite.db.Where("name = ?", name).First(&t).Error)
+	return &t
+}
+
+func (suite *TagIntegrationTestSuite) listTitles(query string) []string {
+	resp := suite.send(http.MethodGet, "/api/v1/contents?"+query, nil)
+	defer resp.Body.Close()
+	suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var response content.ListContentsResponse
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+
+	titles := make([]string, 0, len(response.Contents))
+	for _, c := range response.Contents {
+		titles = append(titles, c.Title)
+	}
+	return titles
+}
+
+func (suite *TagIntegrationTestSuite) TestContentTags() {
+	suite.Run("作成時にタグを付与できる", func() {
+		// When
+		resp := suite.send(http.MethodPost, "/api/v1/contents", map[string]interface{}{
+			"title":        "タイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author":       "作成者",
+			"tags":         []string{"Go", "API", "Go"},
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusCreated, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.ElementsMatch(suite.T(), []string{"Go", "API"}, tagNames(&response))
+	})
+
+	suite.Run("更新時にタグを省略すると既存のタグが維持される", func() {
+		// Given
+		created := suite.createPublished("タイトル", "Go")
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/contents/%d", created.ID), map[string]string{
+			"title":        "新しいタイトル",
+			"body":         "本文",
+			"content_type": "article",
+			"author":       "作成者",
+		})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response entities.Content
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"Go"}, tagNames(&response))
+	})
+
+	suite.Run("tagsとtags_allで絞り込める", func() {
+		// Given
+		suite.createPublished("両方", "Go", "API")
+		suite.createPublished("Goのみ", "Go")
+		suite.createPublished("タグなし")
+
+		// Then
+		assert.ElementsMatch(suite.T(), []string{"両方", "Goのみ"}, suite.listTitles("tags=Go,API"))
+		assert.ElementsMatch(suite.T(), []string{"両方"}, suite.listTitles("tags_all=Go,API"))
+	})
+}
+
+func (suite *TagIntegrationTestSuite) TestTags() {
+	suite.Run("利用件数付きでタグ一覧を取得できる", func() {
+		// Given
+		suite.createPublished("記事1", "Go", "API")
+		suite.createPublished("記事2", "Go")
+
+		// When
+		resp := suite.send(http.MethodGet, "/api/v1/tags", nil)
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		var response tag.ListTagsResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		assert.NoError(suite.T(), err)
+		suite.Require().Len(response.Tags, 2)
+		assert.Equal(suite.T(), "Go", response.Tags[0].Name)
+		assert.Equal(suite.T(), int64(2), response.Tags[0].UsageCount)
+	})
+
+	suite.Run("タグ名を変更できる", func() {
+		// Given
+		suite.createPublished("記事1", "golang")
+		target := suite.findTag("golang")
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/tags/%d", target.ID), map[string]string{"name": "Go"})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+		assert.Equal(suite.T(), "Go", suite.findTag("Go").Name)
+	})
+
+	suite.Run("タグ名を変更するとタグが付いたコンテンツのETagが変わる", func() {
+		// Given: 変更前のETagでキャッシュしている
+		created := suite.createPublished("記事1", "golang")
+		target := suite.findTag("golang")
+
+		getResp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", created.ID), nil)
+		getResp.Body.Close()
+		suite.Require().Equal(http.StatusOK, getResp.StatusCode)
+		cachedETag := getResp.Header.Get("ETag")
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/tags/%d", target.ID), map[string]string{"name": "Go"})
+		resp.Body.Close()
+		suite.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		// Then: If-None-Match で304ではなく新しいタグ名が返される
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/contents/%d", suite.server.URL, created.ID), nil)
+		req.Header.Set("If-None-Match", cachedETag)
+		revalidated, err := suite.httpClient.Do(req)
+		suite.Require().NoError(err)
+		defer revalidated.Body.Close()
+
+		assert.Equal(suite.T(), http.StatusOK, revalidated.StatusCode)
+		assert.NotEqual(suite.T(), cachedETag, revalidated.Header.Get("ETag"))
+
+		var renamed entities.Content
+		suite.Require().NoError(json.NewDecoder(revalidated.Body).Decode(&renamed))
+		assert.Equal(suite.T(), []string{"Go"}, tagNames(&renamed))
+	})
+
+	suite.Run("既存のタグ名への変更は409エラー", func() {
+		// Given
+		suite.createPublished("記事1", "golang", "Go")
+		target := suite.findTag("golang")
+
+		// When
+		resp := suite.send(http.MethodPut, fmt.Sprintf("/api/v1/tags/%d", target.ID), map[string]string{"name": "Go"})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusConflict, resp.StatusCode)
+	})
+
+	suite.Run("タグをマージできる", func() {
+		// Given
+		created := suite.createPublished("記事1", "golang")
+		suite.createPublished("記事2", "Go")
+		source, target := suite.findTag("golang"), suite.findTag("Go")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/tags/%d/merge", source.ID), map[string]uint{"target_id": target.ID})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		getResp := suite.send(http.MethodGet, fmt.Sprintf("/api/v1/contents/%d", created.ID), nil)
+		defer getResp.Body.Close()
+
+		var merged entities.Content
+		suite.Require().NoError(json.NewDecoder(getResp.Body).Decode(&merged))
+		assert.Equal(suite.T(), []string{"Go"}, tagNames(&merged))
+	})
+
+	suite.Run("同じタグ同士のマージは400エラー", func() {
+		// Given
+		suite.createPublished("記事1", "Go")
+		target := suite.findTag("Go")
+
+		// When
+		resp := suite.send(http.MethodPost, fmt.Sprintf("/api/v1/tags/%d/merge", target.ID), map[string]uint{"target_id": target.ID})
+		defer resp.Body.Close()
+
+		// Then
+		assert.Equal(suite.T(), http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestTagIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(TagIntegrationTestSuite))
+}
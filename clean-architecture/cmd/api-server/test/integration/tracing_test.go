@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingIntegrationTestSuite struct {
+	suite.Suite
+	server     *httptest.Server
+	httpClient *http.Client
+	logs       *syncBuffer
+	recorder   *tracetest.SpanRecorder
+}
+
+func (suite *TracingIntegrationTestSuite) SetupSubTest() {
+	gin.SetMode(gin.TestMode)
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	suite.logs = &syncBuffer{}
+	suite.server = httptest.NewServer(suite.setupRouter())
+	suite.httpClient = &http.Client{
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (suite *TracingIntegrationTestSuite) TearDownSubTest() {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+}
+
+func (suite *TracingIntegrationTestSuite) setupRouter() *gin.Engine {
+	logger, err := logging.New(suite.logs, "debug", "json")
+	suite.Require().NoError(err)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(logger))
+	r.Use(middleware.Tracing())
+	r.Use(middleware.Logger())
+	r.Use(gin.Recovery())
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/contents/:id", func(c *gin.Context) {
+		// リポジトリなどコンテキストを受け取る処理のスパン
+		_, span := otel.Tracer("test").Start(c.Request.Context(), "query")
+		span.End()
+
+		if c.Param("id") == "0" {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	return r
+}
+
+func (suite *TracingIntegrationTestSuite) get(path, traceparent string) *http.Response {
+	req, _ := http.NewRequest(http.MethodGet, suite.server.URL+path, nil)
+	if traceparent != "" {
+		req.Header.Set("traceparent", traceparent)
+	}
+
+	resp, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	return resp
+}
+
+// serverSpan は記録されたスパンのうちサーバースパンを返す
+func (suite *TracingIntegrationTestSuite) serverSpan() sdktrace.ReadOnlySpan {
+	for _, span := range suite.recorder.Ended() {
+		if span.SpanKind() == trace.SpanKindServer {
+			return span
+		}
+	}
+	suite.FailNow("サーバースパンが記録されていません")
+	return nil
+}
+
+func (suite *TracingIntegrationTestSuite) TestTracing() {
+	suite.Run("traceparent で指定したトレースを引き継ぐ", func() {
+		// Given
+		traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID := "00f067aa0ba902b7"
+
+		// When
+		resp := suite.get("/api/v1/contents/1", "00-"+traceID+"-"+parentSpanID+"-01")
+
+		// Then
+		assert.Equal(suite.T(), http.StatusOK, resp.StatusCode)
+
+		span := suite.serverSpan()
+		assert.Equal(suite.T(), traceID, span.SpanContext().TraceID().String())
+		assert.Equal(suite.T(), parentSpanID, span.Parent().SpanID().String())
+		assert.Equal(suite.T(), "GET /api/v1/contents/:id", span.Name())
+
+		attrs := map[string]string{}
+		for _, attr := range span.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		assert.Equal(suite.T(), "/api/v1/contents/:id", attrs["http.route"])
+		assert.Equal(suite.T(), "200", attrs["http.response.status_code"])
+		assert.Equal(suite.T(), resp.Header.Get("X-Request-ID"), attrs["request_id"])
+
+		// ハンドラー内のスパンはサーバースパンの子になる
+		for _, child := range suite.recorder.Ended() {
+			if child.Name() == "query" {
+				assert.Equal(suite.T(), span.SpanContext().SpanID(), child.Parent().SpanID())
+			}
+		}
+	})
+
+	suite.Run("ログにトレースIDが付与される", func() {
+		// When
+		suite.get("/api/v1/contents/1", "")
+
+		// Then
+		span := suite.serverSpan()
+		assert.False(suite.T(), span.Parent().IsValid())
+
+		lines := suite.logs.lines()
+		suite.Require().NotEmpty(lines)
+		assert.Equal(suite.T(), span.SpanContext().TraceID().String(), lines[0]["trace_id"])
+		assert.Equal(suite.T(), span.SpanContext().SpanID().String(), lines[0]["span_id"])
+	})
+
+	suite.Run("5xxのレスポンスはスパンをエラーにする", func() {
+		// When
+		suite.get("/api/v1/contents/0", "")
+
+		// Then
+		assert.Equal(suite.T(), codes.Error, suite.serverSpan().Status().Code)
+	})
+}
+
+func TestTracingIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingIntegrationTestSuite))
+}
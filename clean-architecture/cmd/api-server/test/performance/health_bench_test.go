@@ -9,6 +9,7 @@ import (
 
 	"go-api-server-sample/cmd/api-server/internal/api/health"
 	"go-api-server-sample/cmd/api-server/internal/middleware"
+	"go-api-server-sample/internal/infrastructure/database"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,7 +21,8 @@ func setupHealthBenchmark(b *testing.B) (*httptest.Server, *http.Client) {
 	r.Use(gin.Recovery())
 	r.Use(middleware.CORS())
 
-	healthAPI := health.NewHealthAPI(getDB())
+	healthAPI := health.NewHealthAPI()
+	healthAPI.Register(health.DatabaseCheck, database.PingCheck(getDB()))
 	r.GET("/health", healthAPI.Check)
 
 	// テストサーバー起動
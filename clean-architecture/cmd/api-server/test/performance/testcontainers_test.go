@@ -57,7 +57,7 @@ func TestMain(m *testing.M) {
 	testDB = db
 
 	// マイグレーション実行
-	if err := db.AutoMigrate(&entities.Content{}); err != nil {
+	if err := db.AutoMigrate(&entities.Tag{}, &entities.Author{}, &entities.Content{}, &entities.ContentRevision{}); err != nil {
 		log.Fatalf("failed to migrate: %v", err)
 	}
 
@@ -77,11 +77,14 @@ func getDB() *gorm.DB {
 	return testDB
 }
 
-// cleanupDB はテストデータをクリーンアップする
+// cleanupDB はテストデータをクリーンアップし、リクエストで指定する作成者を登録する
 func cleanupDB(b *testing.B) {
-	if err := testDB.Exec("TRUNCATE TABLE contents RESTART IDENTITY CASCADE").Error; err != nil {
+	if err := testDB.Exec("TRUNCATE TABLE contents, content_revisions, content_tags, tags, authors RESTART IDENTITY CASCADE").Error; err != nil {
 		b.Fatalf("failed to cleanup database: %v", err)
 	}
+	if err := testDB.Create([]*entities.Author{{Name: "ベンチマーク作成者"}, {Name: "更新作成者"}}).Error; err != nil {
+		b.Fatalf("failed to create test authors: %v", err)
+	}
 }
 
 // createTestContent はテスト用コンテンツを作成する
@@ -91,6 +94,7 @@ func createTestContent(b *testing.B, title, body string) *entities.Content {
 		Body:        body,
 		ContentType: "article",
 		Author:      "ベンチマーク作成者",
+		Status:      entities.StatusPublished,
 	}
 	if err := testDB.Create(content).Error; err != nil {
 		b.Fatalf("failed to create test content: %v", err)
@@ -1,110 +1,235 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
+// Config はアプリケーション全体の設定
+// 設定ファイル（YAML / TOML）のキーは yaml タグの名前で、時間は "30s" や "24h" のように指定する
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logger   LoggerConfig
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Logger    LoggerConfig    `yaml:"logger"`
+	Content   ContentConfig   `yaml:"content"`
+	Auth      AuthConfig      `yaml:"auth"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Health    HealthConfig    `yaml:"health"`
 }
 
 type ServerConfig struct {
-	Port     string
-	GinMode  string
-	Timeout  time.Duration
-	Shutdown time.Duration
+	Port    string `yaml:"port"`
+	GinMode string `yaml:"gin_mode"`
+	// Timeout はリクエストの読み込みとレスポンスの書き込みそれぞれの制限時間
+	Timeout time.Duration `yaml:"timeout"`
+	// IdleTimeout はKeep-Aliveの接続で次のリクエストを待つ時間
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+	// Shutdown は停止時に処理中のリクエストの完了を待つ時間
+	Shutdown time.Duration `yaml:"shutdown_timeout"`
+	// ShutdownDelay は停止時にヘルスチェックを失敗させてから新しい接続の受け付けを止めるまでの待ち時間
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// TrustedProxies は X-Forwarded-For からクライアントIPを判定するプロキシのIPアドレスまたはCIDR
+	// （空の場合はどのプロキシも信頼せず、接続元のIPアドレスをクライアントIPとする）
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
-	Host            string
-	Port            int
-	User            string
-	Password        string
-	Database        string
-	SSLMode         string
-	MaxIdleConns    int
-	MaxOpenConns    int
-	ConnMaxLifetime time.Duration
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        Secret        `yaml:"password"`
+	Database        string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslmode"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
 }
 
 type LoggerConfig struct {
-	Level  string
-	Format string
+	// Level は出力するログの最低レベル（debug, info, warn, error, silent）
+	Level string `yaml:"level"`
+	// Format はログの形式（json, text）
+	Format string `yaml:"format"`
 }
 
-func Load() *Config {
-	return &Config{
-		Server:   loadServerConfig(),
-		Database: loadDatabaseConfig(),
-		Logger:   loadLoggerConfig(),
-	}
+type ContentConfig struct {
+	// RequireIfMatch が true の場合、更新・削除にIf-Matchヘッダーを必須にする
+	RequireIfMatch bool `yaml:"require_if_match"`
+	// CursorSecret は一覧のカーソルの署名鍵（未設定の場合は起動ごとに生成する）
+	CursorSecret Secret `yaml:"cursor_secret"`
+	// TrashRetention は削除済みコンテンツをゴミ箱に保持する期間（0の場合は自動で完全削除しない）
+	TrashRetention time.Duration `yaml:"trash_retention"`
+	// TrashPurgeInterval は保持期間を過ぎたコンテンツを完全削除する間隔
+	TrashPurgeInterval time.Duration `yaml:"trash_purge_interval"`
+	// IdempotencyTTL は Idempotency-Key を指定した作成リクエストのレスポンスを保存する期間
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl"`
+	// IdempotencyLease は処理中の Idempotency-Key を占有する期間で、過ぎても完了していないキーは再送で引き継げる
+	// （リクエストの処理にかかる最大の時間より長くする）
+	IdempotencyLease time.Duration `yaml:"idempotency_lease"`
+	// ContentTypeRefreshInterval は他のインスタンスで変更されたコンテンツタイプを読み込み直す間隔（0の場合は読み込み直さない）
+	ContentTypeRefreshInterval time.Duration `yaml:"content_type_refresh_interval"`
 }
 
-func loadServerConfig() ServerConfig {
-	return ServerConfig{
-		Port:     getEnv("PORT", "8080"),
-		GinMode:  getEnv("GIN_MODE", "release"),
-		Timeout:  time.Duration(getEnvAsInt("SERVER_TIMEOUT", 30)) * time.Second,
-		Shutdown: time.Duration(getEnvAsInt("SERVER_SHUTDOWN_TIMEOUT", 10)) * time.Second,
-	}
+type AuthConfig struct {
+	// Enabled が true の場合、/api/v1 以下へのリクエストにBearerトークンを必須にする
+	Enabled bool `yaml:"enabled"`
+	// JWTの検証鍵（少なくとも1つを指定する）
+	JWTHS256Secret        Secret `yaml:"jwt_hs256_secret"`
+	JWTRS256PublicKeyFile string `yaml:"jwt_rs256_public_key_file"`
+	JWTJWKSFile           string `yaml:"jwt_jwks_file"`
+	// JWTIssuer と JWTAudience は指定された場合のみ iss / aud クレームを検証する
+	JWTIssuer   string `yaml:"jwt_issuer"`
+	JWTAudience string `yaml:"jwt_audience"`
+	// JWTLeeway は有効期限などの検証で許容する時刻のずれ
+	JWTLeeway time.Duration `yaml:"jwt_leeway"`
 }
 
-func loadDatabaseConfig() DatabaseConfig {
-	return DatabaseConfig{
-		Host:            getEnv("DB_HOST", "localhost"),
-		Port:            getEnvAsInt("DB_PORT", 5432),
-		User:            getEnv("DB_USER", "api_user"),
-		Password:        getEnv("DB_PASSWORD", "api_password"),
-		Database:        getEnv("DB_NAME", "api_db"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
-		MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
-		MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
-		ConnMaxLifetime: time.Duration(getEnvAsInt("DB_CONN_MAX_LIFETIME", 3600)) * time.Second,
-	}
+type RateLimitConfig struct {
+	// Enabled が true の場合、/api/v1 以下へのリクエスト数をクライアントごとに制限する
+	Enabled bool `yaml:"enabled"`
+	// ReadRequests と WriteRequests は Period あたりに許可する読み取り・書き込みリクエスト数
+	// （0の場合は制限しない）
+	ReadRequests  int           `yaml:"read_requests"`
+	WriteRequests int           `yaml:"write_requests"`
+	Period        time.Duration `yaml:"period"`
+	// IPRequests は認証の前にクライアントIPごとに Period あたりに許可するリクエスト数（0の場合は制限しない）
+	// 認証後の制限と異なり、読み取り・書き込みを合わせて数える
+	IPRequests int `yaml:"ip_requests"`
 }
 
-func loadLoggerConfig() LoggerConfig {
-	return LoggerConfig{
-		Level:  getEnv("LOG_LEVEL", "info"),
-		Format: getEnv("LOG_FORMAT", "json"),
-	}
+type MetricsConfig struct {
+	// Enabled が true の場合、/metrics でPrometheus形式のメトリクスを公開する
+	Enabled bool `yaml:"enabled"`
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+type TracingConfig struct {
+	// Exporter はスパンの送信先（none, stdout, otlp）
+	Exporter string `yaml:"exporter"`
+	// OTLPEndpoint は otlp の場合の送信先（host:port）
+	OTLPEndpoint string `yaml:"otlp_endpoint"`
+	// OTLPInsecure が true の場合はTLSを使わずに送信する
+	OTLPInsecure bool `yaml:"otlp_insecure"`
+	// SampleRatio は記録するトレースの割合（0〜1）
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// ServiceName はスパンに付与するサービス名
+	ServiceName string `yaml:"service_name"`
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
-	}
+type HealthConfig struct {
+	// CacheTTL は依存先のチェック結果をキャッシュする期間
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// CheckTimeout はチェック1つあたりの制限時間
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+	// PoolMaxUsage は使用中の接続数がコネクションプールの上限に占める割合がこれ以上の場合に警告する（0〜1）
+	PoolMaxUsage float64 `yaml:"pool_max_usage"`
+	// DiskPath は空き容量を確認するディレクトリ（空の場合は確認しない）
+	DiskPath string `yaml:"disk_path"`
+	// DiskMinFreeMB は DiskPath に必要な空き容量（MB）
+	DiskMinFreeMB int `yaml:"disk_min_free_mb"`
+}
+
+// Load は既定値に設定ファイル、環境変数の順に値を上書きした設定を返す
+// path が空の場合は環境変数 CONFIG_FILE のファイルを読み込み、どちらも指定されていない場合は読み込まない
+// 不正な値がある場合は、すべての問題をまとめたエラーを返す
+func Load(path string) (*Config, error) {
+	cfg := Default()
 
-	value, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return defaultValue
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
 	}
 
-	return value
+	// 解釈できなかった環境変数と不正な設定値をまとめて報告する
+	if err := errors.Join(applyEnv(cfg), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-func getEnvAsBool(key string, defaultValue bool) bool {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
+// Default は既定値の設定を返す
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:           "8080",
+			GinMode:        "release",
+			Timeout:        30 * time.Second,
+			IdleTimeout:    60 * time.Second,
+			Shutdown:       10 * time.Second,
+			ShutdownDelay:  5 * time.Second,
+			TrustedProxies: []string{},
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "api_user",
+			Password:        "api_password",
+			Database:        "api_db",
+			SSLMode:         "disable",
+			MaxIdleConns:    10,
+			MaxOpenConns:    100,
+			ConnMaxLifetime: time.Hour,
+		},
+		Logger: LoggerConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Content: ContentConfig{
+			RequireIfMatch:             false,
+			TrashRetention:             30 * 24 * time.Hour,
+			TrashPurgeInterval:         time.Hour,
+			IdempotencyTTL:             24 * time.Hour,
+			IdempotencyLease:           time.Minute,
+			ContentTypeRefreshInterval: 30 * time.Second,
+		},
+		Auth: AuthConfig{
+			Enabled:   false,
+			JWTLeeway: 30 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       true,
+			ReadRequests:  300,
+			WriteRequests: 60,
+			Period:        time.Minute,
+			IPRequests:    600,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "localhost:4318",
+			OTLPInsecure: false,
+			SampleRatio:  1.0,
+			ServiceName:  "go-api-server-sample",
+		},
+		Health: HealthConfig{
+			CacheTTL:      5 * time.Second,
+			CheckTimeout:  2 * time.Second,
+			PoolMaxUsage:  0.9,
+			DiskPath:      "",
+			DiskMinFreeMB: 100,
+		},
 	}
+}
 
-	value, err := strconv.ParseBool(valueStr)
-	if err != nil {
-		return defaultValue
+// Dump は設定を設定ファイルと同じ形式（YAML）で w に出力する
+// Secret の値は伏せて出力する
+func (c *Config) Dump(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("設定の出力に失敗しました: %w", err)
 	}
-
-	return value
+	return encoder.Close()
 }
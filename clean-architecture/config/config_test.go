@@ -0,0 +1,304 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigTestSuite) SetupSubTest() {
+	// 実行環境の環境変数に左右されないよう、テストで使う環境変数を未設定にする
+	for _, key := range []string{"CONFIG_FILE", "PORT", "GIN_MODE", "SERVER_TIMEOUT", "SERVER_SHUTDOWN_DELAY", "SERVER_TRUSTED_PROXIES", "DB_HOST", "DB_PASSWORD", "DB_PASSWORD_FILE", "DB_MAX_OPEN_CONNS", "LOG_LEVEL", "TRACING_SAMPLE_RATIO"} {
+		suite.T().Setenv(key, "")
+	}
+}
+
+func (suite *ConfigTestSuite) writeFile(name, content string) string {
+	path := filepath.Join(suite.T().TempDir(), name)
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (suite *ConfigTestSuite) TestLoad() {
+	suite.Run("指定がない場合は既定値", func() {
+		// When
+		cfg, err := Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), Default(), cfg)
+	})
+
+	suite.Run("YAMLファイルの値を環境変数で上書きする", func() {
+		// Given
+		path := suite.writeFile("config.yaml", `
+server:
+  port: "9090"
+  timeout: 45s
+database:
+  host: db.internal
+  max_open_conns: 20
+`)
+		suite.T().Setenv("DB_HOST", "db.override")
+
+		// When
+		cfg, err := Load(path)
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "9090", cfg.Server.Port)
+		assert.Equal(suite.T(), 45*time.Second, cfg.Server.Timeout)
+		assert.Equal(suite.T(), "db.override", cfg.Database.Host)
+		assert.Equal(suite.T(), 20, cfg.Database.MaxOpenConns)
+		assert.Equal(suite.T(), "api_user", cfg.Database.User)
+	})
+
+	suite.Run("CONFIG_FILE のTOMLファイルを読み込む", func() {
+		// Given
+		path := suite.writeFile("config.toml", `
+[logger]
+level = "debug"
+
+[content]
+trash_retention = "168h"
+
+[tracing]
+sample_ratio = 0.5
+`)
+		suite.T().Setenv("CONFIG_FILE", path)
+
+		// When
+		cfg, err := Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "debug", cfg.Logger.Level)
+		assert.Equal(suite.T(), 7*24*time.Hour, cfg.Content.TrashRetention)
+		assert.Equal(suite.T(), 0.5, cfg.Tracing.SampleRatio)
+	})
+
+	suite.Run("設定ファイルの不明なキーはエラー", func() {
+		// Given
+		path := suite.writeFile("config.yaml", "server:\n  prot: \"9090\"\n")
+
+		// When
+		_, err := Load(path)
+
+		// Then
+		suite.Require().Error(err)
+		assert.Contains(suite.T(), err.Error(), "prot")
+	})
+
+	suite.Run("対応していない形式の設定ファイルはエラー", func() {
+		// Given
+		path := suite.writeFile("config.json", "{}")
+
+		// When
+		_, err := Load(path)
+
+		// Then
+		assert.Error(suite.T(), err)
+	})
+
+	suite.Run("停止時の待ち時間は既定で5秒、0を指定すると待たない", func() {
+		// When
+		cfg, err := Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), 5*time.Second, cfg.Server.ShutdownDelay)
+
+		// Given
+		suite.T().Setenv("SERVER_SHUTDOWN_DELAY", "0")
+
+		// When
+		cfg, err = Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Zero(suite.T(), cfg.Server.ShutdownDelay)
+	})
+
+	suite.Run("カンマ区切りの環境変数を一覧として読み込む", func() {
+		// Given
+		suite.T().Setenv("SERVER_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.10,")
+
+		// When
+		cfg, err := Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), []string{"10.0.0.0/8", "192.168.1.10"}, cfg.Server.TrustedProxies)
+	})
+
+	suite.Run("解釈できない環境変数はすべてエラーに含める", func() {
+		// Given
+		suite.T().Setenv("SERVER_TIMEOUT", "abc")
+		suite.T().Setenv("DB_MAX_OPEN_CONNS", "many")
+		suite.T().Setenv("TRACING_SAMPLE_RATIO", "half")
+
+		// When
+		_, err := Load("")
+
+		// Then
+		suite.Require().Error(err)
+		assert.Contains(suite.T(), err.Error(), `SERVER_TIMEOUT: 整数を指定してください（"abc"）`)
+		assert.Contains(suite.T(), err.Error(), "DB_MAX_OPEN_CONNS")
+		assert.Contains(suite.T(), err.Error(), "TRACING_SAMPLE_RATIO")
+	})
+}
+
+func (suite *ConfigTestSuite) TestSecretFile() {
+	suite.Run("_FILE で指定したファイルから秘密の値を読み込む", func() {
+		// Given
+		suite.T().Setenv("DB_PASSWORD_FILE", suite.writeFile("db_password", "s3cret pass\n"))
+
+		// When
+		cfg, err := Load("")
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), "s3cret pass", cfg.Database.Password.Reveal())
+	})
+
+	suite.Run("値と _FILE を同時に指定した場合はエラー", func() {
+		// Given
+		suite.T().Setenv("DB_PASSWORD", "from-env")
+		suite.T().Setenv("DB_PASSWORD_FILE", suite.writeFile("db_password", "from-file"))
+
+		// When
+		_, err := Load("")
+
+		// Then
+		suite.Require().Error(err)
+		assert.Contains(suite.T(), err.Error(), "DB_PASSWORD_FILE")
+		assert.NotContains(suite.T(), err.Error(), "from-")
+	})
+
+	suite.Run("存在しないファイルはエラー", func() {
+		// Given
+		suite.T().Setenv("DB_PASSWORD_FILE", filepath.Join(suite.T().TempDir(), "missing"))
+
+		// When
+		_, err := Load("")
+
+		// Then
+		suite.Require().Error(err)
+		assert.Contains(suite.T(), err.Error(), "DB_PASSWORD_FILE")
+	})
+}
+
+func (suite *ConfigTestSuite) TestSecret() {
+	suite.Run("書式を指定して出力しても値を伏せる", func() {
+		// Given
+		secret := Secret("p@ssw0rd")
+
+		// When
+		outputs := []string{
+			fmt.Sprint(secret),
+			fmt.Sprintf("%v %s %q %x %+v %#v", secret, secret, secret, secret, secret, secret),
+			fmt.Sprintf("%+v", Default().Database),
+			fmt.Sprintf("%#v", Default().Database),
+		}
+
+		// Then
+		for _, output := range outputs {
+			assert.NotContains(suite.T(), output, "p@ssw0rd")
+			assert.NotContains(suite.T(), output, "api_password")
+			assert.Contains(suite.T(), output, "[REDACTED]")
+		}
+		assert.Equal(suite.T(), "p@ssw0rd", secret.Reveal())
+	})
+
+	suite.Run("JSONでも値を伏せる", func() {
+		// When
+		data, err := json.Marshal(Default())
+
+		// Then
+		suite.Require().NoError(err)
+		assert.NotContains(suite.T(), string(data), "api_password")
+		assert.Contains(suite.T(), string(data), `"Password":"[REDACTED]"`)
+	})
+
+	suite.Run("未設定の場合は空文字列", func() {
+		assert.Equal(suite.T(), "", Secret("").String())
+	})
+}
+
+func (suite *ConfigTestSuite) TestValidate() {
+	suite.Run("既定値は正しい", func() {
+		assert.NoError(suite.T(), Default().Validate())
+	})
+
+	suite.Run("不正な値をすべてエラーに含める", func() {
+		// Given
+		cfg := Default()
+		cfg.Server.Port = "99999"
+		cfg.Server.GinMode = "production"
+		cfg.Server.TrustedProxies = []string{"10.0.0.0/8", "proxy.internal"}
+		cfg.Database.Host = ""
+		cfg.Auth.Enabled = true
+		cfg.Tracing.SampleRatio = 2
+
+		// When
+		err := cfg.Validate()
+
+		// Then
+		suite.Require().Error(err)
+		for _, field := range []string{"server.port", "server.gin_mode", "server.trusted_proxies", "database.host", "auth:", "tracing.sample_ratio"} {
+			assert.Contains(suite.T(), err.Error(), field)
+		}
+		assert.Contains(suite.T(), err.Error(), "proxy.internal")
+		assert.NotContains(suite.T(), err.Error(), "10.0.0.0/8")
+	})
+}
+
+func (suite *ConfigTestSuite) TestDump() {
+	suite.Run("秘密の値を伏せて出力する", func() {
+		// Given
+		cfg := Default()
+		cfg.Content.CursorSecret = "cursor-secret"
+		cfg.Auth.JWTHS256Secret = "jwt-secret"
+
+		// When
+		var buf bytes.Buffer
+		suite.Require().NoError(cfg.Dump(&buf))
+
+		// Then
+		output := buf.String()
+		assert.NotContains(suite.T(), output, "api_password")
+		assert.NotContains(suite.T(), output, "cursor-secret")
+		assert.NotContains(suite.T(), output, "jwt-secret")
+		assert.Contains(suite.T(), output, "password: '[REDACTED]'")
+		assert.Contains(suite.T(), output, "timeout: 30s")
+	})
+
+	suite.Run("出力した設定はそのまま設定ファイルとして読み込める", func() {
+		// Given
+		var buf bytes.Buffer
+		suite.Require().NoError(Default().Dump(&buf))
+		path := suite.writeFile("dump.yaml", buf.String())
+
+		// When
+		cfg, err := Load(path)
+
+		// Then
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), Default().Server, cfg.Server)
+	})
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
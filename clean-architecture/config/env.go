@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// applyEnv は環境変数が設定されている項目を上書きする
+// 空の環境変数は設定されていないものとして扱い、解釈できない値はすべてまとめてエラーにする
+// 秘密の値は <名前>_FILE でファイルのパスを指定して読み込むこともできる（Docker・Kubernetesのシークレット向け）
+func applyEnv(cfg *Config) error {
+	env := &envLoader{}
+
+	env.string("PORT", &cfg.Server.Port)
+	env.string("GIN_MODE", &cfg.Server.GinMode)
+	env.duration("SERVER_TIMEOUT", time.Second, &cfg.Server.Timeout)
+	env.duration("SERVER_IDLE_TIMEOUT", time.Second, &cfg.Server.IdleTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", time.Second, &cfg.Server.Shutdown)
+	env.duration("SERVER_SHUTDOWN_DELAY", time.Second, &cfg.Server.ShutdownDelay)
+	env.list("SERVER_TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+
+	env.string("DB_HOST", &cfg.Database.Host)
+	env.int("DB_PORT", &cfg.Database.Port)
+	env.string("DB_USER", &cfg.Database.User)
+	env.secret("DB_PASSWORD", &cfg.Database.Password)
+	env.string("DB_NAME", &cfg.Database.Database)
+	env.string("DB_SSLMODE", &cfg.Database.SSLMode)
+	env.int("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	env.int("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	env.duration("DB_CONN_MAX_LIFETIME", time.Second, &cfg.Database.ConnMaxLifetime)
+
+	env.string("LOG_LEVEL", &cfg.Logger.Level)
+	env.string("LOG_FORMAT", &cfg.Logger.Format)
+
+	env.bool("CONTENT_REQUIRE_IF_MATCH", &cfg.Content.RequireIfMatch)
+	env.secret("CONTENT_CURSOR_SECRET", &cfg.Content.CursorSecret)
+	env.duration("CONTENT_TRASH_RETENTION_DAYS", 24*time.Hour, &cfg.Content.TrashRetention)
+	env.duration("CONTENT_TRASH_PURGE_INTERVAL", time.Second, &cfg.Content.TrashPurgeInterval)
+	env.duration("CONTENT_IDEMPOTENCY_TTL_HOURS", time.Hour, &cfg.Content.IdempotencyTTL)
+	env.duration("CONTENT_IDEMPOTENCY_LEASE", time.Second, &cfg.Content.IdempotencyLease)
+	env.duration("CONTENT_TYPE_REFRESH_INTERVAL", time.Second, &cfg.Content.ContentTypeRefreshInterval)
+
+	env.bool("AUTH_ENABLED", &cfg.Auth.Enabled)
+	env.secret("AUTH_JWT_HS256_SECRET", &cfg.Auth.JWTHS256Secret)
+	env.string("AUTH_JWT_RS256_PUBLIC_KEY_FILE", &cfg.Auth.JWTRS256PublicKeyFile)
+	env.string("AUTH_JWT_JWKS_FILE", &cfg.Auth.JWTJWKSFile)
+	env.string("AUTH_JWT_ISSUER", &cfg.Auth.JWTIssuer)
+	env.string("AUTH_JWT_AUDIENCE", &cfg.Auth.JWTAudience)
+	env.duration("AUTH_JWT_LEEWAY", time.Second, &cfg.Auth.JWTLeeway)
+
+	env.bool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled)
+	env.int("RATE_LIMIT_READ_REQUESTS", &cfg.RateLimit.ReadRequests)
+	env.int("RATE_LIMIT_WRITE_REQUESTS", &cfg.RateLimit.WriteRequests)
+	env.int("RATE_LIMIT_IP_REQUESTS", &cfg.RateLimit.IPRequests)
+	env.duration("RATE_LIMIT_PERIOD", time.Second, &cfg.RateLimit.Period)
+
+	env.bool("METRICS_ENABLED", &cfg.Metrics.Enabled)
+
+	env.string("TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	env.string("TRACING_OTLP_ENDPOINT", &cfg.Tracing.OTLPEndpoint)
+	env.bool("TRACING_OTLP_INSECURE", &cfg.Tracing.OTLPInsecure)
+	env.float("TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+	env.string("TRACING_SERVICE_NAME", &cfg.Tracing.ServiceName)
+
+	env.duration("HEALTH_CACHE_TTL", time.Second, &cfg.Health.CacheTTL)
+	env.duration("HEALTH_CHECK_TIMEOUT", time.Second, &cfg.Health.CheckTimeout)
+	env.float("HEALTH_POOL_MAX_USAGE", &cfg.Health.PoolMaxUsage)
+	env.string("HEALTH_DISK_PATH", &cfg.Health.DiskPath)
+	env.int("HEALTH_DISK_MIN_FREE_MB", &cfg.Health.DiskMinFreeMB)
+
+	return errors.Join(env.errs...)
+}
+
+// envLoader は環境変数の値を解釈し、解釈できなかった環境変数のエラーを集める
+type envLoader struct {
+	errs []error
+}
+
+func (l *envLoader) lookup(key string) (string, bool) {
+	value := os.Getenv(key)
+	return value, value != ""
+}
+
+func (l *envLoader) string(key string, dst *string) {
+	if value, ok := l.lookup(key); ok {
+		*dst = value
+	}
+}
+
+// list はカンマ区切りで指定された値を、前後の空白と空の要素を除いて読み込む
+func (l *envLoader) list(key string, dst *[]string) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return
+	}
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	*dst = values
+}
+
+// secret は key または key_FILE で指定されたファイルから秘密の値を読み込む
+// ファイルの末尾の改行は取り除く
+func (l *envLoader) secret(key string, dst *Secret) {
+	fileKey := key + "_FILE"
+	value, hasValue := l.lookup(key)
+	path, hasFile := l.lookup(fileKey)
+
+	switch {
+	case hasValue && hasFile:
+		l.errs = append(l.errs, fmt.Errorf("%s: %s と同時に指定できません", fileKey, key))
+	case hasFile:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s: ファイルを読み込めません: %w", fileKey, err))
+			return
+		}
+		*dst = Secret(strings.TrimRight(string(content), "\r\n"))
+	case hasValue:
+		*dst = Secret(value)
+	}
+}
+
+func (l *envLoader) int(key string, dst *int) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: 整数を指定してください（%q）", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (l *envLoader) bool(key string, dst *bool) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: true または false を指定してください（%q）", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (l *envLoader) float(key string, dst *float64) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: 数値を指定してください（%q）", key, value))
+		return
+	}
+	*dst = parsed
+}
+
+// duration は unit 単位の整数で指定された時間を読み込む（SERVER_TIMEOUT=30 は30秒）
+func (l *envLoader) duration(key string, unit time.Duration, dst *time.Duration) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: 整数を指定してください（%q）", key, value))
+		return
+	}
+	*dst = time.Duration(parsed) * unit
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile は設定ファイルの値で cfg を上書きする。形式は拡張子（.yaml / .yml / .toml）で判定する
+// ファイルに書かれていない項目は cfg の値のまま残し、存在しない項目がある場合はエラーにする
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("設定ファイルを読み込めません: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	case ".toml":
+		// TOMLもYAMLと同じ規則（時間の書式など）で読み込むため、YAMLに変換してから読み込む
+		if data, err = tomlToYAML(data); err != nil {
+			return fmt.Errorf("設定ファイル %s の読み込みに失敗しました: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("設定ファイル %s の形式に対応していません（.yaml / .yml / .toml を指定してください）", path)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("設定ファイル %s の読み込みに失敗しました: %w", path, err)
+	}
+	return nil
+}
+
+func tomlToYAML(data []byte) ([]byte, error) {
+	var values map[string]any
+	if err := toml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(values)
+}
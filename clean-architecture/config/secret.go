@@ -0,0 +1,51 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// redacted は秘密の値を伏せるときに代わりに出力する文字列
+const redacted = "[REDACTED]"
+
+// Secret はパスワードや署名鍵などの秘密の値
+// fmt や JSON・YAML で出力しても値は伏せられ、実際の値は Reveal でのみ取り出せる
+type Secret string
+
+// Reveal は秘密の値をそのまま返す
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String は値が設定されている場合は伏せた文字列を返す
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString は %#v でも値を出力しないようにする
+func (s Secret) GoString() string {
+	return fmt.Sprintf("config.Secret(%q)", s.String())
+}
+
+// Format は %x など String を使わない書式でも値を出力しないようにする
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, s.GoString())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", s.String())
+	default:
+		fmt.Fprint(f, s.String())
+	}
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s Secret) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
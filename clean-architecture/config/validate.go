@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validate は設定値を検証し、問題があればすべてまとめたエラーを返す
+// 項目名は設定ファイルのキー（server.port など）で示す
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		v.addf("server.port", "1〜65535の整数を指定してください（%q）", c.Server.Port)
+	}
+	v.oneOf("server.gin_mode", c.Server.GinMode, "debug", "release", "test")
+	v.check(c.Server.Timeout > 0, "server.timeout", "0より大きい時間を指定してください")
+	v.check(c.Server.IdleTimeout >= 0, "server.idle_timeout", "0以上の時間を指定してください")
+	v.check(c.Server.Shutdown > 0, "server.shutdown_timeout", "0より大きい時間を指定してください")
+	v.check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay", "0以上の時間を指定してください")
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				v.addf("server.trusted_proxies", "IPアドレスまたはCIDRを指定してください（%q）", proxy)
+			}
+		}
+	}
+
+	v.required("database.host", c.Database.Host)
+	v.check(c.Database.Port >= 1 && c.Database.Port <= 65535, "database.port", fmt.Sprintf("1〜65535の整数を指定してください（%d）", c.Database.Port))
+	v.required("database.user", c.Database.User)
+	v.required("database.name", c.Database.Database)
+	v.oneOf("database.sslmode", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", "0以上の整数を指定してください")
+	v.check(c.Database.MaxOpenConns >= 0, "database.max_open_conns", "0以上の整数を指定してください")
+	v.check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime", "0以上の時間を指定してください")
+
+	v.oneOf("logger.level", strings.ToLower(c.Logger.Level), "debug", "info", "warn", "error", "silent")
+	v.oneOf("logger.format", strings.ToLower(c.Logger.Format), "json", "text")
+
+	v.check(c.Content.TrashRetention >= 0, "content.trash_retention", "0以上の時間を指定してください")
+	v.check(c.Content.TrashPurgeInterval >= 0, "content.trash_purge_interval", "0以上の時間を指定してください")
+	v.check(c.Content.ContentTypeRefreshInterval >= 0, "content.content_type_refresh_interval", "0以上の時間を指定してください")
+	v.check(c.Content.IdempotencyTTL > 0, "content.idempotency_ttl", "0より大きい時間を指定してください")
+	v.check(c.Content.IdempotencyLease > 0, "content.idempotency_lease", "0より大きい時間を指定してください")
+
+	if c.Auth.Enabled {
+		v.check(c.Auth.JWTHS256Secret != "" || c.Auth.JWTRS256PublicKeyFile != "" || c.Auth.JWTJWKSFile != "",
+			"auth", "有効にする場合は jwt_hs256_secret、jwt_rs256_public_key_file、jwt_jwks_file のいずれかを指定してください")
+	}
+	v.check(c.Auth.JWTLeeway >= 0, "auth.jwt_leeway", "0以上の時間を指定してください")
+
+	v.check(c.RateLimit.ReadRequests >= 0, "rate_limit.read_requests", "0以上の整数を指定してください")
+	v.check(c.RateLimit.WriteRequests >= 0, "rate_limit.write_requests", "0以上の整数を指定してください")
+	v.check(c.RateLimit.IPRequests >= 0, "rate_limit.ip_requests", "0以上の整数を指定してください")
+	if c.RateLimit.Enabled {
+		v.check(c.RateLimit.Period > 0, "rate_limit.period", "0より大きい時間を指定してください")
+	}
+
+	v.oneOf("tracing.exporter", strings.ToLower(c.Tracing.Exporter), "none", "stdout", "otlp")
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", fmt.Sprintf("0〜1の数値を指定してください（%g）", c.Tracing.SampleRatio))
+	if strings.EqualFold(c.Tracing.Exporter, "otlp") {
+		v.required("tracing.otlp_endpoint", c.Tracing.OTLPEndpoint)
+	}
+	v.required("tracing.service_name", c.Tracing.ServiceName)
+
+	v.check(c.Health.CacheTTL >= 0, "health.cache_ttl", "0以上の時間を指定してください")
+	v.check(c.Health.CheckTimeout > 0, "health.check_timeout", "0より大きい時間を指定してください")
+	v.check(c.Health.PoolMaxUsage > 0 && c.Health.PoolMaxUsage <= 1, "health.pool_max_usage", fmt.Sprintf("0より大きく1以下の数値を指定してください（%g）", c.Health.PoolMaxUsage))
+	v.check(c.Health.DiskMinFreeMB >= 0, "health.disk_min_free_mb", "0以上の整数を指定してください")
+
+	return errors.Join(v.errs...)
+}
+
+// validator は検証で見つかった問題を集める
+type validator struct {
+	errs []error
+}
+
+func (v *validator) addf(field, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) check(ok bool, field, message string) {
+	if !ok {
+		v.addf(field, "%s", message)
+	}
+}
+
+func (v *validator) required(field, value string) {
+	v.check(value != "", field, "指定してください")
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.addf(field, "%s のいずれかを指定してください（%q）", strings.Join(allowed, ", "), value)
+	}
+}
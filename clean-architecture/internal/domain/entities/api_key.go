@@ -0,0 +1,145 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// APIキーに付与できるスコープ
+const (
+	APIKeyScopeContentsRead  = "contents:read"
+	APIKeyScopeContentsWrite = "contents:write"
+)
+
+var supportedAPIKeyScopes = map[string]bool{
+	APIKeyScopeContentsRead:  true,
+	APIKeyScopeContentsWrite: true,
+}
+
+// apiKeyPrefix はAPIキーの平文の先頭に付ける識別子で、設定ファイルなどに紛れたキーを見つけやすくする
+const apiKeyPrefix = "gas_"
+
+// apiKeyDisplayLength は一覧でキーを識別するために保存する平文の先頭の文字数
+const apiKeyDisplayLength = 12
+
+var (
+	ErrInvalidAPIKeyName    = errors.New("APIキー名は1文字以上100文字以下で入力してください")
+	ErrInvalidAPIKeyScope   = errors.New("APIキーのスコープが不正です")
+	ErrAPIKeyScopeRequired  = errors.New("APIキーのスコープを1つ以上指定してください")
+	ErrAPIKeyExpiryInPast   = errors.New("APIキーの有効期限は現在より後の日時を指定してください")
+	ErrAPIKeyAlreadyRevoked = errors.New("APIキーは既に失効しています")
+)
+
+// APIKey は対話的にログインできないクライアントが使用するAPIキー
+// 平文のキーは作成時にのみ返し、データベースにはハッシュ値のみを保存する
+type APIKey struct {
+	ID   uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name string `gorm:"type:varchar(100);not null" json:"name"`
+	// Prefix は平文のキーの先頭部分で、一覧でキーを識別するために使用する
+	Prefix     string     `gorm:"type:varchar(20);not null" json:"prefix"`
+	KeyHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Scopes     []string   `gorm:"type:jsonb;serializer:json;not null" json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// CreatedBy はキーを発行したプリンシパルの識別子で、トークンの sub は長さが制限されないため長さを制限しない列に保存する
+	CreatedBy string    `gorm:"type:text;not null;default:''" json:"created_by"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// NewAPIKey は新しいAPIキーを作成し、平文のキーとともに返す
+// expiresAt が nil の場合は無期限のキーになり、createdBy には発行したプリンシパルの識別子を指定する
+func NewAPIKey(name string, scopes []string, expiresAt *time.Time, createdBy string) (*APIKey, string, error) {
+	key := &APIKey{
+		Name:      strings.TrimSpace(name),
+		Scopes:    dedupeScopes(scopes),
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrAPIKeyExpiryInPast
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	plaintext := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key.Prefix = plaintext[:apiKeyDisplayLength]
+	key.KeyHash = HashAPIKey(plaintext)
+
+	return key, plaintext, nil
+}
+
+func (k *APIKey) Validate() error {
+	nameLen := utf8.RuneCountInString(k.Name)
+	if nameLen == 0 || nameLen > 100 {
+		return ErrInvalidAPIKeyName
+	}
+
+	if len(k.Scopes) == 0 {
+		return ErrAPIKeyScopeRequired
+	}
+	for _, scope := range k.Scopes {
+		if !supportedAPIKeyScopes[scope] {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+
+	return nil
+}
+
+// HashAPIKey は平文のキーから保存・照合に使用するハッシュ値を求める
+// キーは十分な長さの乱数のため、パスワードのような低速なハッシュは使用しない
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsActive はキーが失効しておらず、有効期限内であるかを返す
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Revoke はキーを失効させる
+func (k *APIKey) Revoke(now time.Time) error {
+	if k.RevokedAt != nil {
+		return ErrAPIKeyAlreadyRevoked
+	}
+
+	k.RevokedAt = &now
+	return nil
+}
+
+// dedupeScopes は前後の空白を除いたスコープの重複をまとめる
+func dedupeScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *APIKeyTestSuite) TestNewAPIKey() {
+	suite.Run("正常なパラメータでAPIキーが作成できる", func() {
+		expiresAt := time.Now().Add(24 * time.Hour)
+
+		key, plaintext, err := NewAPIKey(" 取り込みバッチ ", []string{"contents:read", " contents:write", "contents:read"}, &expiresAt, "admin")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "取り込みバッチ", key.Name)
+		assert.Equal(suite.T(), []string{"contents:read", "contents:write"}, key.Scopes)
+		assert.Equal(suite.T(), &expiresAt, key.ExpiresAt)
+		assert.Equal(suite.T(), "admin", key.CreatedBy)
+		assert.True(suite.T(), strings.HasPrefix(plaintext, "gas_"))
+		assert.True(suite.T(), strings.HasPrefix(plaintext, key.Prefix))
+		assert.Equal(suite.T(), HashAPIKey(plaintext), key.KeyHash)
+		assert.NotContains(suite.T(), key.KeyHash, plaintext)
+	})
+
+	suite.Run("作成するたびに異なるキーが生成される", func() {
+		_, first, _ := NewAPIKey("キー", []string{"contents:read"}, nil, "")
+		_, second, _ := NewAPIKey("キー", []string{"contents:read"}, nil, "")
+
+		assert.NotEqual(suite.T(), first, second)
+	})
+
+	suite.Run("空の名前はエラー", func() {
+		_, _, err := NewAPIKey(" ", []string{"contents:read"}, nil, "")
+		assert.Equal(suite.T(), ErrInvalidAPIKeyName, err)
+	})
+
+	suite.Run("スコープがない場合はエラー", func() {
+		_, _, err := NewAPIKey("キー", nil, nil, "")
+		assert.Equal(suite.T(), ErrAPIKeyScopeRequired, err)
+	})
+
+	suite.Run("未定義のスコープはエラー", func() {
+		_, _, err := NewAPIKey("キー", []string{"contents:admin"}, nil, "")
+		assert.Equal(suite.T(), ErrInvalidAPIKeyScope, err)
+	})
+
+	suite.Run("過去の有効期限はエラー", func() {
+		expiresAt := time.Now().Add(-time.Minute)
+
+		_, _, err := NewAPIKey("キー", []string{"contents:read"}, &expiresAt, "")
+
+		assert.Equal(suite.T(), ErrAPIKeyExpiryInPast, err)
+	})
+}
+
+func (suite *APIKeyTestSuite) TestIsActive() {
+	now := time.Now()
+
+	suite.Run("無期限のキーは有効", func() {
+		key, _, _ := NewAPIKey("キー", []string{"contents:read"}, nil, "")
+		assert.True(suite.T(), key.IsActive(now))
+	})
+
+	suite.Run("有効期限を過ぎたキーは無効", func() {
+		expiresAt := now.Add(time.Hour)
+		key, _, _ := NewAPIKey("キー", []string{"contents:read"}, &expiresAt, "")
+
+		assert.True(suite.T(), key.IsActive(now))
+		assert.False(suite.T(), key.IsActive(expiresAt))
+	})
+
+	suite.Run("失効したキーは無効", func() {
+		key, _, _ := NewAPIKey("キー", []string{"contents:read"}, nil, "")
+
+		assert.NoError(suite.T(), key.Revoke(now))
+		assert.False(suite.T(), key.IsActive(now))
+	})
+}
+
+func (suite *APIKeyTestSuite) TestRevoke() {
+	suite.Run("失効済みのキーは再度失効できない", func() {
+		key, _, _ := NewAPIKey("キー", []string{"contents:read"}, nil, "")
+		revokedAt := time.Now()
+		_ = key.Revoke(revokedAt)
+
+		err := key.Revoke(revokedAt.Add(time.Hour))
+
+		assert.Equal(suite.T(), ErrAPIKeyAlreadyRevoked, err)
+		assert.Equal(suite.T(), revokedAt, *key.RevokedAt)
+	})
+}
+
+func TestAPIKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyTestSuite))
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidAuthorEmail = errors.New("メールアドレスの形式が正しくありません")
+	ErrMergeSameAuthor    = errors.New("同じ作成者同士はマージできません")
+	ErrUnknownAuthor      = errors.New("指定された作成者は登録されていません")
+)
+
+// Author はコンテンツの作成者
+// コンテンツは作成者を参照し、表示用に作成者名も非正規化して保持する
+type Author struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Email     string    `gorm:"type:varchar(254);not null;default:''" json:"email"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Author) TableName() string {
+	return "authors"
+}
+
+// AuthorSummary はコンテンツのレスポンスに埋め込む作成者の概要
+type AuthorSummary struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func (AuthorSummary) TableName() string {
+	return "authors"
+}
+
+func NewAuthor(name, email string) (*Author, error) {
+	author := &Author{
+		Name:  strings.TrimSpace(name),
+		Email: strings.TrimSpace(email),
+	}
+
+	if err := author.Validate(); err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (a *Author) Validate() error {
+	nameLen := utf8.RuneCountInString(a.Name)
+	if nameLen == 0 || nameLen > 100 {
+		return ErrInvalidAuthor
+	}
+
+	if a.Email != "" {
+		address, err := mail.ParseAddress(a.Email)
+		if err != nil || address.Address != a.Email || len(a.Email) > 254 {
+			return ErrInvalidAuthorEmail
+		}
+	}
+
+	return nil
+}
+
+// Update は作成者名とメールアドレスを変更する
+func (a *Author) Update(name, email string) error {
+	updated, err := NewAuthor(name, email)
+	if err != nil {
+		return err
+	}
+
+	a.Name = updated.Name
+	a.Email = updated.Email
+	return nil
+}
+
+// Summary は作成者の概要を返す
+func (a *Author) Summary() *AuthorSummary {
+	return &AuthorSummary{
+		ID:   a.ID,
+		Name: a.Name,
+	}
+}
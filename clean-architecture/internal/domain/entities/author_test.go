@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AuthorTestSuite) TestNewAuthor() {
+	suite.Run("正常なパラメータで作成者が作成できる", func() {
+		author, err := NewAuthor(" 運営チーム ", " ops@example.com ")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "運営チーム", author.Name)
+		assert.Equal(suite.T(), "ops@example.com", author.Email)
+	})
+
+	suite.Run("メールアドレスは省略できる", func() {
+		author, err := NewAuthor("運営チーム", "")
+
+		assert.NoError(suite.T(), err)
+		assert.Empty(suite.T(), author.Email)
+	})
+
+	suite.Run("空の名前はエラー", func() {
+		_, err := NewAuthor(" ", "")
+		assert.Equal(suite.T(), ErrInvalidAuthor, err)
+	})
+
+	suite.Run("101文字の名前はエラー", func() {
+		_, err := NewAuthor(strings.Repeat("あ", 101), "")
+		assert.Equal(suite.T(), ErrInvalidAuthor, err)
+	})
+
+	suite.Run("不正なメールアドレスはエラー", func() {
+		for _, email := range []string{"ops", "運営 <ops@example.com>"} {
+			_, err := NewAuthor("運営チーム", email)
+			assert.Equal(suite.T(), ErrInvalidAuthorEmail, err, email)
+		}
+	})
+}
+
+func (suite *AuthorTestSuite) TestUpdate() {
+	suite.Run("無効な値では変更されない", func() {
+		author, _ := NewAuthor("運営チーム", "ops@example.com")
+
+		err := author.Update("", "new@example.com")
+
+		assert.Equal(suite.T(), ErrInvalidAuthor, err)
+		assert.Equal(suite.T(), "運営チーム", author.Name)
+		assert.Equal(suite.T(), "ops@example.com", author.Email)
+	})
+}
+
+func (suite *AuthorTestSuite) TestContentAuthor() {
+	suite.Run("コンテンツの作成者名を変更すると作成者の参照が外れる", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "運営チーム")
+		author, _ := NewAuthor("運営チーム", "")
+		author.ID = 1
+		content.AuthorID = &author.ID
+		content.AuthorSummary = author.Summary()
+
+		err := content.Update("タイトル", "本文", "article", "法務チーム")
+
+		assert.NoError(suite.T(), err)
+		assert.Nil(suite.T(), content.AuthorID)
+		assert.Nil(suite.T(), content.AuthorSummary)
+	})
+
+	suite.Run("作成者名が変わらなければ作成者の参照を維持する", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "運営チーム")
+		author, _ := NewAuthor("運営チーム", "")
+		author.ID = 1
+		content.AuthorID = &author.ID
+		content.AuthorSummary = author.Summary()
+
+		err := content.Update("新しいタイトル", "本文", "article", " 運営チーム ")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), &author.ID, content.AuthorID)
+		assert.Equal(suite.T(), author.Summary(), content.AuthorSummary)
+	})
+
+	suite.Run("登録済みの作成者を指定すると作成者名と参照が設定される", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "運営チーム")
+		author, _ := NewAuthor("法務チーム", "")
+		author.ID = 2
+
+		err := content.Update("タイトル", "本文", "article", "", WithAuthor(author))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "法務チーム", content.Author)
+		assert.Equal(suite.T(), &author.ID, content.AuthorID)
+		assert.Equal(suite.T(), author.Summary(), content.AuthorSummary)
+	})
+}
+
+func TestAuthorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorTestSuite))
+}
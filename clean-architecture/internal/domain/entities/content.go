@@ -15,9 +15,26 @@ type Content struct {
 	Body        string         `gorm:"type:text;not null" json:"body"`
 	ContentType string         `gorm:"type:varchar(50);not null" json:"content_type"`
 	Author      string         `gorm:"type:varchar(100);not null" json:"author"`
+	Status      ContentStatus  `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	PublishedAt *time.Time     `json:"published_at"`
+	Version     uint           `gorm:"not null;default:1" json:"version"`
+	Tags        []*Tag         `gorm:"many2many:content_tags" json:"tags"`
+	Fields      ContentFields  `gorm:"type:jsonb;not null;default:'{}'" json:"fields"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// OwnerSubject はコンテンツを作成したプリンシパルの識別子で、所有者の判定に使用する
+	// 作成者名は表示用で同名の別人がありうるため、所有者の判定には使用しない
+	OwnerSubject string `gorm:"type:varchar(255);not null;default:'';index" json:"-"`
+
+	// AuthorID は作成者への参照で、Author は作成者名を非正規化して保持する
+	// 作成者は永続化時に作成者名から解決される
+	AuthorID      *uint          `gorm:"index" json:"author_id"`
+	AuthorSummary *AuthorSummary `gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"author_summary,omitempty"`
+
+	// registersAuthor は未登録の作成者名を永続化時に作成者として登録してよいかどうか
+	registersAuthor bool
 }
 
 func (Content) TableName() string {
@@ -27,23 +44,87 @@ func (Content) TableName() string {
 var (
 	ErrInvalidTitle       = errors.New("タイトルは1文字以上200文字以下で入力してください")
 	ErrInvalidBody        = errors.New("本文は1文字以上で入力してください")
-	ErrInvalidContentType = errors.New("コンテンツタイプは article, blog, news, page のいずれかを指定してください")
+	ErrInvalidContentType = errors.New("指定されたコンテンツタイプは登録されていません")
 	ErrInvalidAuthor      = errors.New("作成者名は1文字以上100文字以下で入力してください")
+
+	ErrInvalidStatusTransition = errors.New("現在のステータスからは指定されたステータスに変更できません")
 )
 
-var validContentTypes = map[string]bool{
-	"article": true,
-	"blog":    true,
-	"news":    true,
-	"page":    true,
+// ContentStatus はコンテンツの公開ワークフロー上の状態
+type ContentStatus string
+
+const (
+	StatusDraft     ContentStatus = "draft"
+	StatusInReview  ContentStatus = "in_review"
+	StatusPublished ContentStatus = "published"
+	StatusArchived  ContentStatus = "archived"
+)
+
+// allowedTransitions は現在のステータスから遷移可能なステータスの一覧
+var allowedTransitions = map[ContentStatus]map[ContentStatus]bool{
+	StatusDraft:     {StatusInReview: true},
+	StatusInReview:  {StatusPublished: true, StatusDraft: true},
+	StatusPublished: {StatusArchived: true, StatusDraft: true},
+	StatusArchived:  {StatusPublished: true, StatusDraft: true},
+}
+
+// ContentOption はコンテンツの作成・更新時に、検証前に適用する追加の設定
+type ContentOption func(*Content) error
+
+// WithOwner は作成するコンテンツの所有者としてプリンシパルの識別子を設定する
+func WithOwner(subject string) ContentOption {
+	return func(c *Content) error {
+		c.OwnerSubject = subject
+		return nil
+	}
+}
+
+// WithAuthor は登録済みの作成者をコンテンツの作成者に設定する
+// 作成者名の代わりに作成者への参照を設定するため、永続化時に作成者名から解決し直さない
+func WithAuthor(author *Author) ContentOption {
+	return func(c *Content) error {
+		c.Author = author.Name
+		c.AuthorID = &author.ID
+		c.AuthorSummary = author.Summary()
+		return nil
+	}
+}
+
+// RegisterAuthor は作成者名が未登録の場合に、永続化時に作成者として登録することを許可する
+// リクエストで指定された作成者名の誤りや名前の変更・マージ前の作成者名で作成者が作られないよう、プリンシパルの表示名にのみ使用する
+func RegisterAuthor() ContentOption {
+	return func(c *Content) error {
+		c.registersAuthor = true
+		return nil
+	}
+}
+
+// RegistersAuthor は未登録の作成者名を永続化時に作成者として登録してよいかを返す
+func (c *Content) RegistersAuthor() bool {
+	return c.registersAuthor
 }
 
-func NewContent(title, body, contentType, author string) (*Content, error) {
+// IsOwnedBy は指定された識別子のプリンシパルが所有するコンテンツかどうかを返す
+// 所有者のないコンテンツ（認証導入前に作成されたものなど）は誰の所有でもない
+func (c *Content) IsOwnedBy(subject string) bool {
+	return c.OwnerSubject != "" && c.OwnerSubject == subject
+}
+
+func NewContent(title, body, contentType, author string, opts ...ContentOption) (*Content, error) {
 	content := &Content{
 		Title:       strings.TrimSpace(title),
 		Body:        strings.TrimSpace(body),
 		ContentType: strings.TrimSpace(contentType),
 		Author:      strings.TrimSpace(author),
+		Status:      StatusDraft,
+		Version:     1,
+		Fields:      ContentFields{},
+	}
+
+	for _, opt := range opts {
+		if err := opt(content); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := content.Validate(); err != nil {
@@ -63,7 +144,7 @@ func (c *Content) Validate() error {
 		return ErrInvalidBody
 	}
 
-	if !validContentTypes[c.ContentType] {
+	if utf8.RuneCountInString(c.ContentType) == 0 {
 		return ErrInvalidContentType
 	}
 
@@ -75,22 +156,53 @@ func (c *Content) Validate() error {
 	return nil
 }
 
-func (c *Content) Update(title, body, contentType, author string) error {
+// ValidateContentType はコンテンツタイプが登録されており、コンテンツがそのタイプの制約を満たしているかを検証する
+// コンテンツタイプは変更されうるため、作成・更新のたびに最新の登録内容で検証する
+func (c *Content) ValidateContentType(types ContentTypeLookup) error {
+	contentType, ok := types.Lookup(c.ContentType)
+	if !ok {
+		return ErrInvalidContentType
+	}
+
+	return contentType.validateContent(c)
+}
+
+func (c *Content) Update(title, body, contentType, author string, opts ...ContentOption) error {
 	newContent := &Content{
 		Title:       strings.TrimSpace(title),
 		Body:        strings.TrimSpace(body),
 		ContentType: strings.TrimSpace(contentType),
 		Author:      strings.TrimSpace(author),
+		Tags:        c.Tags,
+		Fields:      c.Fields,
+	}
+
+	for _, opt := range opts {
+		if err := opt(newContent); err != nil {
+			return err
+		}
 	}
 
 	if err := newContent.Validate(); err != nil {
 		return err
 	}
 
+	// 作成者が指定された場合はその参照を使い、作成者名だけが変わった場合は永続化時に作成者を解決し直す
+	if newContent.AuthorID != nil {
+		c.AuthorID = newContent.AuthorID
+		c.AuthorSummary = newContent.AuthorSummary
+	} else if c.Author != newContent.Author {
+		c.AuthorID = nil
+		c.AuthorSummary = nil
+	}
+
 	c.Title = newContent.Title
 	c.Body = newContent.Body
 	c.ContentType = newContent.ContentType
 	c.Author = newContent.Author
+	c.registersAuthor = newContent.registersAuthor
+	c.Tags = newContent.Tags
+	c.Fields = newContent.Fields
 
 	return nil
 }
@@ -98,3 +210,61 @@ func (c *Content) Update(title, body, contentType, author string) error {
 func (c *Content) IsDeleted() bool {
 	return c.DeletedAt.Valid
 }
+
+// IsPublished は公開中のコンテンツかどうかを返す
+func (c *Content) IsPublished() bool {
+	return c.Status == StatusPublished
+}
+
+// CanTransitionTo は現在のステータスから指定されたステータスへ遷移できるかを返す
+func (c *Content) CanTransitionTo(next ContentStatus) bool {
+	return allowedTransitions[c.currentStatus()][next]
+}
+
+// SubmitForReview は下書きをレビュー待ちにする
+func (c *Content) SubmitForReview() error {
+	return c.transitionTo(StatusInReview)
+}
+
+// Publish はコンテンツを公開し、公開日時を記録する
+func (c *Content) Publish() error {
+	if err := c.transitionTo(StatusPublished); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.PublishedAt = &now
+	return nil
+}
+
+// Archive は公開中のコンテンツをアーカイブする
+func (c *Content) Archive() error {
+	return c.transitionTo(StatusArchived)
+}
+
+// ReturnToDraft はレビュー差し戻し・公開取り下げ・アーカイブ解除によりコンテンツを下書きに戻す
+func (c *Content) ReturnToDraft() error {
+	if err := c.transitionTo(StatusDraft); err != nil {
+		return err
+	}
+
+	c.PublishedAt = nil
+	return nil
+}
+
+func (c *Content) transitionTo(next ContentStatus) error {
+	if !c.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	c.Status = next
+	return nil
+}
+
+// currentStatus はステータス未設定のコンテンツを下書きとして扱う
+func (c *Content) currentStatus() ContentStatus {
+	if c.Status == "" {
+		return StatusDraft
+	}
+	return c.Status
+}
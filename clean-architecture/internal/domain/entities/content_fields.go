@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	ErrInvalidFieldsSchema = errors.New("カスタムフィールドのスキーマが不正です")
+	ErrInvalidFields       = errors.New("カスタムフィールドがコンテンツタイプのスキーマに一致しません")
+	ErrDuplicateFieldName  = errors.New("前後の空白を除くと同じになるカスタムフィールド名は指定できません")
+	errExternalSchemaRef   = errors.New("外部のスキーマは参照できません")
+)
+
+// ContentFields はコンテンツタイプごとに定義されるカスタムフィールドの値
+// JSONBとして保存され、未設定の場合は空のオブジェクトとして扱う
+type ContentFields map[string]interface{}
+
+func (f ContentFields) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (f *ContentFields) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*f = ContentFields{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("カスタムフィールドを読み込めない型です: %T", value)
+	}
+
+	fields := ContentFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*f = fields
+	return nil
+}
+
+// String はフィールド名順に並んだJSON表現を返す
+func (f ContentFields) String() string {
+	value, _ := f.Value()
+	s, _ := value.(string)
+	return s
+}
+
+// WithFields は作成・更新時にカスタムフィールドの値を置き換える
+// 前後の空白を除いたフィールド名が重複する場合はどちらの値を使うか決められないため ErrDuplicateFieldName を返す
+func WithFields(fields map[string]interface{}) ContentOption {
+	return func(c *Content) error {
+		copied := make(ContentFields, len(fields))
+		for name, value := range fields {
+			trimmed := strings.TrimSpace(name)
+			if _, ok := copied[trimmed]; ok {
+				return fmt.Errorf("%w: %s", ErrDuplicateFieldName, trimmed)
+			}
+			copied[trimmed] = value
+		}
+
+		c.Fields = copied
+		return nil
+	}
+}
+
+// compileFieldsSchema はコンテンツタイプのスキーマをコンパイルする
+// 外部リソースの読み込みによる情報漏えいを防ぐため、$ref はスキーマ内部の参照のみ許可する
+func compileFieldsSchema(schema map[string]interface{}) (*jsonschema.Schema, error) {
+	const location = "fields_schema.json"
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(rejectLoader{})
+	compiler.AssertFormat()
+
+	if err := compiler.AddResource(location, schema); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFieldsSchema, err)
+	}
+
+	compiled, err := compiler.Compile(location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFieldsSchema, err)
+	}
+	return compiled, nil
+}
+
+// validateFields はカスタムフィールドをスキーマで検証する
+func validateFields(schema *jsonschema.Schema, fields ContentFields) error {
+	if err := schema.Validate(map[string]interface{}(fields)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFields, err)
+	}
+	return nil
+}
+
+type rejectLoader struct{}
+
+func (rejectLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("%w: %s", errExternalSchemaRef, url)
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// リビジョンで追跡するフィールド名
+const (
+	FieldTitle       = "title"
+	FieldBody        = "body"
+	FieldContentType = "content_type"
+	FieldAuthor      = "author"
+	FieldFields      = "fields"
+)
+
+// revisionFields は差分比較の対象となるフィールドの順序
+var revisionFields = []string{FieldTitle, FieldBody, FieldContentType, FieldAuthor, FieldFields}
+
+var ErrRevisionContentMismatch = errors.New("指定されたリビジョンは対象コンテンツのものではありません")
+
+// ContentRevision はコンテンツ更新時点のスナップショットを保持する不変のリビジョン
+// Editor はトークンから得た表示名または識別子で長さが制限されないため、長さを制限しない列に保存する
+type ContentRevision struct {
+	ID             uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ContentID      uint          `gorm:"not null;uniqueIndex:idx_content_revisions_content_revision" json:"content_id"`
+	RevisionNumber int           `gorm:"not null;uniqueIndex:idx_content_revisions_content_revision" json:"revision_number"`
+	Title          string        `gorm:"type:varchar(200);not null" json:"title"`
+	Body           string        `gorm:"type:text;not null" json:"body"`
+	ContentType    string        `gorm:"type:varchar(50);not null" json:"content_type"`
+	Author         string        `gorm:"type:varchar(100);not null" json:"author"`
+	Fields         ContentFields `gorm:"type:jsonb;not null;default:'{}'" json:"fields"`
+	ChangedFields  []string      `gorm:"type:jsonb;serializer:json;not null" json:"changed_fields"`
+	Editor         string        `gorm:"type:text;not null" json:"editor"`
+	RestoredFrom   *int          `json:"restored_from,omitempty"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (ContentRevision) TableName() string {
+	return "content_revisions"
+}
+
+// FieldDiff は2つのリビジョン間で値が異なるフィールド
+type FieldDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// NewContentRevision はコンテンツの現在の状態からリビジョンを作成する
+// リビジョン番号は永続化時に採番される
+func NewContentRevision(content *Content, changedFields []string, editor string) *ContentRevision {
+	return &ContentRevision{
+		ContentID:     content.ID,
+		Title:         content.Title,
+		Body:          content.Body,
+		ContentType:   content.ContentType,
+		Author:        content.Author,
+		Fields:        content.Fields,
+		ChangedFields: changedFields,
+		Editor:        editor,
+	}
+}
+
+// NewInitialRevision は作成直後のコンテンツに対する最初のリビジョンを作成する
+func NewInitialRevision(content *Content) *ContentRevision {
+	fields := make([]string, len(revisionFields))
+	copy(fields, revisionFields)
+	return NewContentRevision(content, fields, content.Author)
+}
+
+// ChangedFieldsFrom は更新前のコンテンツと比較して値が変わったフィールド名を返す
+func (c *Content) ChangedFieldsFrom(before *Content) []string {
+	changed := []string{}
+	for _, field := range revisionFields {
+		if fieldValue(c, field) != fieldValue(before, field) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// RestoreRevision はリビジョンの内容でコンテンツを更新する
+// 作成者はリビジョンの作成者名ではなく、呼び出し側が登録済みの作成者から決定した author と opts で設定する
+func (c *Content) RestoreRevision(revision *ContentRevision, author string, opts ...ContentOption) error {
+	if revision.ContentID != c.ID {
+		return ErrRevisionContentMismatch
+	}
+	return c.Update(revision.Title, revision.Body, revision.ContentType, author, append([]ContentOption{WithFields(revision.Fields)}, opts...)...)
+}
+
+// Diff は自身を基準に他のリビジョンとのフィールド単位の差分を返す
+func (r *ContentRevision) Diff(to *ContentRevision) ([]FieldDiff, error) {
+	if r.ContentID != to.ContentID {
+		return nil, ErrRevisionContentMismatch
+	}
+
+	diffs := []FieldDiff{}
+	for _, field := range revisionFields {
+		from, next := r.fieldValue(field), to.fieldValue(field)
+		if from != next {
+			diffs = append(diffs, FieldDiff{Field: field, From: from, To: next})
+		}
+	}
+	return diffs, nil
+}
+
+func (r *ContentRevision) fieldValue(field string) string {
+	return fieldValue(&Content{
+		Title:       r.Title,
+		Body:        r.Body,
+		ContentType: r.ContentType,
+		Author:      r.Author,
+		Fields:      r.Fields,
+	}, field)
+}
+
+func fieldValue(c *Content, field string) string {
+	switch field {
+	case FieldTitle:
+		return c.Title
+	case FieldBody:
+		return c.Body
+	case FieldContentType:
+		return c.ContentType
+	case FieldAuthor:
+		return c.Author
+	case FieldFields:
+		return c.Fields.String()
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentRevisionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ContentRevisionTestSuite) TestChangedFieldsFrom() {
+	suite.Run("変更されたフィールドのみ返す", func() {
+		content, _ := NewContent("元のタイトル", "元の本文", "article", "作成者")
+		before := *content
+
+		err := content.Update("新しいタイトル", "元の本文", "blog", "作成者")
+		suite.Require().NoError(err)
+
+		assert.Equal(suite.T(), []string{FieldTitle, FieldContentType}, content.ChangedFieldsFrom(&before))
+	})
+
+	suite.Run("変更がなければ空を返す", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "作成者")
+		before := *content
+
+		assert.Empty(suite.T(), content.ChangedFieldsFrom(&before))
+	})
+}
+
+func (suite *ContentRevisionTestSuite) TestNewInitialRevision() {
+	suite.Run("全フィールドを変更済みとして記録する", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "作成者")
+		content.ID = 1
+
+		revision := NewInitialRevision(content)
+
+		assert.Equal(suite.T(), uint(1), revision.ContentID)
+		assert.Equal(suite.T(), "タイトル", revision.Title)
+		assert.Equal(suite.T(), "作成者", revision.Editor)
+		assert.Equal(suite.T(), []string{FieldTitle, FieldBody, FieldContentType, FieldAuthor, FieldFields}, revision.ChangedFields)
+	})
+}
+
+func (suite *ContentRevisionTestSuite) TestDiff() {
+	suite.Run("値が異なるフィールドの差分を返す", func() {
+		from := &ContentRevision{ContentID: 1, Title: "旧", Body: "本文", ContentType: "article", Author: "作成者"}
+		to := &ContentRevision{ContentID: 1, Title: "新", Body: "本文", ContentType: "news", Author: "作成者"}
+
+		diffs, err := from.Diff(to)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []FieldDiff{
+			{Field: FieldTitle, From: "旧", To: "新"},
+			{Field: FieldContentType, From: "article", To: "news"},
+		}, diffs)
+	})
+
+	suite.Run("カスタムフィールドの差分はJSONで返す", func() {
+		from := &ContentRevision{ContentID: 1, Fields: ContentFields{"event_date": "2026-01-01"}}
+		to := &ContentRevision{ContentID: 1, Fields: ContentFields{"event_date": "2026-02-01"}}
+
+		diffs, err := from.Diff(to)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []FieldDiff{
+			{Field: FieldFields, From: `{"event_date":"2026-01-01"}`, To: `{"event_date":"2026-02-01"}`},
+		}, diffs)
+	})
+
+	suite.Run("異なるコンテンツのリビジョンはエラー", func() {
+		from := &ContentRevision{ContentID: 1}
+		to := &ContentRevision{ContentID: 2}
+
+		_, err := from.Diff(to)
+
+		assert.Equal(suite.T(), ErrRevisionContentMismatch, err)
+	})
+}
+
+func (suite *ContentRevisionTestSuite) TestRestoreRevision() {
+	suite.Run("リビジョンの内容に戻せる", func() {
+		content, _ := NewContent("現在のタイトル", "現在の本文", "blog", "現在の作成者")
+		content.ID = 1
+		revision := &ContentRevision{ContentID: 1, Title: "旧タイトル", Body: "旧本文", ContentType: "article", Author: "旧作成者"}
+
+		err := content.RestoreRevision(revision, revision.Author)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "旧タイトル", content.Title)
+		assert.Equal(suite.T(), "旧本文", content.Body)
+		assert.Equal(suite.T(), "article", content.ContentType)
+		assert.Equal(suite.T(), "旧作成者", content.Author)
+	})
+
+	suite.Run("作成者はリビジョンではなく指定された作成者になる", func() {
+		content, _ := NewContent("現在のタイトル", "現在の本文", "blog", "現在の作成者", WithAuthor(&Author{ID: 1, Name: "現在の作成者"}))
+		content.ID = 1
+		revision := &ContentRevision{ContentID: 1, Title: "旧タイトル", Body: "旧本文", ContentType: "blog", Author: "旧作成者"}
+
+		err := content.RestoreRevision(revision, content.Author)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "旧タイトル", content.Title)
+		assert.Equal(suite.T(), "現在の作成者", content.Author)
+		assert.Equal(suite.T(), uint(1), *content.AuthorID)
+	})
+
+	suite.Run("他のコンテンツのリビジョンはエラー", func() {
+		content, _ := NewContent("タイトル", "本文", "blog", "作成者")
+		content.ID = 1
+
+		err := content.RestoreRevision(&ContentRevision{ContentID: 2}, "作成者")
+
+		assert.Equal(suite.T(), ErrRevisionContentMismatch, err)
+		assert.Equal(suite.T(), "タイトル", content.Title)
+	})
+}
+
+func TestContentRevisionTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentRevisionTestSuite))
+}
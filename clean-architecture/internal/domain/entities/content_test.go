@@ -22,6 +22,7 @@ func (suite *ContentTestSuite) TestNewContent() {
 		assert.Equal(suite.T(), "テスト本文", content.Body)
 		assert.Equal(suite.T(), "article", content.ContentType)
 		assert.Equal(suite.T(), "テスト作成者", content.Author)
+		assert.Equal(suite.T(), uint(1), content.Version)
 	})
 
 	suite.Run("空白文字が自動でトリミングされる", func() {
@@ -77,8 +78,19 @@ func (suite *ContentTestSuite) TestValidation() {
 			})
 		}
 
-		suite.Run("無効なコンテンツタイプはエラー", func() {
-			_, err := NewContent("テストタイトル", "テスト本文", "invalid", "テスト作成者")
+		suite.Run("空のコンテンツタイプはエラー", func() {
+			_, err := NewContent("テストタイトル", "テスト本文", " ", "テスト作成者")
+			assert.Equal(suite.T(), ErrInvalidContentType, err)
+		})
+
+		suite.Run("登録されていないコンテンツタイプは検証でエラー", func() {
+			registry, err := NewContentTypeRegistry(DefaultContentTypes()...)
+			suite.Require().NoError(err)
+			content, err := NewContent("テストタイトル", "テスト本文", "invalid", "テスト作成者")
+			suite.Require().NoError(err)
+
+			err = content.ValidateContentType(registry)
+
 			assert.Equal(suite.T(), ErrInvalidContentType, err)
 		})
 	})
@@ -131,6 +143,16 @@ func (suite *ContentTestSuite) TestUpdate() {
 		// 元のデータが保持されることを確認
 		assert.Equal(suite.T(), "元のタイトル", content.Title)
 	})
+
+	suite.Run("作成者の登録は許可したときのみ", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "表示名", RegisterAuthor())
+		assert.True(suite.T(), content.RegistersAuthor())
+
+		err := content.Update("タイトル", "本文", "article", "別の作成者")
+
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), content.RegistersAuthor())
+	})
 }
 
 func (suite *ContentTestSuite) TestIsDeleted() {
@@ -140,6 +162,82 @@ func (suite *ContentTestSuite) TestIsDeleted() {
 	})
 }
 
+func (suite *ContentTestSuite) TestStatusTransition() {
+	suite.Run("新規作成時は下書きになる", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+
+		assert.Equal(suite.T(), StatusDraft, content.Status)
+		assert.Nil(suite.T(), content.PublishedAt)
+		assert.False(suite.T(), content.IsPublished())
+	})
+
+	suite.Run("下書きからレビュー・公開・アーカイブへ遷移できる", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+
+		assert.NoError(suite.T(), content.SubmitForReview())
+		assert.Equal(suite.T(), StatusInReview, content.Status)
+
+		assert.NoError(suite.T(), content.Publish())
+		assert.Equal(suite.T(), StatusPublished, content.Status)
+		assert.NotNil(suite.T(), content.PublishedAt)
+		assert.True(suite.T(), content.IsPublished())
+
+		assert.NoError(suite.T(), content.Archive())
+		assert.Equal(suite.T(), StatusArchived, content.Status)
+		assert.NotNil(suite.T(), content.PublishedAt)
+	})
+
+	suite.Run("下書きを直接公開するとエラー", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+
+		err := content.Publish()
+
+		assert.Equal(suite.T(), ErrInvalidStatusTransition, err)
+		assert.Equal(suite.T(), StatusDraft, content.Status)
+		assert.Nil(suite.T(), content.PublishedAt)
+	})
+
+	suite.Run("下書きをアーカイブするとエラー", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+
+		assert.Equal(suite.T(), ErrInvalidStatusTransition, content.Archive())
+	})
+
+	suite.Run("レビュー待ちは下書きに差し戻せる", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+		suite.Require().NoError(content.SubmitForReview())
+
+		assert.NoError(suite.T(), content.ReturnToDraft())
+		assert.Equal(suite.T(), StatusDraft, content.Status)
+	})
+
+	suite.Run("公開を取り下げると公開日時がクリアされる", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+		suite.Require().NoError(content.SubmitForReview())
+		suite.Require().NoError(content.Publish())
+
+		assert.NoError(suite.T(), content.ReturnToDraft())
+		assert.Equal(suite.T(), StatusDraft, content.Status)
+		assert.Nil(suite.T(), content.PublishedAt)
+	})
+
+	suite.Run("アーカイブから再公開できる", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+		suite.Require().NoError(content.SubmitForReview())
+		suite.Require().NoError(content.Publish())
+		suite.Require().NoError(content.Archive())
+
+		assert.NoError(suite.T(), content.Publish())
+		assert.Equal(suite.T(), StatusPublished, content.Status)
+	})
+
+	suite.Run("下書きを下書きに戻すとエラー", func() {
+		content, _ := NewContent("テストタイトル", "テスト本文", "article", "テスト作成者")
+
+		assert.Equal(suite.T(), ErrInvalidStatusTransition, content.ReturnToDraft())
+	})
+}
+
 func TestContentTestSuite(t *testing.T) {
 	suite.Run(t, new(ContentTestSuite))
 }
@@ -0,0 +1,297 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// 必須フィールドとして指定できるフィールド名
+// カスタムフィールドは RequiredFieldPrefix に続けてフィールド名を指定する（例: fields.event_date）
+const (
+	RequiredFieldTags   = "tags"
+	RequiredFieldPrefix = "fields."
+)
+
+var supportedRequiredFields = map[string]bool{
+	RequiredFieldTags: true,
+}
+
+var contentTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+var (
+	ErrInvalidContentTypeName   = errors.New("コンテンツタイプ名は英小文字で始まる50文字以下の英小文字・数字・アンダースコアで入力してください")
+	ErrInvalidMaxBodyLength     = errors.New("本文の最大文字数は0以上で指定してください")
+	ErrUnsupportedRequiredField = errors.New("必須フィールドに指定できないフィールドです")
+	ErrInvalidAllowedAuthor     = errors.New("許可する作成者のIDは1以上で指定してください")
+	ErrBodyTooLong              = errors.New("本文がコンテンツタイプの最大文字数を超えています")
+	ErrMissingRequiredField     = errors.New("コンテンツタイプで必須とされているフィールドが指定されていません")
+	ErrAuthorNotAllowedForType  = errors.New("この作成者はコンテンツタイプに投稿できません")
+)
+
+// ContentType はコンテンツタイプの定義と、そのタイプのコンテンツに課す制約
+type ContentType struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string `gorm:"type:varchar(50);not null;uniqueIndex" json:"name"`
+	Description string `gorm:"type:varchar(200);not null;default:''" json:"description"`
+	// MaxBodyLength は本文の最大文字数（0は無制限）
+	MaxBodyLength  int      `gorm:"not null;default:0" json:"max_body_length"`
+	RequiredFields []string `gorm:"type:jsonb;serializer:json;not null" json:"required_fields"`
+	// AllowedAuthors は投稿できる作成者のID（空の場合は制限なし）
+	// 作成者名の変更やマージで制約が外れないよう、名前ではなくIDで保持する
+	AllowedAuthors []uint `gorm:"type:jsonb;serializer:json;not null" json:"allowed_authors"`
+	// FieldsSchema はカスタムフィールドを検証するJSON Schema（未設定の場合は検証しない）
+	FieldsSchema map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"fields_schema,omitempty"`
+	CreatedAt    time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+
+	fieldsSchema *jsonschema.Schema
+}
+
+func (ContentType) TableName() string {
+	return "content_types"
+}
+
+func NewContentType(name, description string, maxBodyLength int, requiredFields []string, allowedAuthors []uint, fieldsSchema map[string]interface{}) (*ContentType, error) {
+	contentType := &ContentType{
+		Name: strings.TrimSpace(name),
+	}
+
+	if err := contentType.Update(description, maxBodyLength, requiredFields, allowedAuthors, fieldsSchema); err != nil {
+		return nil, err
+	}
+
+	return contentType, nil
+}
+
+// DefaultContentTypes は初期状態で登録されているコンテンツタイプ
+func DefaultContentTypes() []*ContentType {
+	names := []string{"article", "blog", "news", "page"}
+	types := make([]*ContentType, 0, len(names))
+	for _, name := range names {
+		types = append(types, &ContentType{
+			Name:           name,
+			RequiredFields: []string{},
+			AllowedAuthors: []uint{},
+		})
+	}
+	return types
+}
+
+func (t *ContentType) Validate() error {
+	if !contentTypeNamePattern.MatchString(t.Name) {
+		return ErrInvalidContentTypeName
+	}
+
+	if t.MaxBodyLength < 0 {
+		return ErrInvalidMaxBodyLength
+	}
+
+	for _, field := range t.RequiredFields {
+		if !t.supportsRequiredField(field) {
+			return fmt.Errorf("%w: %s", ErrUnsupportedRequiredField, field)
+		}
+	}
+
+	if slices.Contains(t.AllowedAuthors, 0) {
+		return ErrInvalidAllowedAuthor
+	}
+
+	if t.FieldsSchema != nil {
+		if _, err := compileFieldsSchema(t.FieldsSchema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// supportsRequiredField は必須フィールドに指定できるフィールド名かを返す
+// スキーマがプロパティを定義している場合、カスタムフィールドはそのプロパティのみ指定できる
+func (t *ContentType) supportsRequiredField(field string) bool {
+	if supportedRequiredFields[field] {
+		return true
+	}
+
+	name, ok := strings.CutPrefix(field, RequiredFieldPrefix)
+	if !ok || name == "" {
+		return false
+	}
+
+	properties, ok := t.FieldsSchema["properties"].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	_, defined := properties[name]
+	return defined
+}
+
+// hasRequiredField はコンテンツが必須フィールドの値を持つかを返す
+func hasRequiredField(c *Content, field string) bool {
+	if field == RequiredFieldTags {
+		return len(c.Tags) > 0
+	}
+
+	name := strings.TrimPrefix(field, RequiredFieldPrefix)
+	value, ok := c.Fields[name]
+	return ok && value != nil
+}
+
+// Update はコンテンツタイプの制約を変更する（名前は変更できない）
+func (t *ContentType) Update(description string, maxBodyLength int, requiredFields []string, allowedAuthors []uint, fieldsSchema map[string]interface{}) error {
+	updated := &ContentType{
+		Name:           t.Name,
+		Description:    strings.TrimSpace(description),
+		MaxBodyLength:  maxBodyLength,
+		RequiredFields: trimAll(requiredFields),
+		AllowedAuthors: uniqueIDs(allowedAuthors),
+		FieldsSchema:   fieldsSchema,
+	}
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	t.Description = updated.Description
+	t.MaxBodyLength = updated.MaxBodyLength
+	t.RequiredFields = updated.RequiredFields
+	t.AllowedAuthors = updated.AllowedAuthors
+	t.FieldsSchema = updated.FieldsSchema
+	t.fieldsSchema = nil
+
+	return nil
+}
+
+// validateContent はコンテンツがこのタイプの制約を満たしているかを検証する
+func (t *ContentType) validateContent(c *Content) error {
+	if t.MaxBodyLength > 0 && utf8.RuneCountInString(c.Body) > t.MaxBodyLength {
+		return ErrBodyTooLong
+	}
+
+	for _, field := range t.RequiredFields {
+		if !hasRequiredField(c, field) {
+			return fmt.Errorf("%w: %s", ErrMissingRequiredField, field)
+		}
+	}
+
+	// 作成者の参照が未解決（未登録の作成者）の場合は許可された作成者に該当しない
+	if len(t.AllowedAuthors) > 0 && (c.AuthorID == nil || !slices.Contains(t.AllowedAuthors, *c.AuthorID)) {
+		return ErrAuthorNotAllowedForType
+	}
+
+	if t.FieldsSchema != nil {
+		schema := t.fieldsSchema
+		if schema == nil {
+			compiled, err := compileFieldsSchema(t.FieldsSchema)
+			if err != nil {
+				return err
+			}
+			schema = compiled
+		}
+
+		if err := validateFields(schema, c.Fields); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContentTypeLookup は名前からコンテンツタイプを取得する
+type ContentTypeLookup interface {
+	Lookup(name string) (*ContentType, bool)
+}
+
+// ContentTypeRegistry は登録済みのコンテンツタイプを保持し、Content.ValidateContentType から参照される
+type ContentTypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]*ContentType
+}
+
+// NewContentTypeRegistry はコンテンツタイプを登録したレジストリを作成する
+// フィールドのスキーマをコンパイルできないタイプがある場合はエラーを返す
+func NewContentTypeRegistry(types ...*ContentType) (*ContentTypeRegistry, error) {
+	registry := &ContentTypeRegistry{}
+	if err := registry.Replace(types); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// NewDefaultContentTypeRegistry は初期状態のコンテンツタイプのみを登録したレジストリを作成する
+func NewDefaultContentTypeRegistry() *ContentTypeRegistry {
+	// 初期タイプはスキーマを持たないため、登録に失敗することはない
+	registry, _ := NewContentTypeRegistry(DefaultContentTypes()...)
+	return registry
+}
+
+// Lookup は名前に対応するコンテンツタイプを返す
+func (r *ContentTypeRegistry) Lookup(name string) (*ContentType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contentType, ok := r.types[name]
+	return contentType, ok
+}
+
+// Names は登録済みのコンテンツタイプ名を昇順で返す
+func (r *ContentTypeRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replace は登録済みのコンテンツタイプをすべて置き換える
+// フィールドのスキーマをコンパイルできないタイプがある場合はエラーを返し、登録内容を変更しない
+func (r *ContentTypeRegistry) Replace(types []*ContentType) error {
+	registered := make(map[string]*ContentType, len(types))
+	for _, contentType := range types {
+		// 検証のたびにコンパイルしないよう、登録時にスキーマをコンパイルしておく
+		if contentType.FieldsSchema != nil && contentType.fieldsSchema == nil {
+			schema, err := compileFieldsSchema(contentType.FieldsSchema)
+			if err != nil {
+				return fmt.Errorf("コンテンツタイプ %s: %w", contentType.Name, err)
+			}
+			contentType.fieldsSchema = schema
+		}
+		registered[contentType.Name] = contentType
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = registered
+	return nil
+}
+
+func trimAll(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed = append(trimmed, strings.TrimSpace(value))
+	}
+	return trimmed
+}
+
+// uniqueIDs は重複したIDを除き、指定された順序を保ったIDの一覧を返す
+func uniqueIDs(ids []uint) []uint {
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,301 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentTypeTestSuite struct {
+	suite.Suite
+	registry *ContentTypeRegistry
+}
+
+func (suite *ContentTypeTestSuite) SetupSubTest() {
+	suite.registry = NewDefaultContentTypeRegistry()
+}
+
+// register は初期タイプに加えてコンテンツタイプを登録する
+func (suite *ContentTypeTestSuite) register(contentType *ContentType) {
+	suite.Require().NoError(suite.registry.Replace(append(DefaultContentTypes(), contentType)))
+}
+
+// newContent はコンテンツを作成し、登録済みのコンテンツタイプの制約で検証する
+func (suite *ContentTypeTestSuite) newContent(title, body, contentType, author string, opts ...ContentOption) (*Content, error) {
+	content, err := NewContent(title, body, contentType, author, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := content.ValidateContentType(suite.registry); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (suite *ContentTypeTestSuite) TestNewContentType() {
+	suite.Run("正常なパラメータでコンテンツタイプが作成できる", func() {
+		contentType, err := NewContentType(" podcast ", " 音声配信 ", 500, []string{"tags"}, []uint{1, 2, 1}, nil)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "podcast", contentType.Name)
+		assert.Equal(suite.T(), "音声配信", contentType.Description)
+		assert.Equal(suite.T(), 500, contentType.MaxBodyLength)
+		assert.Equal(suite.T(), []string{"tags"}, contentType.RequiredFields)
+		assert.Equal(suite.T(), []uint{1, 2}, contentType.AllowedAuthors)
+	})
+
+	suite.Run("英小文字以外を含む名前はエラー", func() {
+		_, err := NewContentType("Podcast", "", 0, nil, nil, nil)
+		assert.Equal(suite.T(), ErrInvalidContentTypeName, err)
+	})
+
+	suite.Run("51文字の名前はエラー", func() {
+		_, err := NewContentType("a"+strings.Repeat("b", 50), "", 0, nil, nil, nil)
+		assert.Equal(suite.T(), ErrInvalidContentTypeName, err)
+	})
+
+	suite.Run("負の最大文字数はエラー", func() {
+		_, err := NewContentType("podcast", "", -1, nil, nil, nil)
+		assert.Equal(suite.T(), ErrInvalidMaxBodyLength, err)
+	})
+
+	suite.Run("未対応の必須フィールドはエラー", func() {
+		_, err := NewContentType("podcast", "", 0, []string{"thumbnail"}, nil, nil)
+		assert.ErrorIs(suite.T(), err, ErrUnsupportedRequiredField)
+	})
+
+	suite.Run("カスタムフィールドを必須フィールドに指定できる", func() {
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"event_date": map[string]interface{}{"type": "string"}},
+		}
+
+		contentType, err := NewContentType("event", "", 0, []string{"fields.event_date"}, nil, schema)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"fields.event_date"}, contentType.RequiredFields)
+	})
+
+	suite.Run("スキーマに定義されていないカスタムフィールドは必須フィールドに指定できない", func() {
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"event_date": map[string]interface{}{"type": "string"}},
+		}
+
+		_, err := NewContentType("event", "", 0, []string{"fields.venue"}, nil, schema)
+		assert.ErrorIs(suite.T(), err, ErrUnsupportedRequiredField)
+
+		_, err = NewContentType("event", "", 0, []string{"fields."}, nil, nil)
+		assert.ErrorIs(suite.T(), err, ErrUnsupportedRequiredField)
+	})
+
+	suite.Run("0の作成者IDはエラー", func() {
+		_, err := NewContentType("podcast", "", 0, nil, []uint{0}, nil)
+		assert.Equal(suite.T(), ErrInvalidAllowedAuthor, err)
+	})
+}
+
+func (suite *ContentTypeTestSuite) TestUpdate() {
+	suite.Run("無効な制約では変更されない", func() {
+		contentType, _ := NewContentType("podcast", "音声配信", 500, nil, nil, nil)
+
+		err := contentType.Update("変更後", -1, nil, nil, nil)
+
+		assert.Equal(suite.T(), ErrInvalidMaxBodyLength, err)
+		assert.Equal(suite.T(), "音声配信", contentType.Description)
+		assert.Equal(suite.T(), 500, contentType.MaxBodyLength)
+	})
+}
+
+func (suite *ContentTypeTestSuite) TestContentValidation() {
+	suite.Run("登録したタイプでコンテンツが作成できる", func() {
+		contentType, _ := NewContentType("podcast", "", 0, nil, nil, nil)
+		suite.register(contentType)
+
+		content, err := suite.newContent("タイトル", "本文", "podcast", "作成者")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "podcast", content.ContentType)
+	})
+
+	suite.Run("登録されていないタイプはエラー", func() {
+		_, err := suite.newContent("タイトル", "本文", "podcast", "作成者")
+		assert.Equal(suite.T(), ErrInvalidContentType, err)
+	})
+
+	suite.Run("本文が最大文字数を超えるとエラー", func() {
+		contentType, _ := NewContentType("podcast", "", 3, nil, nil, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "あいうえ", "podcast", "作成者")
+		assert.Equal(suite.T(), ErrBodyTooLong, err)
+
+		_, err = suite.newContent("タイトル", "あいう", "podcast", "作成者")
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("必須のタグがないとエラー", func() {
+		contentType, _ := NewContentType("podcast", "", 0, []string{RequiredFieldTags}, nil, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "podcast", "作成者")
+		assert.ErrorIs(suite.T(), err, ErrMissingRequiredField)
+
+		_, err = suite.newContent("タイトル", "本文", "podcast", "作成者", WithTags([]string{"音声"}))
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("必須のタグをすべて外すとエラー", func() {
+		contentType, _ := NewContentType("podcast", "", 0, []string{RequiredFieldTags}, nil, nil)
+		suite.register(contentType)
+		content, _ := suite.newContent("タイトル", "本文", "podcast", "作成者", WithTags([]string{"音声"}))
+		suite.Require().NoError(setTags(content, []string{}))
+
+		err := content.ValidateContentType(suite.registry)
+
+		assert.ErrorIs(suite.T(), err, ErrMissingRequiredField)
+	})
+
+	suite.Run("必須のカスタムフィールドがないとエラー", func() {
+		contentType, _ := NewContentType("event", "", 0, []string{"fields.event_date"}, nil, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "event", "作成者")
+		assert.ErrorIs(suite.T(), err, ErrMissingRequiredField)
+
+		_, err = suite.newContent("タイトル", "本文", "event", "作成者", WithFields(map[string]interface{}{"event_date": nil}))
+		assert.ErrorIs(suite.T(), err, ErrMissingRequiredField)
+
+		_, err = suite.newContent("タイトル", "本文", "event", "作成者", WithFields(map[string]interface{}{"event_date": "2026-01-01"}))
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("許可されていない作成者はエラー", func() {
+		contentType, _ := NewContentType("podcast", "", 0, nil, []uint{1}, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "podcast", "作成者", WithAuthor(&Author{ID: 2, Name: "作成者"}))
+		assert.Equal(suite.T(), ErrAuthorNotAllowedForType, err)
+
+		_, err = suite.newContent("タイトル", "本文", "podcast", "配信者", WithAuthor(&Author{ID: 1, Name: "配信者"}))
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("許可された作成者の名前が変わっても投稿できる", func() {
+		contentType, _ := NewContentType("podcast", "", 0, nil, []uint{1}, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "podcast", "配信チーム", WithAuthor(&Author{ID: 1, Name: "配信チーム"}))
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("作成者の参照が未解決の場合は許可されない", func() {
+		contentType, _ := NewContentType("podcast", "", 0, nil, []uint{1}, nil)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "podcast", "配信者", RegisterAuthor())
+		assert.Equal(suite.T(), ErrAuthorNotAllowedForType, err)
+	})
+}
+
+func (suite *ContentTypeTestSuite) TestFieldsSchema() {
+	eventSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"event_date": map[string]interface{}{"type": "string", "format": "date"},
+		},
+		"required":             []interface{}{"event_date"},
+		"additionalProperties": false,
+	}
+
+	suite.Run("スキーマに一致するカスタムフィールドでコンテンツが作成できる", func() {
+		contentType, err := NewContentType("event", "", 0, nil, nil, eventSchema)
+		suite.Require().NoError(err)
+		suite.register(contentType)
+
+		content, err := suite.newContent("タイトル", "本文", "event", "作成者", WithFields(map[string]interface{}{"event_date": "2026-01-01"}))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), ContentFields{"event_date": "2026-01-01"}, content.Fields)
+	})
+
+	suite.Run("スキーマに一致しないカスタムフィールドはエラー", func() {
+		contentType, _ := NewContentType("event", "", 0, nil, nil, eventSchema)
+		suite.register(contentType)
+
+		_, err := suite.newContent("タイトル", "本文", "event", "作成者", WithFields(map[string]interface{}{"event_date": "来週"}))
+		assert.ErrorIs(suite.T(), err, ErrInvalidFields)
+
+		_, err = suite.newContent("タイトル", "本文", "event", "作成者")
+		assert.ErrorIs(suite.T(), err, ErrInvalidFields)
+	})
+
+	suite.Run("更新時にコンテンツタイプを変更するとカスタムフィールドも再検証される", func() {
+		contentType, _ := NewContentType("event", "", 0, nil, nil, eventSchema)
+		suite.register(contentType)
+		content, _ := suite.newContent("タイトル", "本文", "article", "作成者")
+		suite.Require().NoError(content.Update("タイトル", "本文", "event", "作成者"))
+
+		err := content.ValidateContentType(suite.registry)
+
+		assert.ErrorIs(suite.T(), err, ErrInvalidFields)
+	})
+
+	suite.Run("登録内容を置き換えると以降の検証に反映される", func() {
+		contentType, _ := NewContentType("event", "", 0, nil, nil, eventSchema)
+		suite.register(contentType)
+		content, _ := NewContent("タイトル", "本文", "event", "作成者")
+		assert.ErrorIs(suite.T(), content.ValidateContentType(suite.registry), ErrInvalidFields)
+
+		// When: 他のインスタンスでスキーマが削除された定義を読み込み直す
+		relaxed, _ := NewContentType("event", "", 0, nil, nil, nil)
+		suite.register(relaxed)
+
+		// Then
+		assert.NoError(suite.T(), content.ValidateContentType(suite.registry))
+	})
+
+	suite.Run("スキーマ未設定のタイプではカスタムフィールドを検証しない", func() {
+		content, err := suite.newContent("タイトル", "本文", "article", "作成者", WithFields(map[string]interface{}{"any": 1}))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), ContentFields{"any": 1}, content.Fields)
+	})
+
+	suite.Run("前後の空白を除くと重複するフィールド名はエラー", func() {
+		_, err := suite.newContent("タイトル", "本文", "article", "作成者", WithFields(map[string]interface{}{"a": 1, " a": 2}))
+
+		assert.ErrorIs(suite.T(), err, ErrDuplicateFieldName)
+	})
+
+	suite.Run("不正なスキーマはエラー", func() {
+		_, err := NewContentType("event", "", 0, nil, nil, map[string]interface{}{"type": 1})
+		assert.ErrorIs(suite.T(), err, ErrInvalidFieldsSchema)
+	})
+
+	suite.Run("外部のスキーマを参照するスキーマはエラー", func() {
+		_, err := NewContentType("event", "", 0, nil, nil, map[string]interface{}{"$ref": "file:///etc/passwd"})
+		assert.ErrorIs(suite.T(), err, ErrInvalidFieldsSchema)
+	})
+
+	suite.Run("不正なスキーマを持つタイプが含まれる場合は登録内容を置き換えない", func() {
+		// Given: データベースに直接保存された不正なスキーマ
+		invalid := &ContentType{Name: "event", FieldsSchema: map[string]interface{}{"type": 1}}
+		registry, err := NewContentTypeRegistry(DefaultContentTypes()...)
+		suite.Require().NoError(err)
+
+		// When
+		err = registry.Replace([]*ContentType{invalid})
+
+		// Then
+		assert.ErrorIs(suite.T(), err, ErrInvalidFieldsSchema)
+		assert.ErrorContains(suite.T(), err, "event")
+		assert.Equal(suite.T(), []string{"article", "blog", "news", "page"}, registry.Names())
+	})
+}
+
+func TestContentTypeTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentTypeTestSuite))
+}
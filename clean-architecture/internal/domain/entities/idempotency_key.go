@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// idempotencyKeyMaxLength は Idempotency-Key ヘッダーの値の最大長
+const idempotencyKeyMaxLength = 255
+
+var ErrInvalidIdempotencyKey = errors.New("Idempotency-Keyは1文字以上255文字以下の表示可能なASCII文字で指定してください")
+
+// IdempotencyKey はクライアントが指定した冪等キーと、そのキーで最初に処理したリクエストの結果
+// 処理中は StatusCode が0で、処理が完了すると最初のレスポンスを保存し、再送時にそのまま返す
+// 処理中のキーは LockedUntil まで予約したリクエストが占有し、それを過ぎても完了していないキーは
+// 処理していたプロセスが停止したものとみなして同じリクエストの再送に引き継ぐ
+type IdempotencyKey struct {
+	ID uint `gorm:"primaryKey;autoIncrement"`
+	// Client はキーを指定したクライアント（プリンシパルまたはクライアントIP）で、キーはクライアントごとに区別する
+	Client string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_client_key"`
+	Key    string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_client_key"`
+	// RequestHash は同じキーで異なるリクエストが送られたことを検出するためのリクエストのハッシュ値
+	RequestHash     string            `gorm:"type:varchar(64);not null"`
+	StatusCode      int               `gorm:"not null;default:0"`
+	ResponseHeaders map[string]string `gorm:"type:jsonb;serializer:json"`
+	ResponseBody    []byte
+	ExpiresAt       time.Time `gorm:"not null;index"`
+	// LockedUntil は処理中のキーの占有期限で、予約したリクエストを識別するためにも使用する
+	LockedUntil time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// NewIdempotencyKey は lockedUntil まで占有する処理中の冪等キーを作成する
+// 保存した値と比較できるよう、lockedUntil はデータベースの精度（マイクロ秒）に切り捨てる
+func NewIdempotencyKey(client, key, requestHash string, expiresAt, lockedUntil time.Time) (*IdempotencyKey, error) {
+	if !isValidIdempotencyKey(key) {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	return &IdempotencyKey{
+		Client:      client,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   expiresAt,
+		LockedUntil: lockedUntil.Truncate(time.Microsecond),
+	}, nil
+}
+
+// HashIdempotentRequest はメソッド・パス・リクエストボディから冪等キーと照合するハッシュ値を求める
+func HashIdempotentRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Matches はリクエストがキーを最初に使用したリクエストと同じであるかを返す
+func (k *IdempotencyKey) Matches(requestHash string) bool {
+	return k.RequestHash == requestHash
+}
+
+// IsCompleted は最初のリクエストの処理が完了し、レスポンスが保存されているかを返す
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+// Complete は最初のリクエストのレスポンスを保存する
+func (k *IdempotencyKey) Complete(statusCode int, headers map[string]string, body []byte) {
+	k.StatusCode = statusCode
+	k.ResponseHeaders = headers
+	k.ResponseBody = body
+}
+
+func isValidIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > idempotencyKeyMaxLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyKeyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *IdempotencyKeyTestSuite) TestNewIdempotencyKey() {
+	suite.Run("処理中のキーが作成される", func() {
+		expiresAt := time.Now().Add(time.Hour)
+		lockedUntil := time.Date(2026, 1, 1, 0, 1, 0, 123456789, time.UTC)
+
+		key, err := NewIdempotencyKey("principal:user-1", "8e03978e-40d5-43e8-bc93-6894a57f9324", "hash", expiresAt, lockedUntil)
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "principal:user-1", key.Client)
+		assert.Equal(suite.T(), expiresAt, key.ExpiresAt)
+		assert.False(suite.T(), key.IsCompleted())
+
+		// データベースの精度に切り捨てる
+		assert.Equal(suite.T(), time.Date(2026, 1, 1, 0, 1, 0, 123456000, time.UTC), key.LockedUntil)
+	})
+
+	suite.Run("不正なキーはエラー", func() {
+		for _, k := range []string{"", "空白 を含む", "キー", strings.Repeat("a", 256)} {
+			_, err := NewIdempotencyKey("ip:127.0.0.1", k, "hash", time.Now(), time.Now())
+			assert.Equal(suite.T(), ErrInvalidIdempotencyKey, err, k)
+		}
+	})
+}
+
+func (suite *IdempotencyKeyTestSuite) TestHashIdempotentRequest() {
+	suite.Run("メソッド・パス・ボディのいずれかが異なればハッシュ値も異なる", func() {
+		base := HashIdempotentRequest("POST", "/api/v1/contents", []byte(`{"title":"a"}`))
+
+		assert.Equal(suite.T(), base, HashIdempotentRequest("POST", "/api/v1/contents", []byte(`{"title":"a"}`)))
+		assert.NotEqual(suite.T(), base, HashIdempotentRequest("PUT", "/api/v1/contents", []byte(`{"title":"a"}`)))
+		assert.NotEqual(suite.T(), base, HashIdempotentRequest("POST", "/api/v1/tags", []byte(`{"title":"a"}`)))
+		assert.NotEqual(suite.T(), base, HashIdempotentRequest("POST", "/api/v1/contents", []byte(`{"title":"b"}`)))
+	})
+}
+
+func (suite *IdempotencyKeyTestSuite) TestComplete() {
+	suite.Run("レスポンスを保存すると完了になる", func() {
+		key, _ := NewIdempotencyKey("ip:127.0.0.1", "key-1", "hash", time.Now(), time.Now())
+
+		key.Complete(201, map[string]string{"ETag": `"1"`}, []byte(`{"id":1}`))
+
+		assert.True(suite.T(), key.IsCompleted())
+		assert.True(suite.T(), key.Matches("hash"))
+		assert.False(suite.T(), key.Matches("other"))
+	})
+}
+
+func TestIdempotencyKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyKeyTestSuite))
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTagsPerContent は1つのコンテンツに付与できるタグの上限
+const MaxTagsPerContent = 20
+
+type Tag struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+var (
+	ErrInvalidTagName = errors.New("タグ名は1文字以上50文字以下で入力してください")
+	ErrTooManyTags    = errors.New("タグは20個以下で指定してください")
+	ErrMergeSameTag   = errors.New("同じタグ同士はマージできません")
+)
+
+func NewTag(name string) (*Tag, error) {
+	tag := &Tag{
+		Name: strings.TrimSpace(name),
+	}
+
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (t *Tag) Validate() error {
+	nameLen := utf8.RuneCountInString(t.Name)
+	if nameLen == 0 || nameLen > 50 {
+		return ErrInvalidTagName
+	}
+	return nil
+}
+
+// Rename はタグ名を変更する
+func (t *Tag) Rename(name string) error {
+	renamed, err := NewTag(name)
+	if err != nil {
+		return err
+	}
+
+	t.Name = renamed.Name
+	return nil
+}
+
+// WithTags は作成・更新時にタグ名の一覧でコンテンツのタグを置き換える
+func WithTags(names []string) ContentOption {
+	return func(c *Content) error {
+		tags, err := newTags(names)
+		if err != nil {
+			return err
+		}
+
+		c.Tags = tags
+		return nil
+	}
+}
+
+// newTags はタグ名の一覧からタグを作成し、重複したタグ名を1つにまとめる
+func newTags(names []string) ([]*Tag, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]*Tag, 0, len(names))
+	for _, name := range names {
+		tag, err := NewTag(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[tag.Name] {
+			continue
+		}
+		seen[tag.Name] = true
+		tags = append(tags, tag)
+	}
+
+	if len(tags) > MaxTagsPerContent {
+		return nil, ErrTooManyTags
+	}
+
+	return tags, nil
+}
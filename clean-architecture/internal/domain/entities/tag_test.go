@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TagTestSuite) TestNewTag() {
+	suite.Run("前後の空白を除いてタグが作成できる", func() {
+		tag, err := NewTag("  お知らせ  ")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "お知らせ", tag.Name)
+	})
+
+	suite.Run("空のタグ名はエラー", func() {
+		_, err := NewTag("   ")
+		assert.Equal(suite.T(), ErrInvalidTagName, err)
+	})
+
+	suite.Run("51文字のタグ名はエラー", func() {
+		longName := ""
+		for i := 0; i < 51; i++ {
+			longName += "あ"
+		}
+		_, err := NewTag(longName)
+		assert.Equal(suite.T(), ErrInvalidTagName, err)
+	})
+}
+
+func (suite *TagTestSuite) TestRename() {
+	suite.Run("タグ名を変更できる", func() {
+		tag, _ := NewTag("旧タグ")
+
+		err := tag.Rename("新タグ")
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "新タグ", tag.Name)
+	})
+
+	suite.Run("無効な名前では変更されない", func() {
+		tag, _ := NewTag("旧タグ")
+
+		err := tag.Rename("")
+
+		assert.Equal(suite.T(), ErrInvalidTagName, err)
+		assert.Equal(suite.T(), "旧タグ", tag.Name)
+	})
+}
+
+// tagNames はコンテンツに付与されたタグ名の一覧を返す
+func tagNames(c *Content) []string {
+	names := make([]string, 0, len(c.Tags))
+	for _, tag := range c.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// setTags はコンテンツの他の項目を変えずにタグを置き換える
+func setTags(c *Content, names []string) error {
+	return c.Update(c.Title, c.Body, c.ContentType, c.Author, WithTags(names))
+}
+
+func (suite *TagTestSuite) TestWithTags() {
+	suite.Run("重複を除いてタグが設定される", func() {
+		content, err := NewContent("タイトル", "本文", "article", "作成者", WithTags([]string{"Go", " Go ", "API"}))
+
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), []string{"Go", "API"}, tagNames(content))
+	})
+
+	suite.Run("空の一覧ですべてのタグを外せる", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "作成者", WithTags([]string{"Go"}))
+
+		err := setTags(content, []string{})
+
+		assert.NoError(suite.T(), err)
+		assert.Empty(suite.T(), tagNames(content))
+	})
+
+	suite.Run("無効なタグ名を含む場合は変更されない", func() {
+		content, _ := NewContent("タイトル", "本文", "article", "作成者", WithTags([]string{"Go"}))
+
+		err := setTags(content, []string{"API", ""})
+
+		assert.Equal(suite.T(), ErrInvalidTagName, err)
+		assert.Equal(suite.T(), []string{"Go"}, tagNames(content))
+	})
+
+	suite.Run("21個以上のタグはエラー", func() {
+		names := make([]string, 0, MaxTagsPerContent+1)
+		for i := 0; i <= MaxTagsPerContent; i++ {
+			names = append(names, fmt.Sprintf("タグ%d", i))
+		}
+
+		_, err := NewContent("タイトル", "本文", "article", "作成者", WithTags(names))
+
+		assert.Equal(suite.T(), ErrTooManyTags, err)
+	})
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
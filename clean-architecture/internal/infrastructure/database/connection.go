@@ -1,9 +1,10 @@
 package database
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"strconv"
+	"regexp"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -11,25 +12,38 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-func Connect() (*gorm.DB, error) {
-	config := loadConfig()
+// Connect はデータベースに接続する
+// 返すエラーのメッセージからはパスワードなどの認証情報を取り除く
+func Connect(config DatabaseConfig) (*gorm.DB, error) {
+	db, err := connect(config)
+	if err != nil {
+		return nil, redactError(err, config)
+	}
+	return db, nil
+}
+
+func connect(config DatabaseConfig) (*gorm.DB, error) {
 	dsn := buildDSN(config)
 
-	gormConfig := &gorm.Config{}
+	// 接続に失敗した場合に gorm が接続文字列を含むエラーをログに出力しないよう、ロガーは接続後に設定する
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("データベース接続に失敗しました: %w", err)
+	}
 
-	logLevel := os.Getenv("LOG_LEVEL")
-	switch logLevel {
+	// SQLのログはコンテキストのロガーに出力し、debug の場合はすべてのSQLを出力する
+	switch strings.ToLower(config.LogLevel) {
 	case "debug":
-		gormConfig.Logger = logger.Default.LogMode(logger.Info)
+		db.Logger = newGormLogger(logger.Info)
 	case "silent":
-		gormConfig.Logger = logger.Default.LogMode(logger.Silent)
+		db.Logger = newGormLogger(logger.Silent)
 	default:
-		gormConfig.Logger = logger.Default.LogMode(logger.Error)
+		db.Logger = newGormLogger(logger.Warn)
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
-	if err != nil {
-		return nil, fmt.Errorf("データベース接続に失敗しました: %w", err)
+	// リクエストのスパンの子スパンとしてSQLの実行を記録する
+	if err := db.Use(tracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("トレースの設定に失敗しました: %w", err)
 	}
 
 	sqlDB, err := db.DB()
@@ -42,12 +56,22 @@ func Connect() (*gorm.DB, error) {
 	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
 
 	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
 		return nil, fmt.Errorf("データベース接続確認に失敗しました: %w", err)
 	}
 
 	return db, nil
 }
 
+// Close はコネクションプールのすべての接続を閉じる
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("データベースインスタンス取得に失敗しました: %w", err)
+	}
+	return sqlDB.Close()
+}
+
 type DatabaseConfig struct {
 	Host            string
 	Port            int
@@ -58,51 +82,48 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	MaxOpenConns    int
 	ConnMaxLifetime time.Duration
+	// LogLevel はSQLのログの出力レベル（アプリケーションのログレベルと同じ値）
+	LogLevel string
 }
 
-func loadConfig() DatabaseConfig {
-	return DatabaseConfig{
-		Host:            getEnv("DB_HOST", "localhost"),
-		Port:            getEnvAsInt("DB_PORT", 5432),
-		User:            getEnv("DB_USER", "api_user"),
-		Password:        getEnv("DB_PASSWORD", "api_password"),
-		Database:        getEnv("DB_NAME", "api_db"),
-		SSLMode:         getEnv("DB_SSLMODE", "disable"),
-		MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
-		MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 100),
-		ConnMaxLifetime: time.Duration(getEnvAsInt("DB_CONN_MAX_LIFETIME", 3600)) * time.Second,
-	}
-}
-
+// buildDSN はキーワード/値形式の接続文字列を作成する
+// 空白や引用符を含む値も正しく解釈されるよう、文字列の値は引用符で囲む
 func buildDSN(config DatabaseConfig) string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host,
+		quoteDSNValue(config.Host),
 		config.Port,
-		config.User,
-		config.Password,
-		config.Database,
-		config.SSLMode,
+		quoteDSNValue(config.User),
+		quoteDSNValue(config.Password),
+		quoteDSNValue(config.Database),
+		quoteDSNValue(config.SSLMode),
 	)
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+var dsnValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func quoteDSNValue(value string) string {
+	return "'" + dsnValueEscaper.Replace(value) + "'"
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
-	}
+// redacted は認証情報を伏せるときに代わりに出力する文字列
+const redacted = "[REDACTED]"
 
-	value, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return defaultValue
-	}
+var (
+	quotedPasswordPattern = regexp.MustCompile(`password='(?:[^'\\]|\\.)*'`)
+	plainPasswordPattern  = regexp.MustCompile(`password=[^'\s]\S*`)
+	urlPasswordPattern    = regexp.MustCompile(`(://[^:/@\s]*):[^@\s]*@`)
+)
 
-	return value
+// redactError は err のメッセージからパスワードを伏せたエラーを返す
+// ドライバーのエラーはパスワードを含む場合があるため、元のエラーはラップせずにメッセージだけを引き継ぐ
+func redactError(err error, config DatabaseConfig) error {
+	message := err.Error()
+	if config.Password != "" {
+		message = strings.ReplaceAll(message, config.Password, redacted)
+	}
+	message = quotedPasswordPattern.ReplaceAllLiteralString(message, "password="+redacted)
+	message = plainPasswordPattern.ReplaceAllLiteralString(message, "password="+redacted)
+	message = urlPasswordPattern.ReplaceAllString(message, "${1}:"+redacted+"@")
+	return errors.New(message)
 }
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PingCheck はデータベースに接続できるかを確認する
+func PingCheck(db *gorm.DB) func(context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("データベースインスタンス取得に失敗しました: %w", err)
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// SchemaVersionCheck は適用済みのスキーマがこのアプリケーションが必要とするバージョン以上かを確認する
+func SchemaVersionCheck(db *gorm.DB) func(context.Context) error {
+	return func(ctx context.Context) error {
+		applied, err := AppliedSchemaVersion(ctx, db)
+		if err != nil {
+			return fmt.Errorf("スキーマのバージョンを取得できません: %w", err)
+		}
+		if applied < SchemaVersion {
+			return fmt.Errorf("マイグレーションが適用されていません（適用済み: %d、必要: %d）", applied, SchemaVersion)
+		}
+		return nil
+	}
+}
+
+// PoolSaturationCheck は使用中の接続数がコネクションプールの上限の maxUsage（0〜1）の割合以上の場合にエラーを返す
+// 上限を設定していない場合は常に成功する
+func PoolSaturationCheck(db *gorm.DB, maxUsage float64) func(context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("データベースインスタンス取得に失敗しました: %w", err)
+		}
+		stats := sqlDB.Stats()
+		if stats.MaxOpenConnections <= 0 {
+			return nil
+		}
+		if usage := float64(stats.InUse) / float64(stats.MaxOpenConnections); usage >= maxUsage {
+			return fmt.Errorf("コネクションプールが飽和しています（使用中: %d / %d）", stats.InUse, stats.MaxOpenConnections)
+		}
+		return nil
+	}
+}
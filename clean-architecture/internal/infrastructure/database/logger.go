@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-api-server-sample/internal/infrastructure/logging"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold はこの時間を超えたSQLを警告として出力する閾値
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger はGORMのログをコンテキストのロガー（slog）に出力する
+// リクエストのコンテキストで実行したSQLのログには、リクエストIDと認証済みのユーザーが付与される
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+func newGormLogger(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		logging.FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		logging.FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		logging.FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace はSQLの実行結果を出力する
+// レコードが見つからないエラーは通常の結果として扱い、すべてのSQLはInfoモードの場合のみデバッグレベルで出力する
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	log := logging.FromContext(ctx)
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		log.ErrorContext(ctx, "SQLの実行に失敗しました", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case elapsed > slowQueryThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		log.WarnContext(ctx, "SQLの実行に時間がかかっています", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		log.DebugContext(ctx, "SQLを実行しました", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
@@ -1,25 +1,102 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"go-api-server-sample/internal/domain/entities"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// SchemaVersion はこのアプリケーションが必要とするスキーマのバージョン
+// Migrate でテーブルや列を追加・変更した場合は1つ増やす
+const SchemaVersion = 4
+
+// schemaVersion は適用済みのスキーマのバージョンを記録する（1行のみ）
+type schemaVersion struct {
+	ID         int       `gorm:"primaryKey;autoIncrement:false"`
+	Version    int       `gorm:"not null"`
+	MigratedAt time.Time `gorm:"not null"`
+}
+
+func (schemaVersion) TableName() string {
+	return "schema_version"
+}
+
 func Migrate(db *gorm.DB) error {
 	log.Println("マイグレーションを開始します...")
 
+	// ステータス導入前のコンテンツは公開済みとして扱うため、追加前に列の有無を確認する
+	needsStatusBackfill := db.Migrator().HasTable(&entities.Content{}) &&
+		!db.Migrator().HasColumn(&entities.Content{}, "Status")
+
+	if err := db.AutoMigrate(&entities.ContentType{}); err != nil {
+		return fmt.Errorf("ContentTypeテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
+	if err := seedContentTypes(db); err != nil {
+		return fmt.Errorf("初期コンテンツタイプの登録に失敗しました: %w", err)
+	}
+
+	if err := db.AutoMigrate(&entities.Tag{}); err != nil {
+		return fmt.Errorf("Tagテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
+	if err := db.AutoMigrate(&entities.Author{}); err != nil {
+		return fmt.Errorf("Authorテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
 	if err := db.AutoMigrate(&entities.Content{}); err != nil {
 		return fmt.Errorf("Contentテーブルのマイグレーションに失敗しました: %w", err)
 	}
 
+	if needsStatusBackfill {
+		if err := backfillContentStatus(db); err != nil {
+			return fmt.Errorf("ステータスの移行に失敗しました: %w", err)
+		}
+	}
+
+	if err := backfillAuthors(db); err != nil {
+		return fmt.Errorf("作成者の移行に失敗しました: %w", err)
+	}
+
+	if err := migrateAllowedAuthors(db); err != nil {
+		return fmt.Errorf("許可する作成者の移行に失敗しました: %w", err)
+	}
+
+	if err := db.AutoMigrate(&entities.ContentRevision{}); err != nil {
+		return fmt.Errorf("ContentRevisionテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
+	if err := backfillInitialRevisions(db); err != nil {
+		return fmt.Errorf("初期リビジョンの作成に失敗しました: %w", err)
+	}
+
+	if err := db.AutoMigrate(&entities.APIKey{}); err != nil {
+		return fmt.Errorf("APIKeyテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
+	if err := db.AutoMigrate(&entities.IdempotencyKey{}); err != nil {
+		return fmt.Errorf("IdempotencyKeyテーブルのマイグレーションに失敗しました: %w", err)
+	}
+
+	if err := addSearchColumns(db); err != nil {
+		return fmt.Errorf("全文検索用の列の追加に失敗しました: %w", err)
+	}
+
 	if err := createIndexes(db); err != nil {
 		return fmt.Errorf("インデックス作成に失敗しました: %w", err)
 	}
 
+	if err := recordSchemaVersion(db); err != nil {
+		return fmt.Errorf("スキーマのバージョンの記録に失敗しました: %w", err)
+	}
+
 	log.Println("マイグレーションが完了しました")
 	return nil
 }
@@ -27,7 +104,7 @@ func Migrate(db *gorm.DB) error {
 func Reset(db *gorm.DB) error {
 	log.Println("データベースリセットを開始します...")
 
-	if err := db.Migrator().DropTable(&entities.Content{}); err != nil {
+	if err := db.Migrator().DropTable(&schemaVersion{}, &entities.IdempotencyKey{}, &entities.APIKey{}, &entities.ContentRevision{}, "content_tags", &entities.Content{}, &entities.Author{}, &entities.Tag{}, &entities.ContentType{}); err != nil {
 		return fmt.Errorf("テーブル削除に失敗しました: %w", err)
 	}
 
@@ -52,6 +129,34 @@ func createIndexes(db *gorm.DB) error {
 			name:  "idx_contents_created_at",
 			query: "CREATE INDEX IF NOT EXISTS idx_contents_created_at ON contents(created_at)",
 		},
+		{
+			name:  "idx_contents_created_at_id",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_created_at_id ON contents(created_at DESC, id DESC)",
+		},
+		{
+			name:  "idx_content_tags_tag_id",
+			query: "CREATE INDEX IF NOT EXISTS idx_content_tags_tag_id ON content_tags(tag_id)",
+		},
+		{
+			name:  "idx_contents_status",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_status ON contents(status)",
+		},
+		{
+			name:  "idx_contents_search_vector",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_search_vector ON contents USING GIN (search_vector)",
+		},
+		{
+			name:  "idx_contents_title_trgm",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_title_trgm ON contents USING GIN (title gin_trgm_ops)",
+		},
+		{
+			name:  "idx_contents_body_trgm",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_body_trgm ON contents USING GIN (body gin_trgm_ops)",
+		},
+		{
+			name:  "idx_contents_fields",
+			query: "CREATE INDEX IF NOT EXISTS idx_contents_fields ON contents USING GIN (fields jsonb_path_ops)",
+		},
 	}
 
 	for _, idx := range indexes {
@@ -64,33 +169,147 @@ func createIndexes(db *gorm.DB) error {
 	return nil
 }
 
+// addSearchColumns は全文検索用の生成列と、日本語の部分一致検索に使う pg_trgm 拡張を追加する
+// 日本語は空白で分かち書きされないため、tsvector は言語に依存しない simple 設定で作成する
+func addSearchColumns(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`
+		ALTER TABLE contents ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce(body, '')), 'B')
+		) STORED`,
+	).Error
+}
+
+// backfillContentStatus は既存のコンテンツを公開済みに移行する
+func backfillContentStatus(db *gorm.DB) error {
+	log.Println("既存コンテンツのステータスを公開済みに移行しています...")
+
+	return db.Exec(
+		"UPDATE contents SET status = ?, published_at = created_at",
+		entities.StatusPublished,
+	).Error
+}
+
+// authorTrimChars は作成者名の前後から取り除く空白文字
+// PostgreSQL の btrim は既定で半角スペースのみを取り除くため、strings.TrimSpace と同様に全角スペースなども指定する
+const authorTrimChars = " \t\n\v\f\r\u0085\u00a0\u3000"
+
+// backfillAuthors は作成者を参照していないコンテンツの作成者名から作成者を登録し、参照を設定する
+// 前後の空白のみが異なる作成者名は同じ作成者として扱い、コンテンツの作成者名も揃える
+func backfillAuthors(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO authors (name, email, created_at, updated_at)
+			SELECT DISTINCT btrim(author, ?), '', NOW(), NOW()
+			FROM contents
+			WHERE author_id IS NULL
+			ON CONFLICT (name) DO NOTHING`,
+			authorTrimChars,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE contents c
+			SET author_id = a.id, author = a.name
+			FROM authors a
+			WHERE c.author_id IS NULL AND a.name = btrim(c.author, ?)`,
+			authorTrimChars,
+		).Error
+	})
+}
+
+// migrateAllowedAuthors はコンテンツタイプの許可する作成者を作成者名から作成者IDに置き換える
+// 未登録の作成者名で制限が外れないよう、先に作成者として登録する
+func migrateAllowedAuthors(db *gorm.DB) error {
+	const namedTypes = `EXISTS (SELECT 1 FROM jsonb_array_elements(allowed_authors) AS e WHERE jsonb_typeof(e) = 'string')`
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO authors (name, email, created_at, updated_at)
+			SELECT DISTINCT btrim(n.value, ?), '', NOW(), NOW()
+			FROM content_types, jsonb_array_elements_text(allowed_authors) AS n(value)
+			WHERE `+namedTypes+`
+			ON CONFLICT (name) DO NOTHING`,
+			authorTrimChars,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE content_types
+			SET allowed_authors = (
+				SELECT jsonb_agg(DISTINCT a.id)
+				FROM jsonb_array_elements_text(allowed_authors) AS n(value)
+				JOIN authors a ON a.name = btrim(n.value, ?)
+			)
+			WHERE `+namedTypes,
+			authorTrimChars,
+		).Error
+	})
+}
+
+// seedContentTypes は初期コンテンツタイプを登録する（登録済みのものは変更しない）
+func seedContentTypes(db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(entities.DefaultContentTypes()).Error
+}
+
+// backfillInitialRevisions はリビジョンを持たないコンテンツに現在の内容で最初のリビジョンを作成する
+func backfillInitialRevisions(db *gorm.DB) error {
+	return db.Exec(`
+		INSERT INTO content_revisions
+			(content_id, revision_number, title, body, content_type, author, fields, changed_fields, editor, created_at)
+		SELECT c.id, 1, c.title, c.body, c.content_type, c.author, c.fields, ?, c.author, c.updated_at
+		FROM contents c
+		WHERE NOT EXISTS (SELECT 1 FROM content_revisions r WHERE r.content_id = c.id)`,
+		`["title","body","content_type","author","fields"]`,
+	).Error
+}
+
 func SeedSampleData(db *gorm.DB) error {
 	log.Println("サンプルデータを投入しています...")
 
+	publishedAt := time.Now()
 	sampleContents := []entities.Content{
 		{
 			Title:       "サンプル記事1",
 			Body:        "これは最初のサンプル記事です。コンテンツ管理システムのテスト用データです。",
 			ContentType: "article",
 			Author:      "システム管理者",
+			Status:      entities.StatusPublished,
+			PublishedAt: &publishedAt,
 		},
 		{
 			Title:       "サンプルブログ1",
 			Body:        "ブログ形式のサンプル投稿です。日常的な情報を共有する際に使用します。",
 			ContentType: "blog",
 			Author:      "ブログ投稿者",
+			Status:      entities.StatusPublished,
+			PublishedAt: &publishedAt,
 		},
 		{
 			Title:       "重要なお知らせ",
 			Body:        "システムメンテナンスに関する重要なお知らせです。",
 			ContentType: "news",
 			Author:      "運営チーム",
+			Status:      entities.StatusPublished,
+			PublishedAt: &publishedAt,
 		},
 		{
 			Title:       "利用規約",
 			Body:        "本サービスの利用規約について説明しています。",
 			ContentType: "page",
 			Author:      "法務チーム",
+			Status:      entities.StatusPublished,
+			PublishedAt: &publishedAt,
 		},
 	}
 
@@ -100,6 +319,35 @@ func SeedSampleData(db *gorm.DB) error {
 		}
 	}
 
+	if err := backfillAuthors(db); err != nil {
+		return fmt.Errorf("サンプルデータの作成者の登録に失敗しました: %w", err)
+	}
+
 	log.Printf("サンプルデータ %d 件を投入しました", len(sampleContents))
 	return nil
 }
+
+func recordSchemaVersion(db *gorm.DB) error {
+	if err := db.AutoMigrate(&schemaVersion{}); err != nil {
+		return err
+	}
+	// 新しいバージョンのインスタンスと混在している場合に古いバージョンで上書きしないよう、記録より新しい場合のみ更新する
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "migrated_at"}),
+		Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "schema_version.version < EXCLUDED.version"}}},
+	}).Create(&schemaVersion{ID: 1, Version: SchemaVersion, MigratedAt: time.Now()}).Error
+}
+
+// AppliedSchemaVersion はデータベースに適用済みのスキーマのバージョンを返す（記録がない場合は0）
+func AppliedSchemaVersion(ctx context.Context, db *gorm.DB) (int, error) {
+	var applied schemaVersion
+	err := db.WithContext(ctx).Take(&applied, 1).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return applied.Version, nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"go-api-server-sample/internal/infrastructure/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// tracingSpanKey は実行中のSQLのスパンをStatementに保存するキー
+const tracingSpanKey = "tracing:span"
+
+// tracingPlugin はSQLの実行ごとに、コンテキストのスパン（リクエストのサーバースパンなど）の子スパンを作成するGORMのプラグイン
+// スパンにはプレースホルダーを含むSQLを記録し、パラメーターの値は記録しない
+type tracingPlugin struct{}
+
+func (tracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (tracingPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startSpan),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endSpan),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startSpan),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endSpan),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startSpan),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endSpan),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startSpan),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endSpan),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan),
+	)
+}
+
+// startSpan はSQLのスパンを開始する
+// 同じStatementで続けて実行するSQL（件数の集計と一覧の取得など）が入れ子にならないよう、
+// Statementのコンテキストは変更せずにスパンを保存する
+func startSpan(db *gorm.DB) {
+	if db.Statement.Context == nil {
+		return
+	}
+	_, span := tracing.Tracer().Start(db.Statement.Context, "gorm", trace.WithSpanKind(trace.SpanKindClient))
+	db.InstanceSet(tracingSpanKey, span)
+}
+
+// endSpan は実行したSQLとその結果をスパンに記録して終了する
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// トランザクションの開始に失敗した場合など、SQLを組み立てる前に終了した場合はSQLが空になる
+	sql := db.Statement.SQL.String()
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemNamePostgreSQL,
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	}
+	if sql != "" {
+		operation := operationName(sql)
+		attrs = append(attrs, semconv.DBQueryText(sql), semconv.DBOperationName(operation))
+		span.SetName(strings.TrimSpace(operation + " " + db.Statement.Table))
+	}
+	if table := db.Statement.Table; table != "" {
+		attrs = append(attrs, semconv.DBCollectionName(table))
+	}
+	span.SetAttributes(attrs...)
+
+	// レコードが見つからないことは通常の結果として扱う
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// operationName はSQLの最初のキーワード（SELECT, INSERT など）を返す
+func operationName(sql string) string {
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	return strings.ToUpper(keyword)
+}
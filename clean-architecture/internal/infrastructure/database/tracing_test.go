@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"go-api-server-sample/internal/domain/entities"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type TracingPluginTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	recorder *tracetest.SpanRecorder
+}
+
+func (suite *TracingPluginTestSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+
+	// DryRun のためSQLは実行しない（接続もしない）
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:1/test")
+	suite.Require().NoError(err)
+	suite.db, err = gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.db.Use(tracingPlugin{}))
+}
+
+func (suite *TracingPluginTestSuite) TestSpans() {
+	suite.Run("SQLごとにコンテキストのスパンの子スパンを作成する", func() {
+		// Given
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+
+		// When
+		query := suite.db.WithContext(ctx).Model(&entities.Content{}).Where("content_type = ?", "article")
+		var total int64
+		query.Count(&total)
+		var contents []*entities.Content
+		query.Find(&contents)
+		parent.End()
+
+		// Then
+		spans := suite.recorder.Ended()
+		suite.Require().Len(spans, 3)
+		for _, span := range spans[:2] {
+			assert.Equal(suite.T(), parent.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(suite.T(), trace.SpanKindClient, span.SpanKind())
+			assert.Equal(suite.T(), "SELECT contents", span.Name())
+		}
+
+		attrs := map[string]string{}
+		for _, attr := range spans[1].Attributes() {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		assert.Equal(suite.T(), "postgresql", attrs["db.system.name"])
+		assert.Equal(suite.T(), "contents", attrs["db.collection.name"])
+		assert.Contains(suite.T(), attrs["db.query.text"], "content_type = $1")
+		assert.NotContains(suite.T(), attrs["db.query.text"], "article")
+	})
+
+	suite.Run("コンテキストのスパンがない場合は新しいトレースを開始する", func() {
+		// When
+		suite.db.WithContext(context.Background()).Delete(&entities.Content{}, 1)
+
+		// Then
+		spans := suite.recorder.Ended()
+		suite.Require().NotEmpty(spans)
+		last := spans[len(spans)-1]
+		assert.Equal(suite.T(), "UPDATE contents", last.Name()) // 論理削除
+		assert.False(suite.T(), last.Parent().IsValid())
+	})
+}
+
+func TestTracingPluginTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingPluginTestSuite))
+}
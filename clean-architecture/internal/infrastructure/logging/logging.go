@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// contextKey はcontext.Contextにロガーを保存するキー
+type contextKey struct{}
+
+// New は level（debug, info, warn, error）と format（json, text）に従って w に出力するロガーを作成する
+// level に silent を指定した場合はログを出力しない
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	if strings.EqualFold(level, "silent") {
+		return slog.New(slog.DiscardHandler), nil
+	}
+
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("ログレベル %q は指定できません", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("ログ形式 %q は指定できません", format)
+	}
+}
+
+// WithContext は logger を保存したコンテキストを返す
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext はコンテキストに保存されたロガーを返す。保存されていない場合は slog.Default() を返す
+// リクエストのコンテキストのロガーには、リクエストIDと認証済みのユーザーが付与されている
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With はコンテキストのロガーに args の属性を追加したコンテキストを返す
+func With(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
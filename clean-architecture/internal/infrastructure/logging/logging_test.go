@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggingTestSuite) TestNew() {
+	suite.Run("指定したレベル未満のログは出力しない", func() {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "warn", "json")
+		suite.Require().NoError(err)
+
+		logger.Info("info")
+		logger.Warn("warn", "key", "value")
+
+		var line map[string]any
+		suite.Require().NoError(json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(suite.T(), "warn", line["msg"])
+		assert.Equal(suite.T(), "value", line["key"])
+	})
+
+	suite.Run("text形式で出力する", func() {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "INFO", "text")
+		suite.Require().NoError(err)
+
+		logger.Info("message")
+
+		assert.Contains(suite.T(), buf.String(), "msg=message")
+	})
+
+	suite.Run("不正なレベル・形式はエラー", func() {
+		_, err := New(&bytes.Buffer{}, "verbose", "json")
+		assert.Error(suite.T(), err)
+
+		_, err = New(&bytes.Buffer{}, "info", "xml")
+		assert.Error(suite.T(), err)
+	})
+}
+
+func (suite *LoggingTestSuite) TestFromContext() {
+	suite.Run("保存されていない場合はデフォルトのロガー", func() {
+		assert.Same(suite.T(), slog.Default(), FromContext(context.Background()))
+	})
+
+	suite.Run("With で追加した属性がログに含まれる", func() {
+		var buf bytes.Buffer
+		logger, _ := New(&buf, "info", "json")
+		ctx := With(WithContext(context.Background(), logger), "request_id", "req-1")
+
+		FromContext(ctx).Info("message")
+
+		var line map[string]any
+		suite.Require().NoError(json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(suite.T(), "req-1", line["request_id"])
+	})
+}
+
+func TestLoggingTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggingTestSuite))
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName はこのアプリケーションが作成するスパンの計装名
+const instrumentationName = "go-api-server-sample"
+
+// スパンの送信先
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config はトレースの設定
+type Config struct {
+	// Exporter はスパンの送信先（none, stdout, otlp）
+	Exporter string
+	// OTLPEndpoint は otlp の場合の送信先（host:port）で、OTLP/HTTPで送信する
+	OTLPEndpoint string
+	// OTLPInsecure が true の場合はTLSを使わずに送信する
+	OTLPInsecure bool
+	// SampleRatio は記録するトレースの割合（0〜1）。親スパンがある場合は親のサンプリングに従う
+	SampleRatio float64
+	// ServiceName はスパンに付与するサービス名
+	ServiceName string
+}
+
+// Setup は cfg に従ってトレーサーとW3C Trace Context（traceparent）の伝播を設定し、
+// 終了時に送信待ちのスパンを送信する関数を返す
+// Exporter が none の場合もトレースIDは伝播・採番するが、スパンは送信しない
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("トレースのリソースの作成に失敗しました: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(cfg.Exporter) {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		if cfg.OTLPEndpoint == "" {
+			return nil, errors.New("OTLPの送信先が指定されていません")
+		}
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("トレースの送信先 %q は指定できません", cfg.Exporter)
+	}
+}
+
+// Tracer はこのアプリケーションのスパンを作成するトレーサーを返す
+// Setup を呼ぶ前はスパンを記録しないトレーサーを返す
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TracingTestSuite) TestSetup() {
+	suite.Run("送信先がない場合もトレースIDを採番して伝播する", func() {
+		shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone, SampleRatio: 1, ServiceName: "test"})
+		suite.Require().NoError(err)
+		defer shutdown(context.Background())
+
+		ctx, span := Tracer().Start(context.Background(), "test")
+		defer span.End()
+
+		header := http.Header{}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+
+		assert.True(suite.T(), span.SpanContext().IsValid())
+		assert.Contains(suite.T(), header.Get("traceparent"), span.SpanContext().TraceID().String())
+	})
+
+	suite.Run("stdoutに送信する", func() {
+		shutdown, err := Setup(context.Background(), Config{Exporter: "STDOUT", SampleRatio: 1, ServiceName: "test"})
+		suite.Require().NoError(err)
+		assert.NoError(suite.T(), shutdown(context.Background()))
+	})
+
+	suite.Run("不正な送信先はエラー", func() {
+		_, err := Setup(context.Background(), Config{Exporter: "zipkin"})
+		assert.Error(suite.T(), err)
+
+		_, err = Setup(context.Background(), Config{Exporter: ExporterOTLP})
+		assert.Error(suite.T(), err)
+	})
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}